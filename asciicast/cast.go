@@ -0,0 +1,147 @@
+package asciicast
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"math"
+	"strings"
+	"time"
+)
+
+// Version is the version of the asciicast format supported by this package.
+const Version = 2
+
+// EventType defines the type of an Event.
+type EventType string
+
+// Supported EventTypes.
+const (
+	EventTypeInput  EventType = "i" // data read from the keyboard
+	EventTypeMarker EventType = "m" // a marker (or a breakpoint) with a label
+	EventTypeOutput EventType = "o" // data written to the terminal
+	EventTypeResize EventType = "r" // new size of the terminal as "WxH"
+)
+
+// MarkerPrompt is the label of the Marker recorded every time the prompt is
+// ready for input; the Player waits for the next prompt when it sees one.
+const MarkerPrompt = "prompt"
+
+// Header is the first line of an asciicast file with the details about the
+// recording.
+type Header struct {
+	Version       int               `json:"version"`
+	Width         int               `json:"width"`
+	Height        int               `json:"height"`
+	Timestamp     int64             `json:"timestamp,omitempty"`
+	IdleTimeLimit float64           `json:"idle_time_limit,omitempty"`
+	Command       string            `json:"command,omitempty"`
+	Title         string            `json:"title,omitempty"`
+	Env           map[string]string `json:"env,omitempty"`
+}
+
+// Event is something that happened at a point in time during the recording.
+type Event struct {
+	Time time.Duration // time since the beginning of the recording
+	Type EventType
+	Data string
+}
+
+// MarshalJSON marshals the Event into an array like [1.234567, "o", "data"].
+func (e Event) MarshalJSON() ([]byte, error) {
+	out := bytes.Buffer{}
+	encoder := json.NewEncoder(&out)
+	encoder.SetEscapeHTML(false)
+	err := encoder.Encode([]any{
+		math.Round(e.Time.Seconds()*1e6) / 1e6,
+		e.Type,
+		e.Data,
+	})
+	return bytes.TrimSuffix(out.Bytes(), []byte("\n")), err
+}
+
+// UnmarshalJSON unmarshals an array in the format generated by MarshalJSON
+// into the Event.
+func (e *Event) UnmarshalJSON(data []byte) error {
+	var fields []json.RawMessage
+	if err := json.Unmarshal(data, &fields); err != nil {
+		return err
+	}
+	if len(fields) != 3 {
+		return fmt.Errorf("%w: found %d fields instead of 3", ErrInvalidEvent, len(fields))
+	}
+
+	var seconds float64
+	if err := json.Unmarshal(fields[0], &seconds); err != nil {
+		return fmt.Errorf("%w: bad time: %v", ErrInvalidEvent, err)
+	}
+	if err := json.Unmarshal(fields[1], &e.Type); err != nil {
+		return fmt.Errorf("%w: bad type: %v", ErrInvalidEvent, err)
+	}
+	if err := json.Unmarshal(fields[2], &e.Data); err != nil {
+		return fmt.Errorf("%w: bad data: %v", ErrInvalidEvent, err)
+	}
+	e.Time = time.Duration(seconds * float64(time.Second))
+	return nil
+}
+
+// Cast is a recording in the asciicast format.
+type Cast struct {
+	Header Header
+	Events []Event
+}
+
+// Read reads a Cast from the given Reader.
+func Read(r io.Reader) (*Cast, error) {
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(nil, 1024*1024)
+
+	cast, lineNum := &Cast{}, 0
+	for scanner.Scan() {
+		lineNum++
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" {
+			continue
+		}
+
+		if lineNum == 1 {
+			if err := json.Unmarshal([]byte(line), &cast.Header); err != nil {
+				return nil, fmt.Errorf("failed to parse header: %w", err)
+			}
+			if cast.Header.Version != Version {
+				return nil, fmt.Errorf("%w: %d", ErrUnsupportedVersion, cast.Header.Version)
+			}
+			continue
+		}
+
+		var event Event
+		if err := json.Unmarshal([]byte(line), &event); err != nil {
+			return nil, fmt.Errorf("failed to parse line #%d: %w", lineNum, err)
+		}
+		cast.Events = append(cast.Events, event)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+	if lineNum == 0 {
+		return nil, ErrEmpty
+	}
+	return cast, nil
+}
+
+// Write writes the Cast to the given Writer.
+func (c *Cast) Write(w io.Writer) error {
+	encoder := json.NewEncoder(w)
+	encoder.SetEscapeHTML(false)
+	if err := encoder.Encode(c.Header); err != nil {
+		return err
+	}
+	for _, event := range c.Events {
+		if err := encoder.Encode(event); err != nil {
+			return err
+		}
+	}
+	return nil
+}
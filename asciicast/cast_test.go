@@ -0,0 +1,77 @@
+package asciicast
+
+import (
+	"encoding/json"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestEvent_MarshalJSON(t *testing.T) {
+	b, err := Event{Time: time.Millisecond * 1500, Type: EventTypeOutput, Data: "> foo\r\n"}.MarshalJSON()
+	assert.Nil(t, err)
+	assert.Equal(t, `[1.5,"o","> foo\r\n"]`, string(b))
+}
+
+func TestEvent_UnmarshalJSON(t *testing.T) {
+	var event Event
+	err := json.Unmarshal([]byte(`[1.234567, "i", "\u001b[A"]`), &event)
+	assert.Nil(t, err)
+	assert.Equal(t, Event{Time: time.Microsecond * 1234567, Type: EventTypeInput, Data: "\x1b[A"}, event)
+
+	for _, data := range []string{`{}`, `[1, "o"]`, `["1", "o", "foo"]`, `[1, 2, "foo"]`, `[1, "o", 3]`} {
+		err = json.Unmarshal([]byte(data), &event)
+		assert.NotNil(t, err, data)
+	}
+	err = json.Unmarshal([]byte(`[1, "o"]`), &event)
+	assert.True(t, errors.Is(err, ErrInvalidEvent))
+}
+
+func TestRead(t *testing.T) {
+	t.Run("valid", func(t *testing.T) {
+		cast, err := Read(strings.NewReader(`{"version": 2, "width": 80, "height": 24, "title": "demo"}
+[0.1, "m", "prompt"]
+
+[0.25, "o", "> "]
+[1, "i", "a"]
+`))
+		assert.Nil(t, err)
+		assert.Equal(t, Header{Version: 2, Width: 80, Height: 24, Title: "demo"}, cast.Header)
+		assert.Equal(t, []Event{
+			{Time: time.Second / 10, Type: EventTypeMarker, Data: MarkerPrompt},
+			{Time: time.Second / 4, Type: EventTypeOutput, Data: "> "},
+			{Time: time.Second, Type: EventTypeInput, Data: "a"},
+		}, cast.Events)
+
+		out := strings.Builder{}
+		assert.Nil(t, cast.Write(&out))
+		assert.Equal(t, `{"version":2,"width":80,"height":24,"title":"demo"}
+[0.1,"m","prompt"]
+[0.25,"o","> "]
+[1,"i","a"]
+`, out.String())
+	})
+
+	t.Run("empty", func(t *testing.T) {
+		_, err := Read(strings.NewReader(""))
+		assert.True(t, errors.Is(err, ErrEmpty))
+	})
+
+	t.Run("bad header", func(t *testing.T) {
+		_, err := Read(strings.NewReader("foo"))
+		assert.NotNil(t, err)
+		assert.Contains(t, err.Error(), "failed to parse header")
+
+		_, err = Read(strings.NewReader(`{"version": 1}`))
+		assert.True(t, errors.Is(err, ErrUnsupportedVersion))
+	})
+
+	t.Run("bad event", func(t *testing.T) {
+		_, err := Read(strings.NewReader(`{"version": 2}` + "\n" + `[1, "o"]`))
+		assert.True(t, errors.Is(err, ErrInvalidEvent))
+		assert.Contains(t, err.Error(), "line #2")
+	})
+}
@@ -0,0 +1,13 @@
+package asciicast
+
+import "errors"
+
+// ErrEmpty is returned when reading a Cast with nothing in it.
+var ErrEmpty = errors.New("empty cast")
+
+// ErrInvalidEvent is returned when an Event is not in the expected format.
+var ErrInvalidEvent = errors.New("invalid event")
+
+// ErrUnsupportedVersion is returned when reading a Cast with a version of the
+// asciicast format other than Version.
+var ErrUnsupportedVersion = errors.New("unsupported version")
@@ -0,0 +1,215 @@
+package asciicast
+
+import (
+	"strings"
+	"unicode/utf8"
+
+	"github.com/jedib0t/go-prompter/prompt"
+)
+
+const (
+	pasteStart = "\x1b[200~"
+	pasteEnd   = "\x1b[201~"
+)
+
+var (
+	// keySequenceInputMap contains what an xterm-like terminal sends when the
+	// key is pressed.
+	keySequenceInputMap = map[prompt.KeySequence]string{
+		prompt.ArrowDown:           "\x1b[B",
+		prompt.ArrowLeft:           "\x1b[D",
+		prompt.ArrowRight:          "\x1b[C",
+		prompt.ArrowUp:             "\x1b[A",
+		prompt.Backspace:           "\x7f",
+		prompt.CtrlA:               "\x01",
+		prompt.CtrlArrowDown:       "\x1b[1;5B",
+		prompt.CtrlArrowLeft:       "\x1b[1;5D",
+		prompt.CtrlArrowRight:      "\x1b[1;5C",
+		prompt.CtrlArrowUp:         "\x1b[1;5A",
+		prompt.CtrlB:               "\x02",
+		prompt.CtrlC:               "\x03",
+		prompt.CtrlD:               "\x04",
+		prompt.CtrlE:               "\x05",
+		prompt.CtrlEnd:             "\x1b[1;5F",
+		prompt.CtrlF:               "\x06",
+		prompt.CtrlG:               "\x07",
+		prompt.CtrlH:               "\x08",
+		prompt.CtrlHome:            "\x1b[1;5H",
+		prompt.CtrlI:               "\t",
+		prompt.CtrlJ:               "\n",
+		prompt.CtrlK:               "\x0b",
+		prompt.CtrlL:               "\x0c",
+		prompt.CtrlM:               "\r",
+		prompt.CtrlN:               "\x0e",
+		prompt.CtrlO:               "\x0f",
+		prompt.CtrlP:               "\x10",
+		prompt.CtrlQ:               "\x11",
+		prompt.CtrlR:               "\x12",
+		prompt.CtrlS:               "\x13",
+		prompt.CtrlShiftArrowDown:  "\x1b[1;6B",
+		prompt.CtrlShiftArrowLeft:  "\x1b[1;6D",
+		prompt.CtrlShiftArrowRight: "\x1b[1;6C",
+		prompt.CtrlShiftArrowUp:    "\x1b[1;6A",
+		prompt.CtrlShiftEnd:        "\x1b[1;6F",
+		prompt.CtrlShiftHome:       "\x1b[1;6H",
+		prompt.CtrlSpace:           "\x00",
+		prompt.CtrlT:               "\x14",
+		prompt.CtrlU:               "\x15",
+		prompt.CtrlV:               "\x16",
+		prompt.CtrlW:               "\x17",
+		prompt.CtrlX:               "\x18",
+		prompt.CtrlY:               "\x19",
+		prompt.CtrlZ:               "\x1a",
+		prompt.Delete:              "\x1b[3~",
+		prompt.End:                 "\x1b[F",
+		prompt.Enter:               "\r",
+		prompt.Escape:              "\x1b",
+		prompt.F1:                  "\x1bOP",
+		prompt.F10:                 "\x1b[21~",
+		prompt.F11:                 "\x1b[23~",
+		prompt.F12:                 "\x1b[24~",
+		prompt.F2:                  "\x1bOQ",
+		prompt.F3:                  "\x1bOR",
+		prompt.F4:                  "\x1bOS",
+		prompt.F5:                  "\x1b[15~",
+		prompt.F6:                  "\x1b[17~",
+		prompt.F7:                  "\x1b[18~",
+		prompt.F8:                  "\x1b[19~",
+		prompt.F9:                  "\x1b[20~",
+		prompt.Home:                "\x1b[H",
+		prompt.Insert:              "\x1b[2~",
+		prompt.PageDown:            "\x1b[6~",
+		prompt.PageUp:              "\x1b[5~",
+		prompt.ShiftArrowDown:      "\x1b[1;2B",
+		prompt.ShiftArrowLeft:      "\x1b[1;2D",
+		prompt.ShiftArrowRight:     "\x1b[1;2C",
+		prompt.ShiftArrowUp:        "\x1b[1;2A",
+		prompt.ShiftEnd:            "\x1b[1;2F",
+		prompt.ShiftHome:           "\x1b[1;2H",
+		prompt.ShiftTab:            "\x1b[Z",
+		prompt.Space:               " ",
+		prompt.Tab:                 "\t",
+	}
+	// inputKeySequenceMap is the reverse of keySequenceInputMap along with a
+	// few alternatives sent by some terminals.
+	inputKeySequenceMap = map[string]prompt.KeySequence{
+		"\x1b[1~": prompt.Home,
+		"\x1b[4~": prompt.End,
+		"\x1b[7~": prompt.Home,
+		"\x1b[8~": prompt.End,
+		"\x1bOA":  prompt.ArrowUp,
+		"\x1bOB":  prompt.ArrowDown,
+		"\x1bOC":  prompt.ArrowRight,
+		"\x1bOD":  prompt.ArrowLeft,
+		"\x1bOF":  prompt.End,
+		"\x1bOH":  prompt.Home,
+	}
+	// inputKeySequenceMaxLen is the length of the longest input in
+	// inputKeySequenceMap.
+	inputKeySequenceMaxLen = 0
+)
+
+func init() {
+	for r := 'a'; r <= 'z'; r++ {
+		keySequenceInputMap[prompt.KeySequence("alt+"+string(r))] = "\x1b" + string(r)
+	}
+	for ks, input := range keySequenceInputMap {
+		switch ks {
+		case prompt.CtrlI, prompt.CtrlM, prompt.Space: // same as Tab, Enter, " "
+			continue
+		}
+		inputKeySequenceMap[input] = ks
+	}
+	for input := range inputKeySequenceMap {
+		if len(input) > inputKeySequenceMaxLen {
+			inputKeySequenceMaxLen = len(input)
+		}
+	}
+}
+
+// decodeInput returns the KeyPresses that the terminal input is made up of.
+// Printable text is kept together in one KeyPress, like it is when typed
+// faster than it can be handled, unless it is a lone space.
+func decodeInput(input string) []prompt.KeyPress {
+	var rsp []prompt.KeyPress
+	text := strings.Builder{}
+	flushText := func() {
+		if text.String() == keySequenceInputMap[prompt.Space] {
+			rsp = append(rsp, prompt.KeyPress{KeySequence: prompt.Space})
+		} else if text.Len() > 0 {
+			rsp = append(rsp, prompt.KeyPress{Text: text.String()})
+		}
+		text.Reset()
+	}
+
+	for len(input) > 0 {
+		if strings.HasPrefix(input, pasteStart) {
+			flushText()
+			pasted := strings.TrimPrefix(input, pasteStart)
+			input = ""
+			if idx := strings.Index(pasted, pasteEnd); idx >= 0 {
+				pasted, input = pasted[:idx], pasted[idx+len(pasteEnd):]
+			}
+			rsp = append(rsp, prompt.KeyPress{Paste: true, Text: pasted})
+			continue
+		}
+
+		if ks, size := decodeKeySequence(input); size > 0 {
+			flushText()
+			if ks != "" {
+				rsp = append(rsp, prompt.KeyPress{KeySequence: ks})
+			}
+			input = input[size:]
+			continue
+		}
+
+		r, size := utf8.DecodeRuneInString(input)
+		text.WriteRune(r)
+		input = input[size:]
+	}
+	flushText()
+	return rsp
+}
+
+// decodeKeySequence returns the KeySequence at the beginning of the input, and
+// the number of bytes it takes up; zero if the input begins with text. Control
+// characters and escape sequences that are not known are skipped.
+func decodeKeySequence(input string) (prompt.KeySequence, int) {
+	maxLen := inputKeySequenceMaxLen
+	if maxLen > len(input) {
+		maxLen = len(input)
+	}
+	for size := maxLen; size > 1; size-- {
+		if ks, ok := inputKeySequenceMap[input[:size]]; ok {
+			return ks, size
+		}
+	}
+
+	if strings.HasPrefix(input, "\x1b[") {
+		// skip over the unknown control sequence up to the final byte
+		for idx := 2; idx < len(input); idx++ {
+			if input[idx] >= 0x40 && input[idx] <= 0x7e {
+				return "", idx + 1
+			}
+		}
+		return "", len(input)
+	}
+	if ks, ok := inputKeySequenceMap[input[:1]]; ok {
+		return ks, 1
+	}
+	if input[0] < 0x20 || input[0] == 0x7f {
+		return "", 1
+	}
+	return "", 0
+}
+
+// encodeKeyPress returns what the terminal sends for the KeyPress.
+func encodeKeyPress(kp prompt.KeyPress) string {
+	if kp.KeySequence != "" {
+		return keySequenceInputMap[kp.KeySequence]
+	}
+	if kp.Paste {
+		return pasteStart + kp.Text + pasteEnd
+	}
+	return kp.Text
+}
@@ -0,0 +1,54 @@
+package asciicast
+
+import (
+	"testing"
+
+	"github.com/jedib0t/go-prompter/prompt"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_decodeInput(t *testing.T) {
+	assert.Empty(t, decodeInput(""))
+	assert.Equal(t, []prompt.KeyPress{
+		{Text: "select 1"},
+		{KeySequence: prompt.Tab},
+		{Text: "héllo"},
+		{KeySequence: prompt.Enter},
+		{KeySequence: prompt.ArrowUp},
+		{KeySequence: prompt.ArrowUp},
+		{KeySequence: prompt.CtrlShiftArrowLeft},
+		{KeySequence: prompt.AltF},
+		{KeySequence: prompt.Escape},
+		{Paste: true, Text: "foo\nbar"},
+		{KeySequence: prompt.Space},
+		{Text: "x"},
+	}, decodeInput("select 1\théllo\r\x1b[A\x1bOA\x1b[1;6D\x1bf\x1b\x1b[200~foo\nbar\x1b[201~ \x1b[99;99X\x1cx"))
+
+	// paste without an end
+	assert.Equal(t, []prompt.KeyPress{{Paste: true, Text: "foo"}}, decodeInput("\x1b[200~foo"))
+	// unknown control sequence without an end
+	assert.Equal(t, []prompt.KeyPress{{Text: "a"}}, decodeInput("a\x1b[12"))
+}
+
+func Test_encodeKeyPress(t *testing.T) {
+	assert.Equal(t, "", encodeKeyPress(prompt.KeyPress{}))
+	assert.Equal(t, "", encodeKeyPress(prompt.KeyPress{KeySequence: "foo"}))
+	assert.Equal(t, "foo", encodeKeyPress(prompt.KeyPress{Text: "foo"}))
+	assert.Equal(t, "\x1b[200~foo\nbar\x1b[201~", encodeKeyPress(prompt.KeyPress{Paste: true, Text: "foo\nbar"}))
+
+	// every KeySequence decodes back into itself (or its alias)
+	aliases := map[prompt.KeySequence]prompt.KeySequence{
+		prompt.CtrlI: prompt.Tab,
+		prompt.CtrlM: prompt.Enter,
+	}
+	for ks := range keySequenceInputMap {
+		expected := ks
+		if alias, ok := aliases[ks]; ok {
+			expected = alias
+		}
+		input := encodeKeyPress(prompt.KeyPress{KeySequence: ks})
+		assert.NotEmpty(t, input, ks)
+		assert.Equal(t, []prompt.KeyPress{{KeySequence: expected}}, decodeInput(input), ks)
+	}
+	assert.Equal(t, "\x1bz", keySequenceInputMap[prompt.AltZ])
+}
@@ -0,0 +1,173 @@
+package asciicast
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/jedib0t/go-prompter/prompt"
+)
+
+// Player plays back the key-presses in a Cast into a Prompter. The key-presses
+// recorded after each MarkerPrompt are sent only once the Prompter is ready
+// for input again, so that none of them are lost in between the prompts.
+type Player struct {
+	cast          *Cast
+	mutex         sync.Mutex
+	numPrompts    int
+	prompter      prompt.Prompter
+	promptStarted chan bool
+	recorder      prompt.Recorder
+	speed         float64
+}
+
+// PlayerOption helps customize the Player.
+type PlayerOption func(pl *Player)
+
+// WithRecorder passes on everything the Prompter records to the given
+// Recorder, like to record the session being played back.
+func WithRecorder(recorder prompt.Recorder) PlayerOption {
+	return func(pl *Player) {
+		pl.recorder = recorder
+	}
+}
+
+// WithSpeed plays back the key-presses faster (or slower) than they were
+// recorded; use 0 to send them without any delays in between.
+func WithSpeed(speed float64) PlayerOption {
+	return func(pl *Player) {
+		pl.speed = speed
+	}
+}
+
+// NewPlayer returns a Player to play back the Cast into the Prompter. This
+// sets up the Recorder of the Prompter to know when it is ready for input, and
+// so has to be called before the Prompter starts prompting.
+func NewPlayer(p prompt.Prompter, cast *Cast, opts ...PlayerOption) *Player {
+	pl := &Player{
+		cast:          cast,
+		prompter:      p,
+		promptStarted: make(chan bool, 1),
+		speed:         1,
+	}
+	for _, opt := range opts {
+		opt(pl)
+	}
+	p.SetRecorder(pl)
+	return pl
+}
+
+// Play sends the key-presses in the Cast to the Prompter with the same delays
+// in between as when they were recorded (adjusted for the speed and the idle
+// time limit of the Cast). Returns once all of them have been sent.
+func (pl *Player) Play(ctx context.Context) error {
+	var numPrompts int
+	var timePrev time.Duration
+	for _, event := range pl.cast.Events {
+		if err := pl.sleep(ctx, event.Time-timePrev); err != nil {
+			return err
+		}
+		timePrev = event.Time
+
+		switch event.Type {
+		case EventTypeMarker:
+			if event.Data == MarkerPrompt {
+				numPrompts++
+				if err := pl.waitForPrompt(ctx, numPrompts); err != nil {
+					return err
+				}
+			}
+		case EventTypeInput:
+			// casts without markers are played into the first prompt
+			if numPrompts == 0 {
+				numPrompts++
+				if err := pl.waitForPrompt(ctx, numPrompts); err != nil {
+					return err
+				}
+			}
+
+			var keyPresses []any
+			for _, kp := range decodeInput(event.Data) {
+				keyPresses = append(keyPresses, kp)
+			}
+			if err := pl.prompter.SendInput(keyPresses); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// RecordKeyPress passes on the key-press to the Recorder set up using
+// WithRecorder, if any.
+func (pl *Player) RecordKeyPress(kp prompt.KeyPress) {
+	if pl.recorder != nil {
+		pl.recorder.RecordKeyPress(kp)
+	}
+}
+
+// RecordOutput passes on the output to the Recorder set up using WithRecorder,
+// if any.
+func (pl *Player) RecordOutput(b []byte) {
+	if pl.recorder != nil {
+		pl.recorder.RecordOutput(b)
+	}
+}
+
+// RecordPromptStart lets Play know that the Prompter is ready for input.
+func (pl *Player) RecordPromptStart() {
+	pl.mutex.Lock()
+	pl.numPrompts++
+	pl.mutex.Unlock()
+
+	select {
+	case pl.promptStarted <- true:
+	default: // already notified
+	}
+	if pl.recorder != nil {
+		pl.recorder.RecordPromptStart()
+	}
+}
+
+// RecordResize passes on the resize to the Recorder set up using WithRecorder,
+// if any.
+func (pl *Player) RecordResize(width int, height int) {
+	if pl.recorder != nil {
+		pl.recorder.RecordResize(width, height)
+	}
+}
+
+func (pl *Player) sleep(ctx context.Context, duration time.Duration) error {
+	if pl.speed <= 0 || duration <= 0 {
+		return nil
+	}
+	if limit := time.Duration(pl.cast.Header.IdleTimeLimit * float64(time.Second)); limit > 0 && duration > limit {
+		duration = limit
+	}
+
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-time.After(time.Duration(float64(duration) / pl.speed)):
+		return nil
+	}
+}
+
+// waitForPrompt waits until the Prompter has started prompting the given
+// number of times.
+func (pl *Player) waitForPrompt(ctx context.Context, numPrompts int) error {
+	for {
+		pl.mutex.Lock()
+		done := pl.numPrompts >= numPrompts
+		pl.mutex.Unlock()
+		if done {
+			return nil
+		}
+
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-pl.promptStarted:
+		}
+	}
+}
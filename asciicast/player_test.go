@@ -0,0 +1,142 @@
+package asciicast
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/jedib0t/go-prompter/prompt"
+	"github.com/jedib0t/go-prompter/prompttest"
+	"github.com/stretchr/testify/assert"
+)
+
+func generateTestTerminal(t *testing.T) (*prompttest.Terminal, prompt.Prompter) {
+	p, err := prompt.New()
+	assert.Nil(t, err)
+	p.SetPrefix("> ")
+	p.Style().Cursor.Blink = false
+
+	term, err := prompttest.NewTerminal(p, 40, 6)
+	assert.Nil(t, err)
+	if err != nil {
+		t.FailNow()
+	}
+	return term, p
+}
+
+func promptAndWait(t *testing.T, ctx context.Context, term *prompttest.Terminal, keys ...any) string {
+	assert.Nil(t, term.Prompt(ctx, time.Second*5))
+	assert.Nil(t, term.Send(keys...))
+	userInput, err := term.Result(time.Second * 5)
+	assert.Nil(t, err)
+	return userInput
+}
+
+func TestPlayer(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second*20)
+	defer cancel()
+
+	// record two prompts
+	cast := strings.Builder{}
+	term, p := generateTestTerminal(t)
+	defer term.Close()
+	r, err := NewRecorder(&cast, Header{Width: 40, Height: 6})
+	assert.Nil(t, err)
+	p.SetRecorder(r)
+	assert.Equal(t, "foo", promptAndWait(t, ctx, term, "foo", prompt.Enter))
+	assert.Equal(t, "baz qux", promptAndWait(t, ctx, term,
+		"bar", prompt.Backspace, "z", prompt.Space, prompt.KeyPress{Paste: true, Text: "qux"}, prompt.Enter,
+	))
+	assert.Nil(t, r.Err())
+
+	recording, err := Read(strings.NewReader(cast.String()))
+	assert.Nil(t, err)
+	var numMarkers, numInputs int
+	for _, event := range recording.Events {
+		switch event.Type {
+		case EventTypeInput:
+			numInputs++
+		case EventTypeMarker:
+			numMarkers++
+		}
+	}
+	assert.Equal(t, 2, numMarkers)
+	assert.Equal(t, 12, numInputs)
+
+	// play it back into another prompt, and record that too
+	reRecording := strings.Builder{}
+	term2, p2 := generateTestTerminal(t)
+	defer term2.Close()
+	r2, err := NewRecorder(&reRecording, Header{Width: 40, Height: 6})
+	assert.Nil(t, err)
+	player := NewPlayer(p2, recording, WithRecorder(r2), WithSpeed(0))
+	chPlayErr := make(chan error, 1)
+	go func() {
+		chPlayErr <- player.Play(ctx)
+	}()
+	userInput, err := p2.Prompt(ctx)
+	assert.Nil(t, err)
+	assert.Equal(t, "foo", userInput)
+	userInput, err = p2.Prompt(ctx)
+	assert.Nil(t, err)
+	assert.Equal(t, "baz qux", userInput)
+	assert.Nil(t, <-chPlayErr)
+	assert.Equal(t, []string{"> foo", "> baz qux", "", "", "", ""}, term2.Screen().Lines())
+
+	reRecorded, err := Read(strings.NewReader(reRecording.String()))
+	assert.Nil(t, err)
+	var inputs, reInputs []Event
+	for _, event := range recording.Events {
+		if event.Type == EventTypeInput {
+			inputs = append(inputs, Event{Type: event.Type, Data: event.Data})
+		}
+	}
+	for _, event := range reRecorded.Events {
+		if event.Type == EventTypeInput {
+			reInputs = append(reInputs, Event{Type: event.Type, Data: event.Data})
+		}
+	}
+	assert.Equal(t, inputs, reInputs)
+}
+
+func TestPlayer_Play(t *testing.T) {
+	t.Run("without markers", func(t *testing.T) {
+		ctx, cancel := context.WithTimeout(context.Background(), time.Second*10)
+		defer cancel()
+
+		term, p := generateTestTerminal(t)
+		defer term.Close()
+		cast := &Cast{
+			Header: Header{Version: Version, IdleTimeLimit: 0.01},
+			Events: []Event{
+				{Time: time.Second, Type: EventTypeInput, Data: "foo"},
+				{Time: time.Second * 2, Type: EventTypeInput, Data: "\r"},
+			},
+		}
+		player := NewPlayer(p, cast, WithSpeed(2))
+		chPlayErr := make(chan error, 1)
+		go func() {
+			chPlayErr <- player.Play(ctx)
+		}()
+		userInput, err := p.Prompt(ctx)
+		assert.Nil(t, err)
+		assert.Equal(t, "foo", userInput)
+		assert.Nil(t, <-chPlayErr)
+	})
+
+	t.Run("cancelled", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+
+		_, p := generateTestTerminal(t)
+		cast := &Cast{Events: []Event{{Type: EventTypeMarker, Data: MarkerPrompt}}}
+		err := NewPlayer(p, cast).Play(ctx)
+		assert.True(t, errors.Is(err, context.Canceled))
+
+		cast = &Cast{Events: []Event{{Time: time.Hour, Type: EventTypeInput, Data: "a"}}}
+		err = NewPlayer(p, cast).Play(ctx)
+		assert.True(t, errors.Is(err, context.Canceled))
+	})
+}
@@ -0,0 +1,157 @@
+package asciicast
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"sync"
+	"time"
+	"unicode/utf8"
+
+	"github.com/jedib0t/go-prompter/prompt"
+)
+
+// Recorder records the key-presses and the output of a Prompter as Events in
+// the asciicast (v2) format, to be played back with the Player or with tools
+// like asciinema. Use Prompter.SetRecorder to start recording.
+type Recorder struct {
+	encoder   *json.Encoder
+	err       error
+	height    int
+	lastByte  byte
+	mutex     sync.Mutex
+	pending   []byte
+	timeStart time.Time
+	width     int
+}
+
+// NewRecorder writes the Header to the given Writer, and returns a Recorder
+// that writes the Events to it. The Version and the Timestamp in the Header
+// get set if they are not already set.
+func NewRecorder(w io.Writer, header Header) (*Recorder, error) {
+	if header.Version == 0 {
+		header.Version = Version
+	}
+	if header.Timestamp == 0 {
+		header.Timestamp = time.Now().Unix()
+	}
+
+	r := &Recorder{
+		encoder:   json.NewEncoder(w),
+		height:    header.Height,
+		timeStart: time.Now(),
+		width:     header.Width,
+	}
+	r.encoder.SetEscapeHTML(false)
+	if err := r.encoder.Encode(header); err != nil {
+		return nil, fmt.Errorf("failed to write header: %w", err)
+	}
+	return r, nil
+}
+
+// Err returns the first error encountered while writing the Events, if any.
+func (r *Recorder) Err() error {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	return r.err
+}
+
+// Marker records a Marker with the given label.
+func (r *Recorder) Marker(label string) {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	r.record(EventTypeMarker, label)
+}
+
+// RecordKeyPress records the key-press as an input Event with what the
+// terminal sends for the key.
+func (r *Recorder) RecordKeyPress(kp prompt.KeyPress) {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	if input := encodeKeyPress(kp); input != "" {
+		r.record(EventTypeInput, input)
+	}
+}
+
+// RecordOutput records the output as an output Event.
+func (r *Recorder) RecordOutput(b []byte) {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	// the terminal moves back to the beginning of the line on a line feed
+	// unless it is in raw mode; do the same for the players
+	out := make([]byte, 0, len(r.pending)+len(b))
+	out = append(out, r.pending...)
+	for _, c := range b {
+		if c == '\n' && r.lastByte != '\r' {
+			out = append(out, '\r')
+		}
+		out = append(out, c)
+		r.lastByte = c
+	}
+
+	// hold on to an incomplete UTF-8 character until the rest of it shows up
+	r.pending = r.pending[:0]
+	if idx := incompleteRuneIdx(out); idx < len(out) {
+		r.pending = append(r.pending, out[idx:]...)
+		out = out[:idx]
+	}
+	if len(out) > 0 {
+		r.record(EventTypeOutput, string(out))
+	}
+}
+
+// RecordPromptStart records a Marker (MarkerPrompt) for the Player to know
+// when to start sending the key-presses that follow.
+func (r *Recorder) RecordPromptStart() {
+	r.Marker(MarkerPrompt)
+}
+
+// RecordResize records a resize Event if the size of the terminal changed.
+func (r *Recorder) RecordResize(width int, height int) {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	if width != r.width || height != r.height {
+		r.width, r.height = width, height
+		r.record(EventTypeResize, fmt.Sprintf("%dx%d", width, height))
+	}
+}
+
+// Write records what is written as output; use this for output that does not
+// go through the Prompter, like the results of a command.
+func (r *Recorder) Write(b []byte) (int, error) {
+	r.RecordOutput(b)
+	if err := r.Err(); err != nil {
+		return 0, err
+	}
+	return len(b), nil
+}
+
+func (r *Recorder) record(eventType EventType, data string) {
+	if r.err != nil {
+		return
+	}
+	r.err = r.encoder.Encode(Event{
+		Time: time.Since(r.timeStart),
+		Type: eventType,
+		Data: data,
+	})
+}
+
+// incompleteRuneIdx returns the index of the incomplete UTF-8 character at
+// the end of the given bytes, or the length of the bytes if there is none.
+func incompleteRuneIdx(b []byte) int {
+	for idx := len(b) - 1; idx >= 0 && idx >= len(b)-utf8.UTFMax; idx-- {
+		if utf8.RuneStart(b[idx]) {
+			if !utf8.FullRune(b[idx:]) {
+				return idx
+			}
+			break
+		}
+	}
+	return len(b)
+}
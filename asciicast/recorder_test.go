@@ -0,0 +1,102 @@
+package asciicast
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/jedib0t/go-prompter/prompt"
+	"github.com/stretchr/testify/assert"
+)
+
+var errFoo = errors.New("foo")
+
+type failingWriter struct {
+	numWrites int
+}
+
+func (fw *failingWriter) Write(b []byte) (int, error) {
+	fw.numWrites++
+	if fw.numWrites > 1 {
+		return 0, errFoo
+	}
+	return len(b), nil
+}
+
+func TestNewRecorder(t *testing.T) {
+	out := strings.Builder{}
+	r, err := NewRecorder(&out, Header{Width: 80, Height: 24, Timestamp: 1234})
+	assert.Nil(t, err)
+	assert.NotNil(t, r)
+	assert.Equal(t, `{"version":2,"width":80,"height":24,"timestamp":1234}`+"\n", out.String())
+
+	out.Reset()
+	_, err = NewRecorder(&out, Header{})
+	assert.Nil(t, err)
+	assert.Regexp(t, `^\{"version":2,"width":0,"height":0,"timestamp":\d+\}\n$`, out.String())
+
+	_, err = NewRecorder(&failingWriter{numWrites: 1}, Header{})
+	assert.True(t, errors.Is(err, errFoo))
+}
+
+func TestRecorder(t *testing.T) {
+	out := strings.Builder{}
+	r, err := NewRecorder(&out, Header{Width: 80, Height: 24})
+	assert.Nil(t, err)
+
+	r.RecordPromptStart()
+	r.RecordResize(80, 24) // no change
+	r.RecordResize(100, 30)
+	r.RecordOutput([]byte("> "))
+	r.RecordKeyPress(prompt.KeyPress{Text: "é"})
+	r.RecordKeyPress(prompt.KeyPress{KeySequence: "unknown"})
+	r.RecordKeyPress(prompt.KeyPress{KeySequence: prompt.Enter})
+	r.RecordOutput([]byte("\xc3")) // incomplete
+	r.RecordOutput([]byte("\xa9\n"))
+	r.RecordOutput([]byte("foo\r"))
+	r.RecordOutput([]byte("\nbar\r\n"))
+	n, err := r.Write([]byte("<baz>\n"))
+	assert.Nil(t, err)
+	assert.Equal(t, 6, n)
+	r.Marker("done")
+	assert.Nil(t, r.Err())
+
+	cast, err := Read(strings.NewReader(out.String()))
+	assert.Nil(t, err)
+	var events []string
+	for _, event := range cast.Events {
+		assert.True(t, event.Time >= 0)
+		events = append(events, string(event.Type)+":"+event.Data)
+	}
+	assert.Equal(t, []string{
+		"m:prompt",
+		"r:100x30",
+		"o:> ",
+		"i:é",
+		"i:\r",
+		"o:é\r\n",
+		"o:foo\r",
+		"o:\nbar\r\n",
+		"o:<baz>\r\n",
+		"m:done",
+	}, events)
+	assert.Contains(t, out.String(), `"<baz>\r\n"`)
+}
+
+func TestRecorder_Err(t *testing.T) {
+	r, err := NewRecorder(&failingWriter{}, Header{})
+	assert.Nil(t, err)
+
+	r.RecordOutput([]byte("foo"))
+	assert.True(t, errors.Is(r.Err(), errFoo))
+	_, err = r.Write([]byte("bar"))
+	assert.True(t, errors.Is(err, errFoo))
+}
+
+func Test_incompleteRuneIdx(t *testing.T) {
+	assert.Equal(t, 0, incompleteRuneIdx(nil))
+	assert.Equal(t, 3, incompleteRuneIdx([]byte("foo")))
+	assert.Equal(t, 5, incompleteRuneIdx([]byte("fooé")))
+	assert.Equal(t, 3, incompleteRuneIdx([]byte("foo\xe2\x94")))
+	assert.Equal(t, 4, incompleteRuneIdx([]byte("foo\x94")))
+}
@@ -19,6 +19,8 @@ var (
 	flagDisableLineNum = flag.Bool("disable-line-num", false, "Disable Line numbers?")
 	flagHeightMax      = flag.Uint("height-max", 5, "Maximum Height (excluding title); 0==no-limit")
 	flagHeightMin      = flag.Uint("height-min", 1, "Minimum Height (excluding title); 0==no-limit")
+	flagRecord         = flag.String("record", "", "Record the session to the given asciicast file?")
+	flagReplay         = flag.String("replay", "", "Replay the key-presses from the given asciicast file?")
 	flagWidthMax       = flag.Uint("width-max", 0, "Maximum Terminal Width to use (0 for full length)")
 	flagStyle          = flag.String("style", "monokai", "Chroma Style to use for syntax highlighting")
 	flagTimeoutSecs    = flag.Uint("timeout", 300, "Number of seconds to timeout after.")
@@ -64,6 +66,7 @@ func main() {
 	p.SetAutoCompleterContextual(prompt.AutoCompleteSimple(tableAndColumnNames, true))
 	p.SetCommandShortcuts(shortcuts)
 	p.SetDebug(*flagDebug)
+	p.SetExternalEditorFileExtension(".sql")
 	if !*flagDemo {
 		p.SetHistory(history)
 	}
@@ -77,9 +80,13 @@ func main() {
 	p.SetPrefixer(prompt.PrefixNone())
 	p.SetSyntaxHighlighter(syntaxHighlighter)
 	p.SetTerminationChecker(prompt.TerminationCheckerSQL())
+	p.SetWidthEnforcer(prompt.WidthEnforcerWordWrap("  "))
+	p.Style().Brackets = prompt.StyleBracketsSQL
 	p.Style().Dimensions.HeightMax = *flagHeightMax
 	p.Style().Dimensions.HeightMin = *flagHeightMin
 	p.Style().Dimensions.WidthMax = *flagWidthMax
+	p.Style().Indent = prompt.StyleIndentSQL
+	p.Style().LineComment = "-- "
 	if !*flagDisableLineNum {
 		p.Style().LineNumbers = prompt.StyleLineNumbersEnabled
 	}
@@ -107,18 +114,26 @@ func main() {
 		go runDemo(p)
 	}
 
+	// record and/or replay the session if asked for
+	if err := setupRecordAndReplay(ctx, p); err != nil {
+		fmt.Printf("ERROR: failed to set up recording/replaying: %v", err)
+		os.Exit(1)
+	}
+
 	// Prompt the user and handle each input in a loop until we are done for any
 	// reason (user wants to quit, etc.).
+	var promptOpts []prompt.PromptOption
 	for {
 		// Update the # of the command we are handling on the title bar
 		segmentCmdNum.SetContent(fmt.Sprint(len(p.History()) + 1))
 
 		// Prompt
-		input, err := p.Prompt(ctx)
+		input, err := p.Prompt(ctx, promptOpts...)
 		if err != nil {
 			fmt.Printf("ERROR: %v\n", err.Error())
 			os.Exit(1)
 		}
+		promptOpts = nil
 
 		// Handle the input
 		cmd, _ := getCommandAndArgs(input)
@@ -127,15 +142,19 @@ func main() {
 			printHelp()
 		case "/clear":
 			p.ClearHistory()
-			fmt.Println("Cleared history.")
+			fmt.Fprintln(output, "Cleared history.")
+		case "/edit":
+			// compose the next input using the whole terminal
+			promptOpts = append(promptOpts, prompt.WithFullScreen())
+			continue
 		case "/quit":
-			fmt.Println("Bye!")
+			fmt.Fprintln(output, "Bye!")
 			os.Exit(0)
 		default:
 			// pretend we talk to a real database and output real data
 			printDummyOutput(input)
 		}
-		fmt.Println()
+		fmt.Fprintln(output)
 	}
 }
 
@@ -165,9 +184,9 @@ func printDummyOutput(input string) {
 		tw.AppendHeader(table.Row{"ID", "First Name", "Last Name", "Salary", "Notes"})
 		tw.AppendRow(table.Row{1, "Night", "King", 10000, "Has horns!"})
 		tw.SetCaption("Returned 1 row in 0.001s.")
-		fmt.Println(tw.Render())
+		fmt.Fprintln(output, tw.Render())
 	case history[1].Command: // "insert into employees (first_name, last_name, salary) values\n  ('Arya', 'Stark', 3000),\n  ('Jon', 'Snow', 2000),\n  ('Tyrion', 'Lannister', 5000);"
-		fmt.Println("Inserted 3 records in 0.015s.")
+		fmt.Fprintln(output, "Inserted 3 records in 0.015s.")
 	case history[2].Command: // "select * from employees where salary between 1000 and 6000 order by id;"
 		tw := tableWriter()
 		tw.AppendHeader(table.Row{"ID", "First Name", "Last Name", "Salary", "Notes"})
@@ -175,19 +194,20 @@ func printDummyOutput(input string) {
 		tw.AppendRow(table.Row{3, "Jon", "Snow", 2000, "Knows nothing."})
 		tw.AppendRow(table.Row{4, "Tyrion", "Lannister", 5000, "Pays his debts."})
 		tw.SetCaption("Returned 3 rows in 0.003s.")
-		fmt.Println(tw.Render())
+		fmt.Fprintln(output, tw.Render())
 	case history[3].Command: // "delete from employees where salary between 1000 and 6000;"
-		fmt.Println("Deleted 3 records in 0.013s.")
+		fmt.Fprintln(output, "Deleted 3 records in 0.013s.")
 	default:
-		fmt.Printf("> Pretending to execute: %#v\n", input)
+		fmt.Fprintf(output, "> Pretending to execute: %#v\n", input)
 	}
 }
 
 func printHelp() {
-	fmt.Println(`SQL Prompt demo using github.com/jedib0t/go-prompter.
+	fmt.Fprintln(output, `SQL Prompt demo using github.com/jedib0t/go-prompter.
 
 * /?, /help    Prints this help text.
 * /clear       Clears History.
+* /edit        Edits the next input in a full-screen editor.
 * /quit        Exits the prompt.`)
 }
 
@@ -0,0 +1,62 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/jedib0t/go-prompter/asciicast"
+	"github.com/jedib0t/go-prompter/prompt"
+	"golang.org/x/term"
+)
+
+// output is where the results of the commands are printed to; it includes the
+// recording when recording.
+var output io.Writer = os.Stdout
+
+// setupRecordAndReplay records the session to an asciicast file (that can be
+// played back with asciinema), and/or replays the key-presses from one.
+func setupRecordAndReplay(ctx context.Context, p prompt.Prompter) error {
+	var recorder *asciicast.Recorder
+	if *flagRecord != "" {
+		file, err := os.Create(*flagRecord)
+		if err != nil {
+			return err
+		}
+		width, height, _ := term.GetSize(int(os.Stdout.Fd()))
+		recorder, err = asciicast.NewRecorder(file, asciicast.Header{
+			Width:  width,
+			Height: height,
+			Title:  "SQL Prompt",
+			Env:    map[string]string{"TERM": os.Getenv("TERM")},
+		})
+		if err != nil {
+			return err
+		}
+		p.SetRecorder(recorder)
+		output = io.MultiWriter(os.Stdout, recorder)
+	}
+
+	if *flagReplay != "" {
+		file, err := os.Open(*flagReplay)
+		if err != nil {
+			return err
+		}
+		defer file.Close()
+		cast, err := asciicast.Read(file)
+		if err != nil {
+			return fmt.Errorf("failed to read %s: %w", *flagReplay, err)
+		}
+
+		var opts []asciicast.PlayerOption
+		if recorder != nil {
+			opts = append(opts, asciicast.WithRecorder(recorder))
+		}
+		player := asciicast.NewPlayer(p, cast, opts...)
+		go func() {
+			_ = player.Play(ctx)
+		}()
+	}
+	return nil
+}
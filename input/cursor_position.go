@@ -0,0 +1,45 @@
+package input
+
+import (
+	"reflect"
+	"strconv"
+	"strings"
+)
+
+// QueryCursorPosition is the escape sequence that makes the terminal report
+// the location of the cursor when written to it. The response gets delivered
+// as a CursorPositionMsg through Reader.CursorPositionEvents.
+const QueryCursorPosition = "\x1b[6n"
+
+// CursorPositionMsg contains the location of the cursor as reported by the
+// terminal. The values are 0-indexed like the ones in tea.MouseMsg.
+type CursorPositionMsg struct {
+	Column int
+	Row    int
+}
+
+// parseCursorPosition parses the response to QueryCursorPosition. Bubble Tea
+// does not know about this response, and passes it on as an (unexported)
+// unknown CSI sequence message which is a byte slice underneath.
+func parseCursorPosition(msg any) (CursorPositionMsg, bool) {
+	v := reflect.ValueOf(msg)
+	if v.Kind() != reflect.Slice || v.Type().Elem().Kind() != reflect.Uint8 {
+		return CursorPositionMsg{}, false
+	}
+
+	// expected format: ESC [ <row> ; <column> R
+	seq := string(v.Bytes())
+	if !strings.HasPrefix(seq, "\x1b[") || !strings.HasSuffix(seq, "R") {
+		return CursorPositionMsg{}, false
+	}
+	parts := strings.Split(seq[2:len(seq)-1], ";")
+	if len(parts) != 2 {
+		return CursorPositionMsg{}, false
+	}
+	row, errRow := strconv.Atoi(parts[0])
+	column, errColumn := strconv.Atoi(parts[1])
+	if errRow != nil || errColumn != nil || row < 1 || column < 1 {
+		return CursorPositionMsg{}, false
+	}
+	return CursorPositionMsg{Column: column - 1, Row: row - 1}, true
+}
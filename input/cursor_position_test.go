@@ -0,0 +1,32 @@
+package input
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type testCSISequence []byte
+
+func Test_parseCursorPosition(t *testing.T) {
+	cp, ok := parseCursorPosition(testCSISequence("\x1b[12;40R"))
+	assert.True(t, ok)
+	assert.Equal(t, CursorPositionMsg{Column: 39, Row: 11}, cp)
+
+	cp, ok = parseCursorPosition([]byte("\x1b[1;1R"))
+	assert.True(t, ok)
+	assert.Equal(t, CursorPositionMsg{Column: 0, Row: 0}, cp)
+
+	for _, msg := range []any{
+		nil,
+		"\x1b[12;40R",
+		testCSISequence("\x1b[12;40H"),
+		testCSISequence("\x1b[12R"),
+		testCSISequence("\x1b[0;0R"),
+		testCSISequence("\x1b[a;bR"),
+		[]int{1, 2},
+	} {
+		_, ok = parseCursorPosition(msg)
+		assert.False(t, ok, "msg=%#v", msg)
+	}
+}
@@ -13,6 +13,25 @@ func WithInput(input io.Reader) Option {
 	}
 }
 
+// WithOutput makes Reader write the escape sequences that set up the terminal
+// (like the ones enabling bracketed paste and mouse reporting) to the given
+// destination instead of os.Stdout. Use this if the output is going to some
+// other terminal (or a virtual one).
+func WithOutput(output io.Writer) Option {
+	return func(r *reader) {
+		r.output = output
+	}
+}
+
+// WithoutBracketedPaste disables bracketed paste, which is enabled by default
+// and delivers pasted text as a single tea.KeyMsg (with Paste set to true)
+// instead of one event per character.
+func WithoutBracketedPaste() Option {
+	return func(r *reader) {
+		r.bracketedPasteDisabled = true
+	}
+}
+
 // WatchMouseAll makes Reader watch and pipe Mouse click and move events.
 func WatchMouseAll() Option {
 	return func(r *reader) {
@@ -4,11 +4,21 @@ import (
 	"context"
 	"fmt"
 	"io"
+	"os"
+	"strings"
 	"sync"
 
 	tea "github.com/charmbracelet/bubbletea"
 )
 
+// DEC private modes set on the terminal while reading.
+const (
+	modeBracketedPaste  = "2004"
+	modeMouseAllMotion  = "1003"
+	modeMouseCellMotion = "1002"
+	modeMouseSGR        = "1006"
+)
+
 // Reader channels Key, Mouse and Window Resize events to the caller through the
 // publicly defined channels below. The caller needs to drain all the channels
 // to prevent blocking.
@@ -18,6 +28,9 @@ import (
 type Reader interface {
 	// Begin begins reading inputs.
 	Begin(ctx context.Context)
+	// CursorPositionEvents returns the channel with the cursor locations
+	// reported by the Terminal in response to QueryCursorPosition.
+	CursorPositionEvents() <-chan CursorPositionMsg
 	// End terminates reading.
 	End()
 	// Errors returns the channel with errors encountered while reading.
@@ -26,6 +39,12 @@ type Reader interface {
 	KeyEvents() <-chan tea.KeyMsg
 	// MouseEvents returns the channel with events from the Mouse.
 	MouseEvents() <-chan tea.MouseMsg
+	// ReleaseTerminal stops reading and restores the terminal to its original
+	// state so that another program (like an editor) can take control of it.
+	ReleaseTerminal() error
+	// RestoreTerminal takes back control of the terminal and resumes reading
+	// after a call to ReleaseTerminal.
+	RestoreTerminal() error
 	// Send sends the message over the appropriate channel back to client. This
 	// can be useful for testing and automated inputs.
 	Send(msg any) error
@@ -48,20 +67,26 @@ func NewReader(opts ...Option) Reader {
 }
 
 type reader struct {
-	chDone             chan bool
-	chErrors           chan error
-	chKeyEvents        chan tea.KeyMsg
-	chMouseEvents      chan tea.MouseMsg
-	chWindowSizeEvents chan tea.WindowSizeMsg
-	done               bool
-	input              io.Reader
-	mutex              sync.Mutex
-	program            *tea.Program
-	programMutex       sync.Mutex
-	teaBag             *teaBag
-	watchMouseAll      bool
-	watchMouseClick    bool
-	watchWindowSize    bool
+	bracketedPasteDisabled bool
+	chCursorPositionEvents chan CursorPositionMsg
+	chDone                 chan bool
+	chErrors               chan error
+	chKeyEvents            chan tea.KeyMsg
+	chMouseEvents          chan tea.MouseMsg
+	chStarted              chan bool
+	chStopped              chan bool
+	chWindowSizeEvents     chan tea.WindowSizeMsg
+	done                   bool
+	ended                  bool
+	input                  io.Reader
+	mutex                  sync.Mutex
+	output                 io.Writer
+	program                *tea.Program
+	programMutex           sync.Mutex
+	teaBag                 *teaBag
+	watchMouseAll          bool
+	watchMouseClick        bool
+	watchWindowSize        bool
 }
 
 // Begin sets up the channels for reading and begins capturing inputs.
@@ -71,21 +96,36 @@ func (r *reader) Begin(ctx context.Context) {
 	r.done = false
 
 	r.programMutex.Lock()
-	r.program = tea.NewProgram(r.teaBag, r.progOpts(ctx)...)
+	if r.ended { // End was called before this got a chance to begin
+		r.programMutex.Unlock()
+		return
+	}
+	program := tea.NewProgram(r.teaBag, r.progOpts(ctx)...)
+	r.program = program
+	r.setTerminalModes(true)
 	r.programMutex.Unlock()
 
-	_, err := r.program.Run()
+	_, err := program.Run()
+	r.setTerminalModes(false)
+	close(r.chStopped)
 	if err != nil {
 		r.chErrors <- err
 	}
 	r.chDone <- true
 }
 
+// CursorPositionEvents returns the channel that passes on cursor position
+// reports.
+func (r *reader) CursorPositionEvents() <-chan CursorPositionMsg {
+	return r.chCursorPositionEvents
+}
+
 // End stops the input handling and cleans up.
 func (r *reader) End() {
 	r.programMutex.Lock()
 	defer r.programMutex.Unlock()
 
+	r.ended = true
 	if r.program != nil {
 		r.program.Quit()
 		r.program = nil
@@ -108,6 +148,34 @@ func (r *reader) MouseEvents() <-chan tea.MouseMsg {
 	return r.chMouseEvents
 }
 
+// ReleaseTerminal stops reading and restores the terminal to its original state
+// so that another program (like an editor) can take control of it.
+func (r *reader) ReleaseTerminal() error {
+	r.programMutex.Lock()
+	defer r.programMutex.Unlock()
+
+	if r.program != nil && r.waitUntilStarted() {
+		err := r.program.ReleaseTerminal()
+		r.setTerminalModes(false)
+		return err
+	}
+	return nil
+}
+
+// RestoreTerminal takes back control of the terminal and resumes reading after
+// a call to ReleaseTerminal.
+func (r *reader) RestoreTerminal() error {
+	r.programMutex.Lock()
+	defer r.programMutex.Unlock()
+
+	if r.program != nil && r.waitUntilStarted() {
+		err := r.program.RestoreTerminal()
+		r.setTerminalModes(true)
+		return err
+	}
+	return nil
+}
+
 // Send sends the message over the appropriate channel back to client. This can
 // be useful for testing and automated inputs.
 func (r *reader) Send(msg any) error {
@@ -115,6 +183,8 @@ func (r *reader) Send(msg any) error {
 	defer r.programMutex.Unlock()
 
 	switch obj := msg.(type) {
+	case CursorPositionMsg:
+		r.chCursorPositionEvents <- obj
 	case error:
 		r.chErrors <- obj
 	case tea.KeyMsg:
@@ -147,48 +217,107 @@ func (r *reader) Reset() error {
 }
 
 func (r *reader) init() {
+	r.chCursorPositionEvents = make(chan CursorPositionMsg, 5)
 	r.chDone = make(chan bool, 1)
 	r.chErrors = make(chan error, 5)
 	r.chKeyEvents = make(chan tea.KeyMsg, 5)
 	r.chMouseEvents = make(chan tea.MouseMsg, 5)
+	r.chStarted = make(chan bool)
+	r.chStopped = make(chan bool)
 	r.chWindowSizeEvents = make(chan tea.WindowSizeMsg, 5)
+	r.ended = false
 	r.teaBag = &teaBag{
-		ErrorEvents:     r.chErrors,
-		KeyEvents:       r.chKeyEvents,
-		MouseEvents:     r.chMouseEvents,
-		ResizeEvents:    r.chWindowSizeEvents,
-		watchMouse:      r.watchMouseAll || r.watchMouseClick,
-		watchWindowSize: r.watchWindowSize,
+		CursorPositionEvents: r.chCursorPositionEvents,
+		ErrorEvents:          r.chErrors,
+		KeyEvents:            r.chKeyEvents,
+		MouseEvents:          r.chMouseEvents,
+		ResizeEvents:         r.chWindowSizeEvents,
+		Started:              r.chStarted,
+		watchMouse:           r.watchMouseAll || r.watchMouseClick,
+		watchWindowSize:      r.watchWindowSize,
 	}
 }
 
+// waitUntilStarted waits for the program to be up and running (i.e., reading
+// the input), and returns false if it stopped without getting there.
+func (r *reader) waitUntilStarted() bool {
+	select {
+	case <-r.chStarted:
+		return true
+	case <-r.chStopped:
+		return false
+	}
+}
+
+// progOpts returns the options for the bubbletea program. Its renderer is
+// disabled as the client renders everything (including the cursor) on its
+// own, which is why the terminal modes are set using setTerminalModes instead.
 func (r *reader) progOpts(ctx context.Context) []tea.ProgramOption {
 	opts := []tea.ProgramOption{
 		tea.WithContext(ctx),
+		tea.WithoutRenderer(),
 	}
 	if r.input != nil {
 		opts = append(opts, tea.WithInput(r.input))
 	}
+	if r.output != nil {
+		opts = append(opts, tea.WithOutput(r.output))
+	}
+	return opts
+}
+
+// setTerminalModes enables (or disables) bracketed paste and mouse reporting
+// on the terminal as requested using the options.
+func (r *reader) setTerminalModes(enable bool) {
+	var modes []string
+	if !r.bracketedPasteDisabled {
+		modes = append(modes, modeBracketedPaste)
+	}
 	if r.watchMouseAll {
-		opts = append(opts, tea.WithMouseAllMotion())
+		modes = append(modes, modeMouseAllMotion, modeMouseSGR)
 	} else if r.watchMouseClick {
-		opts = append(opts, tea.WithMouseCellMotion())
+		modes = append(modes, modeMouseCellMotion, modeMouseSGR)
 	}
-	return opts
+	if len(modes) == 0 {
+		return
+	}
+
+	suffix := "l"
+	if enable {
+		suffix = "h"
+	}
+	sequences := strings.Builder{}
+	for _, mode := range modes {
+		sequences.WriteString("\x1b[?" + mode + suffix)
+	}
+
+	var output io.Writer = os.Stdout
+	if r.output != nil {
+		output = r.output
+	}
+	_, _ = io.WriteString(output, sequences.String())
 }
 
 // teaBag wraps a bubbletea model. Get it?
 type teaBag struct {
-	ErrorEvents     chan error
-	KeyEvents       chan tea.KeyMsg
-	MouseEvents     chan tea.MouseMsg
-	ResizeEvents    chan tea.WindowSizeMsg
-	watchMouse      bool
-	watchWindowSize bool
+	CursorPositionEvents chan CursorPositionMsg
+	ErrorEvents          chan error
+	KeyEvents            chan tea.KeyMsg
+	MouseEvents          chan tea.MouseMsg
+	ResizeEvents         chan tea.WindowSizeMsg
+	Started              chan bool
+	watchMouse           bool
+	watchWindowSize      bool
 }
 
+// startedMsg is handled once the program has begun processing the messages,
+// which is after it has begun reading the input.
+type startedMsg struct{}
+
 func (tb *teaBag) Init() tea.Cmd {
-	return nil
+	return func() tea.Msg {
+		return startedMsg{}
+	}
 }
 
 func (tb *teaBag) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
@@ -205,6 +334,12 @@ func (tb *teaBag) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		if tb.watchWindowSize {
 			tb.ResizeEvents <- msg
 		}
+	case startedMsg:
+		close(tb.Started)
+	default:
+		if cursorPosition, ok := parseCursorPosition(msg); ok {
+			tb.CursorPositionEvents <- cursorPosition
+		}
 	}
 	return tb, nil
 }
@@ -5,6 +5,8 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"io"
+	"strings"
 	"testing"
 	"time"
 
@@ -21,10 +23,9 @@ var (
 
 func generateTestReader(ctx context.Context, t *testing.T, opts ...Option) (Reader, *reader) {
 	var in bytes.Reader
-	opts = append([]Option{WithInput(&in)}, opts...)
+	opts = append([]Option{WithInput(&in), WithOutput(io.Discard)}, opts...)
 	r := NewReader(opts...)
 	go r.Begin(ctx)
-	<-time.After(time.Second / 4) // time to begin
 
 	rObj, ok := r.(*reader)
 	assert.NotNil(t, rObj)
@@ -32,11 +33,49 @@ func generateTestReader(ctx context.Context, t *testing.T, opts ...Option) (Read
 	if !ok {
 		t.FailNow()
 	}
+	assert.True(t, rObj.waitUntilStarted())
 	assert.NotNil(t, rObj.program)
 
 	return r, rObj
 }
 
+func TestReader_BracketedPaste(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second*5)
+	defer cancel()
+
+	in := strings.NewReader("\x1b[200~foo\rbar\x1b[201~")
+	r, _ := generateTestReader(ctx, t, WithInput(in))
+	defer r.End()
+
+	received, ok := <-r.KeyEvents()
+	assert.True(t, ok)
+	assert.Equal(t, tea.KeyRunes, received.Type)
+	assert.Equal(t, "foo\rbar", string(received.Runes))
+	assert.True(t, received.Paste)
+
+	out := &strings.Builder{}
+	r, rObj := generateTestReader(ctx, t, WithOutput(out), WithoutBracketedPaste())
+	r.End()
+	assert.True(t, rObj.bracketedPasteDisabled)
+	assert.Empty(t, out.String())
+}
+
+func TestReader_Output(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second*5)
+	defer cancel()
+
+	out := &strings.Builder{}
+	r, rObj := generateTestReader(ctx, t, WithOutput(out), WatchMouseClick())
+	assert.Equal(t, out, rObj.output)
+	assert.Nil(t, r.ReleaseTerminal())
+	assert.Nil(t, r.RestoreTerminal())
+	r.End()
+
+	// nothing but the terminal modes, as the renderer is disabled
+	modesOn, modesOff := "\x1b[?2004h\x1b[?1002h\x1b[?1006h", "\x1b[?2004l\x1b[?1002l\x1b[?1006l"
+	assert.Equal(t, modesOn+modesOff+modesOn+modesOff, out.String())
+}
+
 func TestReader_Begin(t *testing.T) {
 	ctx, cancel := context.WithTimeout(context.Background(), time.Second*5)
 	defer cancel()
@@ -51,6 +90,41 @@ func TestReader_Begin(t *testing.T) {
 	assert.Contains(t, err.Error(), "killed")
 }
 
+func TestReader_CursorPositionEvents(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second*5)
+	defer cancel()
+
+	in := strings.NewReader("\x1b[12;40R")
+	r, _ := generateTestReader(ctx, t, WithInput(in))
+	defer r.End()
+
+	received, ok := <-r.CursorPositionEvents()
+	assert.True(t, ok)
+	assert.Equal(t, CursorPositionMsg{Column: 39, Row: 11}, received)
+}
+
+func TestReader_End(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second*5)
+	defer cancel()
+
+	var in bytes.Reader
+	r := NewReader(WithInput(&in))
+	rObj := r.(*reader)
+
+	// ending before beginning does not leave anything running
+	r.End()
+	r.Begin(ctx)
+	assert.Nil(t, rObj.program)
+
+	// but it can begin again after a reset
+	assert.Nil(t, r.Reset())
+	go r.Begin(ctx)
+	<-time.After(time.Second / 4) // time to begin
+	r.End()
+	assert.Nil(t, rObj.program)
+	assert.True(t, rObj.ended)
+}
+
 func TestReader_Errors(t *testing.T) {
 	ctx, cancel := context.WithTimeout(context.Background(), time.Second*5)
 	defer cancel()
@@ -98,6 +172,26 @@ func TestReader_MouseEvents(t *testing.T) {
 	runTest(WatchMouseAll())
 }
 
+func TestReader_ReleaseTerminal(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second*5)
+	defer cancel()
+
+	r := NewReader()
+	assert.Nil(t, r.ReleaseTerminal())
+	assert.Nil(t, r.RestoreTerminal())
+
+	r, rObj := generateTestReader(ctx, t)
+	defer r.End()
+
+	assert.Nil(t, r.ReleaseTerminal())
+	assert.Nil(t, r.RestoreTerminal())
+
+	rObj.program.Send(testKeyMsg)
+	received, ok := <-r.KeyEvents()
+	assert.True(t, ok)
+	assert.Equal(t, testKeyMsg, received)
+}
+
 func TestReader_Reset(t *testing.T) {
 	var in bytes.Reader
 	r := NewReader(WithInput(&in), WatchMouseAll())
@@ -108,6 +202,7 @@ func TestReader_Reset(t *testing.T) {
 		t.FailNow()
 	}
 
+	originalChCursorPositionEvents := rObj.chCursorPositionEvents
 	originalChDone := rObj.chDone
 	originalChErrors := rObj.chErrors
 	originalChKeyEvents := rObj.chKeyEvents
@@ -115,6 +210,7 @@ func TestReader_Reset(t *testing.T) {
 	originalChWindowSizeEvents := rObj.chWindowSizeEvents
 	err := r.Reset()
 	assert.Nil(t, err)
+	assert.NotEqual(t, originalChCursorPositionEvents, rObj.chCursorPositionEvents)
 	assert.NotEqual(t, originalChDone, rObj.chDone)
 	assert.NotEqual(t, originalChErrors, rObj.chErrors)
 	assert.NotEqual(t, originalChKeyEvents, rObj.chKeyEvents)
@@ -132,6 +228,14 @@ func TestReader_Send(t *testing.T) {
 	defer r.End()
 	<-time.After(time.Second / 4) // time to begin
 
+	t.Run("CursorPositionMsg", func(t *testing.T) {
+		err := r.Send(CursorPositionMsg{Column: 1, Row: 2})
+		assert.Nil(t, err)
+		received, ok := <-r.CursorPositionEvents()
+		assert.True(t, ok)
+		assert.Equal(t, CursorPositionMsg{Column: 1, Row: 2}, received)
+	})
+
 	t.Run("errors", func(t *testing.T) {
 		err := r.Send(errFoo)
 		assert.Nil(t, err)
@@ -13,6 +13,7 @@ import (
 	reflect "reflect"
 
 	tea "github.com/charmbracelet/bubbletea"
+	input "github.com/jedib0t/go-prompter/input"
 	gomock "go.uber.org/mock/gomock"
 )
 
@@ -51,6 +52,20 @@ func (mr *MockReaderMockRecorder) Begin(arg0 any) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Begin", reflect.TypeOf((*MockReader)(nil).Begin), arg0)
 }
 
+// CursorPositionEvents mocks base method.
+func (m *MockReader) CursorPositionEvents() <-chan input.CursorPositionMsg {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CursorPositionEvents")
+	ret0, _ := ret[0].(<-chan input.CursorPositionMsg)
+	return ret0
+}
+
+// CursorPositionEvents indicates an expected call of CursorPositionEvents.
+func (mr *MockReaderMockRecorder) CursorPositionEvents() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CursorPositionEvents", reflect.TypeOf((*MockReader)(nil).CursorPositionEvents))
+}
+
 // End mocks base method.
 func (m *MockReader) End() {
 	m.ctrl.T.Helper()
@@ -105,6 +120,20 @@ func (mr *MockReaderMockRecorder) MouseEvents() *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MouseEvents", reflect.TypeOf((*MockReader)(nil).MouseEvents))
 }
 
+// ReleaseTerminal mocks base method.
+func (m *MockReader) ReleaseTerminal() error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ReleaseTerminal")
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ReleaseTerminal indicates an expected call of ReleaseTerminal.
+func (mr *MockReaderMockRecorder) ReleaseTerminal() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReleaseTerminal", reflect.TypeOf((*MockReader)(nil).ReleaseTerminal))
+}
+
 // Reset mocks base method.
 func (m *MockReader) Reset() error {
 	m.ctrl.T.Helper()
@@ -119,6 +148,20 @@ func (mr *MockReaderMockRecorder) Reset() *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Reset", reflect.TypeOf((*MockReader)(nil).Reset))
 }
 
+// RestoreTerminal mocks base method.
+func (m *MockReader) RestoreTerminal() error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RestoreTerminal")
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RestoreTerminal indicates an expected call of RestoreTerminal.
+func (mr *MockReaderMockRecorder) RestoreTerminal() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RestoreTerminal", reflect.TypeOf((*MockReader)(nil).RestoreTerminal))
+}
+
 // Send mocks base method.
 func (m *MockReader) Send(arg0 any) error {
 	m.ctrl.T.Helper()
@@ -15,6 +15,7 @@ import (
 	time "time"
 
 	prompt "github.com/jedib0t/go-prompter/prompt"
+	termenv "github.com/muesli/termenv"
 	gomock "go.uber.org/mock/gomock"
 )
 
@@ -123,19 +124,57 @@ func (mr *MockPrompterMockRecorder) NumLines() *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "NumLines", reflect.TypeOf((*MockPrompter)(nil).NumLines))
 }
 
+// Print mocks base method.
+func (m *MockPrompter) Print(arg0 ...any) {
+	m.ctrl.T.Helper()
+	varargs := []any{}
+	for _, a := range arg0 {
+		varargs = append(varargs, a)
+	}
+	m.ctrl.Call(m, "Print", varargs...)
+}
+
+// Print indicates an expected call of Print.
+func (mr *MockPrompterMockRecorder) Print(arg0 ...any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Print", reflect.TypeOf((*MockPrompter)(nil).Print), arg0...)
+}
+
+// Printf mocks base method.
+func (m *MockPrompter) Printf(arg0 string, arg1 ...any) {
+	m.ctrl.T.Helper()
+	varargs := []any{arg0}
+	for _, a := range arg1 {
+		varargs = append(varargs, a)
+	}
+	m.ctrl.Call(m, "Printf", varargs...)
+}
+
+// Printf indicates an expected call of Printf.
+func (mr *MockPrompterMockRecorder) Printf(arg0 any, arg1 ...any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]any{arg0}, arg1...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Printf", reflect.TypeOf((*MockPrompter)(nil).Printf), varargs...)
+}
+
 // Prompt mocks base method.
-func (m *MockPrompter) Prompt(arg0 context.Context) (string, error) {
+func (m *MockPrompter) Prompt(arg0 context.Context, arg1 ...prompt.PromptOption) (string, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "Prompt", arg0)
+	varargs := []any{arg0}
+	for _, a := range arg1 {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "Prompt", varargs...)
 	ret0, _ := ret[0].(string)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // Prompt indicates an expected call of Prompt.
-func (mr *MockPrompterMockRecorder) Prompt(arg0 any) *gomock.Call {
+func (mr *MockPrompterMockRecorder) Prompt(arg0 any, arg1 ...any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Prompt", reflect.TypeOf((*MockPrompter)(nil).Prompt), arg0)
+	varargs := append([]any{arg0}, arg1...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Prompt", reflect.TypeOf((*MockPrompter)(nil).Prompt), varargs...)
 }
 
 // SendInput mocks base method.
@@ -157,6 +196,18 @@ func (mr *MockPrompterMockRecorder) SendInput(arg0 any, arg1 ...any) *gomock.Cal
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SendInput", reflect.TypeOf((*MockPrompter)(nil).SendInput), varargs...)
 }
 
+// SetAccessibleMode mocks base method.
+func (m *MockPrompter) SetAccessibleMode(arg0 bool) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "SetAccessibleMode", arg0)
+}
+
+// SetAccessibleMode indicates an expected call of SetAccessibleMode.
+func (mr *MockPrompterMockRecorder) SetAccessibleMode(arg0 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetAccessibleMode", reflect.TypeOf((*MockPrompter)(nil).SetAccessibleMode), arg0)
+}
+
 // SetAutoCompleter mocks base method.
 func (m *MockPrompter) SetAutoCompleter(arg0 prompt.AutoCompleter) {
 	m.ctrl.T.Helper()
@@ -181,6 +232,18 @@ func (mr *MockPrompterMockRecorder) SetAutoCompleterContextual(arg0 any) *gomock
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetAutoCompleterContextual", reflect.TypeOf((*MockPrompter)(nil).SetAutoCompleterContextual), arg0)
 }
 
+// SetColorProfile mocks base method.
+func (m *MockPrompter) SetColorProfile(arg0 termenv.Profile) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "SetColorProfile", arg0)
+}
+
+// SetColorProfile indicates an expected call of SetColorProfile.
+func (mr *MockPrompterMockRecorder) SetColorProfile(arg0 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetColorProfile", reflect.TypeOf((*MockPrompter)(nil).SetColorProfile), arg0)
+}
+
 // SetCommandShortcuts mocks base method.
 func (m *MockPrompter) SetCommandShortcuts(arg0 map[prompt.KeySequence]string) {
 	m.ctrl.T.Helper()
@@ -193,6 +256,18 @@ func (mr *MockPrompterMockRecorder) SetCommandShortcuts(arg0 any) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetCommandShortcuts", reflect.TypeOf((*MockPrompter)(nil).SetCommandShortcuts), arg0)
 }
 
+// SetContinuationPrefixer mocks base method.
+func (m *MockPrompter) SetContinuationPrefixer(arg0 prompt.ContinuationPrefixer) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "SetContinuationPrefixer", arg0)
+}
+
+// SetContinuationPrefixer indicates an expected call of SetContinuationPrefixer.
+func (mr *MockPrompterMockRecorder) SetContinuationPrefixer(arg0 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetContinuationPrefixer", reflect.TypeOf((*MockPrompter)(nil).SetContinuationPrefixer), arg0)
+}
+
 // SetDebug mocks base method.
 func (m *MockPrompter) SetDebug(arg0 bool) {
 	m.ctrl.T.Helper()
@@ -205,6 +280,18 @@ func (mr *MockPrompterMockRecorder) SetDebug(arg0 any) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetDebug", reflect.TypeOf((*MockPrompter)(nil).SetDebug), arg0)
 }
 
+// SetExternalEditorFileExtension mocks base method.
+func (m *MockPrompter) SetExternalEditorFileExtension(arg0 string) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "SetExternalEditorFileExtension", arg0)
+}
+
+// SetExternalEditorFileExtension indicates an expected call of SetExternalEditorFileExtension.
+func (mr *MockPrompterMockRecorder) SetExternalEditorFileExtension(arg0 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetExternalEditorFileExtension", reflect.TypeOf((*MockPrompter)(nil).SetExternalEditorFileExtension), arg0)
+}
+
 // SetFooter mocks base method.
 func (m *MockPrompter) SetFooter(arg0 string) {
 	m.ctrl.T.Helper()
@@ -301,6 +388,18 @@ func (mr *MockPrompterMockRecorder) SetInput(arg0 any) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetInput", reflect.TypeOf((*MockPrompter)(nil).SetInput), arg0)
 }
 
+// SetInteractive mocks base method.
+func (m *MockPrompter) SetInteractive(arg0 bool) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "SetInteractive", arg0)
+}
+
+// SetInteractive indicates an expected call of SetInteractive.
+func (mr *MockPrompterMockRecorder) SetInteractive(arg0 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetInteractive", reflect.TypeOf((*MockPrompter)(nil).SetInteractive), arg0)
+}
+
 // SetKeyMap mocks base method.
 func (m *MockPrompter) SetKeyMap(arg0 prompt.KeyMap) error {
 	m.ctrl.T.Helper()
@@ -315,6 +414,18 @@ func (mr *MockPrompterMockRecorder) SetKeyMap(arg0 any) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetKeyMap", reflect.TypeOf((*MockPrompter)(nil).SetKeyMap), arg0)
 }
 
+// SetMouseSupport mocks base method.
+func (m *MockPrompter) SetMouseSupport(arg0 bool) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "SetMouseSupport", arg0)
+}
+
+// SetMouseSupport indicates an expected call of SetMouseSupport.
+func (mr *MockPrompterMockRecorder) SetMouseSupport(arg0 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetMouseSupport", reflect.TypeOf((*MockPrompter)(nil).SetMouseSupport), arg0)
+}
+
 // SetOutput mocks base method.
 func (m *MockPrompter) SetOutput(arg0 io.Writer) {
 	m.ctrl.T.Helper()
@@ -327,6 +438,18 @@ func (mr *MockPrompterMockRecorder) SetOutput(arg0 any) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetOutput", reflect.TypeOf((*MockPrompter)(nil).SetOutput), arg0)
 }
 
+// SetPlaceholder mocks base method.
+func (m *MockPrompter) SetPlaceholder(arg0 string) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "SetPlaceholder", arg0)
+}
+
+// SetPlaceholder indicates an expected call of SetPlaceholder.
+func (mr *MockPrompterMockRecorder) SetPlaceholder(arg0 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetPlaceholder", reflect.TypeOf((*MockPrompter)(nil).SetPlaceholder), arg0)
+}
+
 // SetPrefix mocks base method.
 func (m *MockPrompter) SetPrefix(arg0 string) {
 	m.ctrl.T.Helper()
@@ -351,6 +474,18 @@ func (mr *MockPrompterMockRecorder) SetPrefixer(arg0 any) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetPrefixer", reflect.TypeOf((*MockPrompter)(nil).SetPrefixer), arg0)
 }
 
+// SetRecorder mocks base method.
+func (m *MockPrompter) SetRecorder(arg0 prompt.Recorder) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "SetRecorder", arg0)
+}
+
+// SetRecorder indicates an expected call of SetRecorder.
+func (mr *MockPrompterMockRecorder) SetRecorder(arg0 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetRecorder", reflect.TypeOf((*MockPrompter)(nil).SetRecorder), arg0)
+}
+
 // SetRefreshInterval mocks base method.
 func (m *MockPrompter) SetRefreshInterval(arg0 time.Duration) {
 	m.ctrl.T.Helper()
@@ -363,6 +498,42 @@ func (mr *MockPrompterMockRecorder) SetRefreshInterval(arg0 any) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetRefreshInterval", reflect.TypeOf((*MockPrompter)(nil).SetRefreshInterval), arg0)
 }
 
+// SetRightPrompt mocks base method.
+func (m *MockPrompter) SetRightPrompt(arg0 string) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "SetRightPrompt", arg0)
+}
+
+// SetRightPrompt indicates an expected call of SetRightPrompt.
+func (mr *MockPrompterMockRecorder) SetRightPrompt(arg0 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetRightPrompt", reflect.TypeOf((*MockPrompter)(nil).SetRightPrompt), arg0)
+}
+
+// SetRightGutter mocks base method.
+func (m *MockPrompter) SetRightGutter(arg0 prompt.RightGutter) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "SetRightGutter", arg0)
+}
+
+// SetRightGutter indicates an expected call of SetRightGutter.
+func (mr *MockPrompterMockRecorder) SetRightGutter(arg0 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetRightGutter", reflect.TypeOf((*MockPrompter)(nil).SetRightGutter), arg0)
+}
+
+// SetRightPrompter mocks base method.
+func (m *MockPrompter) SetRightPrompter(arg0 prompt.Prefixer) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "SetRightPrompter", arg0)
+}
+
+// SetRightPrompter indicates an expected call of SetRightPrompter.
+func (mr *MockPrompterMockRecorder) SetRightPrompter(arg0 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetRightPrompter", reflect.TypeOf((*MockPrompter)(nil).SetRightPrompter), arg0)
+}
+
 // SetStyle mocks base method.
 func (m *MockPrompter) SetStyle(arg0 prompt.Style) {
 	m.ctrl.T.Helper()
@@ -375,6 +546,18 @@ func (mr *MockPrompterMockRecorder) SetStyle(arg0 any) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetStyle", reflect.TypeOf((*MockPrompter)(nil).SetStyle), arg0)
 }
 
+// SetSynchronizedUpdates mocks base method.
+func (m *MockPrompter) SetSynchronizedUpdates(arg0 bool) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "SetSynchronizedUpdates", arg0)
+}
+
+// SetSynchronizedUpdates indicates an expected call of SetSynchronizedUpdates.
+func (mr *MockPrompterMockRecorder) SetSynchronizedUpdates(arg0 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetSynchronizedUpdates", reflect.TypeOf((*MockPrompter)(nil).SetSynchronizedUpdates), arg0)
+}
+
 // SetSyntaxHighlighter mocks base method.
 func (m *MockPrompter) SetSyntaxHighlighter(arg0 prompt.SyntaxHighlighter) {
 	m.ctrl.T.Helper()
@@ -399,6 +582,42 @@ func (mr *MockPrompterMockRecorder) SetTerminationChecker(arg0 any) *gomock.Call
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetTerminationChecker", reflect.TypeOf((*MockPrompter)(nil).SetTerminationChecker), arg0)
 }
 
+// SetTransientPrefixer mocks base method.
+func (m *MockPrompter) SetTransientPrefixer(arg0 prompt.Prefixer) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "SetTransientPrefixer", arg0)
+}
+
+// SetTransientPrefixer indicates an expected call of SetTransientPrefixer.
+func (mr *MockPrompterMockRecorder) SetTransientPrefixer(arg0 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetTransientPrefixer", reflect.TypeOf((*MockPrompter)(nil).SetTransientPrefixer), arg0)
+}
+
+// SetValidator mocks base method.
+func (m *MockPrompter) SetValidator(arg0 prompt.Validator) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "SetValidator", arg0)
+}
+
+// SetValidator indicates an expected call of SetValidator.
+func (mr *MockPrompterMockRecorder) SetValidator(arg0 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetValidator", reflect.TypeOf((*MockPrompter)(nil).SetValidator), arg0)
+}
+
+// SetVisualHomeEnd mocks base method.
+func (m *MockPrompter) SetVisualHomeEnd(arg0 bool) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "SetVisualHomeEnd", arg0)
+}
+
+// SetVisualHomeEnd indicates an expected call of SetVisualHomeEnd.
+func (mr *MockPrompterMockRecorder) SetVisualHomeEnd(arg0 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetVisualHomeEnd", reflect.TypeOf((*MockPrompter)(nil).SetVisualHomeEnd), arg0)
+}
+
 // SetWidthEnforcer mocks base method.
 func (m *MockPrompter) SetWidthEnforcer(arg0 prompt.WidthEnforcer) {
 	m.ctrl.T.Helper()
@@ -424,3 +643,17 @@ func (mr *MockPrompterMockRecorder) Style() *gomock.Call {
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Style", reflect.TypeOf((*MockPrompter)(nil).Style))
 }
+
+// Writer mocks base method.
+func (m *MockPrompter) Writer() io.Writer {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Writer")
+	ret0, _ := ret[0].(io.Writer)
+	return ret0
+}
+
+// Writer indicates an expected call of Writer.
+func (mr *MockPrompterMockRecorder) Writer() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Writer", reflect.TypeOf((*MockPrompter)(nil).Writer))
+}
@@ -8,12 +8,14 @@ import (
 
 	"github.com/jedib0t/go-pretty/v6/text"
 	"github.com/jedib0t/go-prompter/prompt"
+	"github.com/muesli/termenv"
 	"golang.org/x/term"
 )
 
 // Powerline helps construct a powerline like prompt with segmented contents.
 type Powerline struct {
 	autoAdjustWidth  bool
+	colorProfile     *termenv.Profile
 	hasChanges       bool
 	left             []*Segment
 	leftRendered     string
@@ -79,9 +81,21 @@ func (p *Powerline) Render(maxWidth int) string {
 		right := p.renderRight(maxWidth, nsLeft, nsRight, paddingSpace)
 		rightLen := text.RuneWidthWithoutEscSequences(right)
 		padding := p.renderPadding(maxWidth - (leftLen + rightLen))
-		return fmt.Sprintf("%s%s%s", left, padding, right)
+		return p.convertColors(fmt.Sprintf("%s%s%s", left, padding, right))
 	}
-	return left
+	return p.convertColors(left)
+}
+
+// SetColorProfile sets up the colors to be downsampled to the ones supported
+// by the given color profile when rendering. This is not needed when the
+// Powerline is rendered as the header or the prefix of a Prompt as the Prompt
+// does it already for its output.
+func (p *Powerline) SetColorProfile(profile termenv.Profile) {
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+
+	p.hasChanges = true
+	p.colorProfile = &profile
 }
 
 func (p *Powerline) SetStyle(style Style) {
@@ -112,6 +126,13 @@ func (p *Powerline) autoAdjustNumSegments(maxWidth int) (int, int, int) {
 	return idxLeft, idxRight, maxWidth - usedWidth
 }
 
+func (p *Powerline) convertColors(str string) string {
+	if p.colorProfile == nil {
+		return str
+	}
+	return prompt.ConvertColors(str, *p.colorProfile)
+}
+
 func (p *Powerline) hasChangesLeft() bool {
 	if p.hasChanges {
 		return true
@@ -157,6 +157,22 @@ func TestPowerline_Render(t *testing.T) {
 			p.Render(25),
 		)
 	})
+
+	t.Run("with a color profile", func(t *testing.T) {
+		p := Powerline{}
+		p.Append(segUser)
+		p.AppendRight(segTime)
+		p.SetStyle(style)
+
+		out := p.Render(30)
+		assert.Contains(t, out, "\x1b[38;5;7;48;5;17m")
+		p.SetColorProfile(termenv.ANSI)
+		assert.Equal(t, prompt.ConvertColors(out, termenv.ANSI), p.Render(30))
+		assert.NotContains(t, p.Render(30), "38;5;")
+		p.SetColorProfile(termenv.Ascii)
+		assert.Equal(t, " 👤 username ◢     ◣ 12:13:14 ", p.Render(30))
+		assert.NotContains(t, p.Render(30), "\x1b")
+	})
 }
 
 func TestPowerline_hasChangesLeft(t *testing.T) {
@@ -18,28 +18,53 @@ const (
 	/*
 	 * Insert-mode Actions
 	 */
-	AutoComplete           Action = "AutoComplete"           // force an auto-complete
-	DeleteCharCurrent      Action = "DeleteCharCurrent"      // delete the character at the cursor
-	DeleteCharPrevious     Action = "DeleteCharPrevious"     // delete the character before the cursor
-	DeleteWordNext         Action = "DeleteWordNext"         // delete the next work
-	DeleteWordPrevious     Action = "DeleteWordPrevious"     // delete the previous word
-	EraseEverything        Action = "EraseEverything"        // erase the entire prompt
-	EraseToBeginningOfLine Action = "EraseToBeginningOfLine" // erase from cursor to the beginning of current line
-	EraseToEndOfLine       Action = "EraseToEndOfLine"       // erase from cursor to the end of current line
-	HistoryNext            Action = "HistoryNext"            // show command executed after current command if any
-	HistoryPrevious        Action = "HistoryPrevious"        // show previously executed command if any
-	MakeWordCapitalCase    Action = "MakeWordCapitalCase"    // make the word at the cursor capitalized
-	MakeWordLowerCase      Action = "MakeWordLowerCase"      // make the word at the cursor lower case
-	MakeWordUpperCase      Action = "MakeWordUpperCase"      // make the word at the cursor upper case
-	MoveDownOneLine        Action = "MoveDownOneLine"        // move the cursor down one line
-	MoveLeftOneCharacter   Action = "MoveLeftOneCharacter"   // move the cursor left one character
-	MoveRightOneCharacter  Action = "MoveRightOneCharacter"  // move the cursor right one character
-	MoveUpOneLine          Action = "MoveUpOneLine"          // move the cursor up one line
-	MoveToBeginning        Action = "MoveToBeginning"        // move to the beginning of the entire prompt text
-	MoveToBeginningOfLine  Action = "MoveToBeginningOfLine"  // move to the beginning of the current line
-	MoveToEnd              Action = "MoveToEnd"              // move to the end of the entire prompt text
-	MoveToEndOfLine        Action = "MoveToEndOfLine"        // move to the end of the current line
-	MoveToWordNext         Action = "MoveToWordNext"         // move to the beginning of the next word
-	MoveToWordPrevious     Action = "MoveToWordPrevious"     // move to the beginning of the previous word
-	Terminate              Action = "Terminate"              // trigger the termination checker if any, or return the text
+	AutoComplete            Action = "AutoComplete"            // force an auto-complete
+	Copy                    Action = "Copy"                    // copy the selected text (or everything) to the clipboard
+	Cut                     Action = "Cut"                     // cut the selected text (or everything) to the clipboard
+	Dedent                  Action = "Dedent"                  // remove one level of indentation from the current/selected lines
+	DeleteCharCurrent       Action = "DeleteCharCurrent"       // delete the character at the cursor
+	DeleteCharPrevious      Action = "DeleteCharPrevious"      // delete the character before the cursor
+	DeleteLine              Action = "DeleteLine"              // delete the current line entirely
+	DeleteWordNext          Action = "DeleteWordNext"          // delete the next work
+	DeleteWordPrevious      Action = "DeleteWordPrevious"      // delete the previous word
+	DuplicateLine           Action = "DuplicateLine"           // duplicate the current line below it
+	EditInExternalEditor    Action = "EditInExternalEditor"    // edit the prompt text in an external editor ($VISUAL/$EDITOR)
+	EraseEverything         Action = "EraseEverything"         // erase the entire prompt
+	EraseToBeginningOfLine  Action = "EraseToBeginningOfLine"  // erase from cursor to the beginning of current line
+	EraseToEndOfLine        Action = "EraseToEndOfLine"        // erase from cursor to the end of current line
+	HistoryNext             Action = "HistoryNext"             // show command executed after current command if any
+	HistoryPrevious         Action = "HistoryPrevious"         // show previously executed command if any
+	Indent                  Action = "Indent"                  // add one level of indentation to the current/selected lines
+	JoinWithNextLine        Action = "JoinWithNextLine"        // join the next line with the current line
+	JumpToMatchingBracket   Action = "JumpToMatchingBracket"   // move the cursor to the bracket matching the one at/before the cursor
+	MakeWordCapitalCase     Action = "MakeWordCapitalCase"     // make the word at the cursor capitalized
+	MakeWordLowerCase       Action = "MakeWordLowerCase"       // make the word at the cursor lower case
+	MakeWordUpperCase       Action = "MakeWordUpperCase"       // make the word at the cursor upper case
+	MoveDownOneLine         Action = "MoveDownOneLine"         // move the cursor down one line
+	MoveLeftOneCharacter    Action = "MoveLeftOneCharacter"    // move the cursor left one character
+	MoveLineDown            Action = "MoveLineDown"            // move the current line down below the next line
+	MoveLineUp              Action = "MoveLineUp"              // move the current line up above the previous line
+	MoveRightOneCharacter   Action = "MoveRightOneCharacter"   // move the cursor right one character
+	MoveUpOneLine           Action = "MoveUpOneLine"           // move the cursor up one line
+	MoveToBeginning         Action = "MoveToBeginning"         // move to the beginning of the entire prompt text
+	MoveToBeginningOfLine   Action = "MoveToBeginningOfLine"   // move to the beginning of the current line
+	MoveToEnd               Action = "MoveToEnd"               // move to the end of the entire prompt text
+	MoveToEndOfLine         Action = "MoveToEndOfLine"         // move to the end of the current line
+	MoveToWordNext          Action = "MoveToWordNext"          // move to the beginning of the next word
+	MoveToWordPrevious      Action = "MoveToWordPrevious"      // move to the beginning of the previous word
+	OpenLineAbove           Action = "OpenLineAbove"           // insert a new line above the current line
+	OpenLineBelow           Action = "OpenLineBelow"           // insert a new line below the current line
+	Paste                   Action = "Paste"                   // paste the last copied/cut text
+	SelectDownOneLine       Action = "SelectDownOneLine"       // extend the selection down one line
+	SelectLeftOneCharacter  Action = "SelectLeftOneCharacter"  // extend the selection left one character
+	SelectRightOneCharacter Action = "SelectRightOneCharacter" // extend the selection right one character
+	SelectToBeginning       Action = "SelectToBeginning"       // extend the selection to the beginning of the entire prompt text
+	SelectToBeginningOfLine Action = "SelectToBeginningOfLine" // extend the selection to the beginning of the current line
+	SelectToEnd             Action = "SelectToEnd"             // extend the selection to the end of the entire prompt text
+	SelectToEndOfLine       Action = "SelectToEndOfLine"       // extend the selection to the end of the current line
+	SelectToWordNext        Action = "SelectToWordNext"        // extend the selection to the beginning of the next word
+	SelectToWordPrevious    Action = "SelectToWordPrevious"    // extend the selection to the beginning of the previous word
+	SelectUpOneLine         Action = "SelectUpOneLine"         // extend the selection up one line
+	Terminate               Action = "Terminate"               // trigger the termination checker if any, or return the text
+	ToggleLineComment       Action = "ToggleLineComment"       // comment out (or back in) the current/selected lines
 )
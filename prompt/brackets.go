@@ -0,0 +1,122 @@
+package prompt
+
+import (
+	"strings"
+	"unicode/utf8"
+)
+
+// closingRune returns the rune that closes the given opening bracket or quote.
+func (s StyleBrackets) closingRune(r rune) (rune, bool) {
+	if strings.ContainsRune(s.Quotes, r) {
+		return r, true
+	}
+	pairs := []rune(s.Pairs)
+	for idx := 0; idx+1 < len(pairs); idx += 2 {
+		if pairs[idx] == r {
+			return pairs[idx+1], true
+		}
+	}
+	return 0, false
+}
+
+// isClosingRune returns true if the given rune closes a bracket or a quote.
+func (s StyleBrackets) isClosingRune(r rune) bool {
+	if strings.ContainsRune(s.Quotes, r) {
+		return true
+	}
+	pairs := []rune(s.Pairs)
+	for idx := 1; idx < len(pairs); idx += 2 {
+		if pairs[idx] == r {
+			return true
+		}
+	}
+	return false
+}
+
+// matchBrackets scans the given lines and returns a map of the location of
+// every bracket to that of its matching bracket (in both directions). The
+// brackets within string literals and comments are ignored, and so are the
+// brackets without a match.
+//
+//gocyclo:ignore
+func (s StyleBrackets) matchBrackets(lines []string) map[CursorLocation]CursorLocation {
+	type openBracket struct {
+		closing  rune
+		location CursorLocation
+	}
+
+	rsp := make(map[CursorLocation]CursorLocation)
+	var stack []openBracket
+	var quote rune
+	inBlockComment := false
+	for lineIdx, line := range lines {
+	scanLine:
+		for colIdx := 0; colIdx < len(line); {
+			r, size := utf8.DecodeRuneInString(line[colIdx:])
+			remaining := line[colIdx:]
+			switch {
+			case inBlockComment:
+				if strings.HasPrefix(remaining, s.CommentBlockEnd) {
+					inBlockComment = false
+					size = len(s.CommentBlockEnd)
+				}
+			case quote != 0:
+				if r == '\\' && colIdx+size < len(line) { // skip the escaped rune
+					_, escapedSize := utf8.DecodeRuneInString(line[colIdx+size:])
+					size += escapedSize
+				} else if r == quote {
+					quote = 0
+				}
+			case s.CommentBlockStart != "" && strings.HasPrefix(remaining, s.CommentBlockStart):
+				inBlockComment = true
+				size = len(s.CommentBlockStart)
+			case hasAnyPrefix(remaining, s.CommentLine):
+				break scanLine
+			case strings.ContainsRune(s.Quotes, r):
+				quote = r
+			default:
+				location := CursorLocation{Line: lineIdx, Column: colIdx}
+				if closing, ok := s.closingRune(r); ok {
+					stack = append(stack, openBracket{closing: closing, location: location})
+				} else if s.isClosingRune(r) && len(stack) > 0 && stack[len(stack)-1].closing == r {
+					opening := stack[len(stack)-1].location
+					stack = stack[:len(stack)-1]
+					rsp[opening] = location
+					rsp[location] = opening
+				}
+			}
+			colIdx += size
+		}
+	}
+	return rsp
+}
+
+// findMatchingBracket returns the location of the bracket at (or right before)
+// the cursor and that of the bracket matching it.
+func (s StyleBrackets) findMatchingBracket(lines []string, cursor CursorLocation) (CursorLocation, CursorLocation, bool) {
+	if cursor.Line < 0 || cursor.Line >= len(lines) {
+		return CursorLocation{}, CursorLocation{}, false
+	}
+
+	matches := s.matchBrackets(lines)
+	if match, ok := matches[cursor]; ok {
+		return cursor, match, true
+	}
+	if cursor.Column > 0 && cursor.Column <= len(lines[cursor.Line]) {
+		_, size := utf8.DecodeLastRuneInString(lines[cursor.Line][:cursor.Column])
+		before := CursorLocation{Line: cursor.Line, Column: cursor.Column - size}
+		if match, ok := matches[before]; ok {
+			return before, match, true
+		}
+	}
+	return CursorLocation{}, CursorLocation{}, false
+}
+
+func hasAnyPrefix(str string, prefixes []string) bool {
+	for _, prefix := range prefixes {
+		if prefix != "" && strings.HasPrefix(str, prefix) {
+			return true
+		}
+	}
+	return false
+}
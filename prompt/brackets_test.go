@@ -0,0 +1,75 @@
+package prompt
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestStyleBrackets_closingRune(t *testing.T) {
+	s := StyleBracketsGo
+
+	for opening, expected := range map[rune]rune{'(': ')', '[': ']', '{': '}', '"': '"', '`': '`'} {
+		closing, ok := s.closingRune(opening)
+		assert.True(t, ok, string(opening))
+		assert.Equal(t, expected, closing, string(opening))
+	}
+	_, ok := s.closingRune(')')
+	assert.False(t, ok)
+	_, ok = s.closingRune('a')
+	assert.False(t, ok)
+
+	assert.True(t, s.isClosingRune(')'))
+	assert.True(t, s.isClosingRune('\''))
+	assert.False(t, s.isClosingRune('('))
+}
+
+func TestStyleBrackets_matchBrackets(t *testing.T) {
+	s := StyleBracketsGo
+
+	matches := s.matchBrackets([]string{`fmt.Println("(", x[0]) // (`, `/* ) */ f(`, `)`})
+	expected := map[CursorLocation]CursorLocation{
+		{Line: 0, Column: 11}: {Line: 0, Column: 21},
+		{Line: 0, Column: 21}: {Line: 0, Column: 11},
+		{Line: 0, Column: 18}: {Line: 0, Column: 20},
+		{Line: 0, Column: 20}: {Line: 0, Column: 18},
+		{Line: 1, Column: 9}:  {Line: 2, Column: 0},
+		{Line: 2, Column: 0}:  {Line: 1, Column: 9},
+	}
+	assert.Equal(t, expected, matches)
+
+	// escaped quotes and mismatched brackets
+	matches = s.matchBrackets([]string{`("\")" ]`})
+	assert.Equal(t, map[CursorLocation]CursorLocation{}, matches)
+	matches = s.matchBrackets([]string{`("\"" )`})
+	assert.Equal(t, CursorLocation{Line: 0, Column: 6}, matches[CursorLocation{Line: 0, Column: 0}])
+
+	// comments depend on the language
+	matches = StyleBracketsSQL.matchBrackets([]string{`-- (`, `(// )`})
+	assert.Equal(t, CursorLocation{Line: 1, Column: 4}, matches[CursorLocation{Line: 1, Column: 0}])
+}
+
+func TestStyleBrackets_findMatchingBracket(t *testing.T) {
+	s := StyleBracketsDefault
+	lines := []string{"a(b)c", "[x]"}
+
+	for _, tc := range []struct {
+		cursor  CursorLocation
+		bracket CursorLocation
+		match   CursorLocation
+		ok      bool
+	}{
+		{cursor: CursorLocation{Line: 0, Column: 0}},
+		{cursor: CursorLocation{Line: 0, Column: 1}, bracket: CursorLocation{Line: 0, Column: 1}, match: CursorLocation{Line: 0, Column: 3}, ok: true},
+		{cursor: CursorLocation{Line: 0, Column: 2}, bracket: CursorLocation{Line: 0, Column: 1}, match: CursorLocation{Line: 0, Column: 3}, ok: true},
+		{cursor: CursorLocation{Line: 0, Column: 4}, bracket: CursorLocation{Line: 0, Column: 3}, match: CursorLocation{Line: 0, Column: 1}, ok: true},
+		{cursor: CursorLocation{Line: 0, Column: 5}},
+		{cursor: CursorLocation{Line: 1, Column: 3}, bracket: CursorLocation{Line: 1, Column: 2}, match: CursorLocation{Line: 1, Column: 0}, ok: true},
+		{cursor: CursorLocation{Line: 2, Column: 0}},
+	} {
+		bracket, match, ok := s.findMatchingBracket(lines, tc.cursor)
+		assert.Equal(t, tc.ok, ok, tc.cursor.String())
+		assert.Equal(t, tc.bracket, bracket, tc.cursor.String())
+		assert.Equal(t, tc.match, match, tc.cursor.String())
+	}
+}
@@ -6,6 +6,8 @@ import (
 	"strings"
 	"sync"
 	"time"
+	"unicode"
+	"unicode/utf8"
 )
 
 // buffer helps store the user input, track the cursor position, and help
@@ -18,6 +20,7 @@ type buffer struct {
 	linesChanged   linesChangedMap
 	linesRendered  string
 	mutex          sync.Mutex
+	selection      *CursorLocation // where the selection (if any) started
 	tab            string
 }
 
@@ -33,6 +36,17 @@ func newBuffer() *buffer {
 	}
 }
 
+// ClearSelection clears the selection (if any) without touching the text.
+func (b *buffer) ClearSelection() {
+	b.mutex.Lock()
+	defer b.mutex.Unlock()
+
+	if b.selection != nil {
+		b.selection = nil
+		b.linesChanged.MarkAll()
+	}
+}
+
 // Cursor returns the current Cursor Location.
 func (b *buffer) Cursor() CursorLocation {
 	b.mutex.Lock()
@@ -41,6 +55,16 @@ func (b *buffer) Cursor() CursorLocation {
 	return b.cursor
 }
 
+// Dedent removes one level of indentation (the tab string, or whatever is
+// there of it) from the current line, or from all the lines with some text
+// selected.
+func (b *buffer) Dedent() {
+	b.mutex.Lock()
+	defer b.mutex.Unlock()
+
+	b.shiftLines(false)
+}
+
 // DeleteBackward deletes n runes backwards
 func (b *buffer) DeleteBackward(n int, locked ...bool) {
 	if len(locked) == 0 {
@@ -139,6 +163,44 @@ func (b *buffer) DeleteForwardToEndOfLine(locked ...bool) {
 	b.DeleteForward(len(b.getCurrentLine())-b.cursor.Column, true)
 }
 
+// DeleteLine deletes the current line entirely.
+func (b *buffer) DeleteLine() {
+	b.mutex.Lock()
+	defer b.mutex.Unlock()
+
+	if len(b.lines) == 1 {
+		b.lines[0] = ""
+		b.cursor.Column = 0
+	} else {
+		b.lines = append(b.lines[:b.cursor.Line], b.lines[b.cursor.Line+1:]...)
+		if b.cursor.Line >= len(b.lines) {
+			b.cursor.Line = len(b.lines) - 1
+		}
+		if line := b.getCurrentLine(); b.cursor.Column > len(line) {
+			b.cursor.Column = len(line)
+		}
+	}
+	b.linesChanged.MarkAll()
+}
+
+// DeleteSelection deletes the selected text (if any) and returns true if
+// something was deleted.
+func (b *buffer) DeleteSelection() bool {
+	b.mutex.Lock()
+	defer b.mutex.Unlock()
+
+	start, end, ok := b.getSelection()
+	if b.selection != nil {
+		b.selection = nil
+		b.linesChanged.MarkAll()
+	}
+	if !ok {
+		return false
+	}
+	b.deleteRange(start, end)
+	return true
+}
+
 // DeleteWordBackward deletes the previous word
 func (b *buffer) DeleteWordBackward() {
 	b.mutex.Lock()
@@ -213,6 +275,16 @@ func (b *buffer) Display() ([]string, CursorLocation) {
 	return lines, cursor
 }
 
+// DuplicateLine inserts a copy of the current line below it, and moves the
+// cursor to the same column on the copy.
+func (b *buffer) DuplicateLine() {
+	b.mutex.Lock()
+	defer b.mutex.Unlock()
+
+	b.insertLine(b.cursor.Line+1, b.getCurrentLine())
+	b.cursor.Line++
+}
+
 // HasChanges returns true if Render() will return something else on the next
 // call to it.
 func (b *buffer) HasChanges() bool {
@@ -222,6 +294,24 @@ func (b *buffer) HasChanges() bool {
 	return b.linesChanged.AnythingChanged()
 }
 
+// HasSelection returns true if some text is selected.
+func (b *buffer) HasSelection() bool {
+	b.mutex.Lock()
+	defer b.mutex.Unlock()
+
+	_, _, ok := b.getSelection()
+	return ok
+}
+
+// Indent adds one level of indentation (the tab string) to the current line,
+// or to all the lines with some text selected.
+func (b *buffer) Indent() {
+	b.mutex.Lock()
+	defer b.mutex.Unlock()
+
+	b.shiftLines(true)
+}
+
 // Insert inserts the string at the current cursor position
 func (b *buffer) Insert(r rune, locked ...bool) {
 	if len(locked) == 0 {
@@ -277,6 +367,26 @@ func (b *buffer) IsDone() bool {
 	return b.done
 }
 
+// JoinWithNextLine appends the next line to the current one with the
+// indentation of the next line replaced by a single space, and moves the
+// cursor to where they were joined.
+func (b *buffer) JoinWithNextLine() {
+	b.mutex.Lock()
+	defer b.mutex.Unlock()
+
+	if b.cursor.Line == len(b.lines)-1 {
+		return
+	}
+	line, nextLine := b.getCurrentLine(), strings.TrimLeft(b.getLine(b.cursor.Line+1), " \t")
+	if line != "" && nextLine != "" && !strings.HasSuffix(line, " ") {
+		line += " "
+	}
+	b.lines[b.cursor.Line] = line + nextLine
+	b.lines = append(b.lines[:b.cursor.Line+1], b.lines[b.cursor.Line+2:]...)
+	b.cursor.Column = len(line)
+	b.linesChanged.MarkAll()
+}
+
 // Length returns the current input length
 func (b *buffer) Length() int {
 	return len(b.String())
@@ -392,6 +502,30 @@ func (b *buffer) MoveLeft(n int) {
 	}
 }
 
+// MoveLineDown swaps the current line with the one below it, and moves the
+// cursor along with it.
+func (b *buffer) MoveLineDown() {
+	b.mutex.Lock()
+	defer b.mutex.Unlock()
+
+	if b.cursor.Line < len(b.lines)-1 {
+		b.swapLines(b.cursor.Line, b.cursor.Line+1)
+		b.cursor.Line++
+	}
+}
+
+// MoveLineUp swaps the current line with the one above it, and moves the
+// cursor along with it.
+func (b *buffer) MoveLineUp() {
+	b.mutex.Lock()
+	defer b.mutex.Unlock()
+
+	if b.cursor.Line > 0 {
+		b.swapLines(b.cursor.Line, b.cursor.Line-1)
+		b.cursor.Line--
+	}
+}
+
 // MoveRight moves the cursor right n runes
 func (b *buffer) MoveRight(n int) {
 	b.mutex.Lock()
@@ -421,6 +555,28 @@ func (b *buffer) MoveRight(n int) {
 	}
 }
 
+// MoveTo moves the cursor to the given location, or the closest valid location
+// to it.
+func (b *buffer) MoveTo(location CursorLocation) {
+	b.mutex.Lock()
+	defer b.mutex.Unlock()
+
+	b.linesChanged.Mark(b.cursor.Line) // before
+	b.cursor.Line = location.Line
+	if b.cursor.Line < 0 {
+		b.cursor.Line = 0
+	} else if b.cursor.Line >= len(b.lines) {
+		b.cursor.Line = len(b.lines) - 1
+	}
+	b.linesChanged.Mark(b.cursor.Line) // after
+	b.cursor.Column = location.Column
+	if b.cursor.Column < 0 {
+		b.cursor.Column = 0
+	} else if line := b.getCurrentLine(); b.cursor.Column > len(line) {
+		b.cursor.Column = len(line)
+	}
+}
+
 // MoveToBeginning moves the cursor right to the beginning of the first line
 func (b *buffer) MoveToBeginning() {
 	b.mutex.Lock()
@@ -566,11 +722,69 @@ func (b *buffer) NumLines() int {
 	return len(b.lines)
 }
 
+// OpenLineAbove inserts a new line with the given indentation above the
+// current one, and moves the cursor to the end of it.
+func (b *buffer) OpenLineAbove(indent string) {
+	b.mutex.Lock()
+	defer b.mutex.Unlock()
+
+	b.insertLine(b.cursor.Line, indent)
+	b.cursor.Column = len(indent)
+}
+
+// OpenLineBelow inserts a new line with the given indentation below the
+// current one, and moves the cursor to the end of it.
+func (b *buffer) OpenLineBelow(indent string) {
+	b.mutex.Lock()
+	defer b.mutex.Unlock()
+
+	b.insertLine(b.cursor.Line+1, indent)
+	b.cursor = CursorLocation{Line: b.cursor.Line + 1, Column: len(indent)}
+}
+
 // Reset resets the buffer to its initial state
 func (b *buffer) Reset() {
 	b.Set("")
 }
 
+// RunesAroundCursor returns the runes right before and at the cursor on the
+// current line; 0 is returned for either if the cursor is at the beginning or
+// the end of the line respectively.
+func (b *buffer) RunesAroundCursor() (rune, rune) {
+	b.mutex.Lock()
+	defer b.mutex.Unlock()
+
+	line := b.getCurrentLine()
+	var prev, next rune
+	if b.cursor.Column > 0 {
+		prev, _ = utf8.DecodeLastRuneInString(line[:b.cursor.Column])
+	}
+	if b.cursor.Column < len(line) {
+		next, _ = utf8.DecodeRuneInString(line[b.cursor.Column:])
+	}
+	return prev, next
+}
+
+// Selection returns the beginning and the end of the selected text (if any).
+func (b *buffer) Selection() (CursorLocation, CursorLocation, bool) {
+	b.mutex.Lock()
+	defer b.mutex.Unlock()
+
+	return b.getSelection()
+}
+
+// SelectionText returns the selected text.
+func (b *buffer) SelectionText() string {
+	b.mutex.Lock()
+	defer b.mutex.Unlock()
+
+	start, end, ok := b.getSelection()
+	if !ok {
+		return ""
+	}
+	return b.getText(start, end)
+}
+
 // Set overwrites the contents of the buffer with the given string.
 func (b *buffer) Set(str string) {
 	b.mutex.Lock()
@@ -580,6 +794,7 @@ func (b *buffer) Set(str string) {
 
 	b.done = false
 	b.lines = strings.Split(str, "\n")
+	b.selection = nil
 	b.linesChanged.MarkAll()
 	b.linesRendered = time.Now().Format(time.RFC3339Nano)
 	b.cursor = CursorLocation{
@@ -593,6 +808,19 @@ func (b *buffer) SetTab(tab string) {
 	b.tab = tab
 }
 
+// StartSelection starts a selection at the cursor unless one is in progress
+// already. The selection covers everything between this location and the
+// cursor as it moves around.
+func (b *buffer) StartSelection() {
+	b.mutex.Lock()
+	defer b.mutex.Unlock()
+
+	if b.selection == nil {
+		selection := b.cursor
+		b.selection = &selection
+	}
+}
+
 // String returns the current input from the user.
 func (b *buffer) String() string {
 	b.mutex.Lock()
@@ -601,6 +829,120 @@ func (b *buffer) String() string {
 	return strings.Join(b.lines, "\n")
 }
 
+// SurroundSelection wraps the selected text with the given runes and keeps
+// the original text selected. Returns false if nothing was selected.
+func (b *buffer) SurroundSelection(opening, closing rune) bool {
+	b.mutex.Lock()
+	defer b.mutex.Unlock()
+
+	start, end, ok := b.getSelection()
+	if !ok {
+		return false
+	}
+	cursorAtEnd := b.cursor == end
+
+	openingStr, closingStr := string(opening), string(closing)
+	line := b.lines[end.Line]
+	b.lines[end.Line] = line[:end.Column] + closingStr + line[end.Column:]
+	line = b.lines[start.Line]
+	b.lines[start.Line] = line[:start.Column] + openingStr + line[start.Column:]
+	start.Column += len(openingStr)
+	if start.Line == end.Line {
+		end.Column += len(openingStr)
+	}
+	b.setSelection(start, end, cursorAtEnd)
+	return true
+}
+
+// ToggleLineComment comments out the current line, or all the lines with some
+// text selected, by inserting the given marker after the indentation. If all
+// of them (ignoring empty lines) are commented out already, the marker is
+// removed instead.
+func (b *buffer) ToggleLineComment(marker string) {
+	b.mutex.Lock()
+	defer b.mutex.Unlock()
+
+	markerTrimmed := strings.TrimSpace(marker)
+	if markerTrimmed == "" {
+		return
+	}
+
+	// find the common indentation, and if everything is commented out already
+	startLine, endLine := b.getLinesToEdit()
+	isCommented, indent := true, -1
+	for idx := startLine; idx <= endLine; idx++ {
+		line := b.lines[idx]
+		text := strings.TrimLeft(line, " \t")
+		if text == "" {
+			continue
+		}
+		if !strings.HasPrefix(text, markerTrimmed) {
+			isCommented = false
+		}
+		if lineIndent := len(line) - len(text); indent == -1 || lineIndent < indent {
+			indent = lineIndent
+		}
+	}
+	if indent == -1 { // only empty lines
+		return
+	}
+
+	for idx := startLine; idx <= endLine; idx++ {
+		line := b.lines[idx]
+		text := strings.TrimLeft(line, " \t")
+		if text == "" {
+			continue
+		}
+		if isCommented {
+			column, removed := len(line)-len(text), markerTrimmed
+			if strings.HasPrefix(text, marker) {
+				removed = marker
+			}
+			b.lines[idx] = line[:column] + line[column+len(removed):]
+			b.shiftColumns(idx, column, -len(removed))
+		} else {
+			b.lines[idx] = line[:indent] + marker + line[indent:]
+			b.shiftColumns(idx, indent, len(marker))
+		}
+		b.linesChanged.Mark(idx)
+	}
+}
+
+// TransformSelection replaces the selected text with the output of the given
+// function and keeps the result selected. Returns false if nothing was
+// selected.
+func (b *buffer) TransformSelection(fn func(string) string) bool {
+	b.mutex.Lock()
+	defer b.mutex.Unlock()
+
+	start, end, ok := b.getSelection()
+	if !ok {
+		return false
+	}
+	cursorAtEnd := b.cursor == end
+
+	text := fn(b.getText(start, end))
+	b.deleteRange(start, end)
+	for _, r := range text {
+		b.Insert(r, true)
+	}
+	b.setSelection(start, b.cursor, cursorAtEnd)
+	return true
+}
+
+func (b *buffer) deleteRange(start, end CursorLocation) {
+	lineStart, lineEnd := b.lines[start.Line], b.lines[end.Line]
+
+	var lines []string
+	lines = append(lines, b.lines[:start.Line]...)
+	lines = append(lines, lineStart[:start.Column]+lineEnd[end.Column:])
+	lines = append(lines, b.lines[end.Line+1:]...)
+
+	b.lines = lines
+	b.linesChanged.MarkAll()
+	b.cursor = start
+}
+
 func (b *buffer) getCurrentLine() string {
 	return b.getLine(b.cursor.Line)
 }
@@ -631,6 +973,47 @@ func (b *buffer) getLine(n int) string {
 	return b.lines[n]
 }
 
+// getLinesToEdit returns the range of lines to be edited by an action working
+// on lines: the current line, or all the lines with some text selected.
+func (b *buffer) getLinesToEdit() (int, int) {
+	startLine, endLine := b.cursor.Line, b.cursor.Line
+	if start, end, ok := b.getSelection(); ok {
+		startLine, endLine = start.Line, end.Line
+		if end.Column == 0 && end.Line > start.Line { // nothing selected on the last line
+			endLine--
+		}
+	}
+	return startLine, endLine
+}
+
+// getSelection returns the beginning and the end of the selection in order.
+func (b *buffer) getSelection() (CursorLocation, CursorLocation, bool) {
+	if b.selection == nil || *b.selection == b.cursor {
+		return CursorLocation{}, CursorLocation{}, false
+	}
+	// ignore a selection made stale by changes to the text
+	if b.selection.Line >= len(b.lines) || b.selection.Column > len(b.lines[b.selection.Line]) {
+		return CursorLocation{}, CursorLocation{}, false
+	}
+
+	start, end := *b.selection, b.cursor
+	if end.Line < start.Line || (end.Line == start.Line && end.Column < start.Column) {
+		start, end = end, start
+	}
+	return start, end, true
+}
+
+func (b *buffer) getText(start, end CursorLocation) string {
+	if start.Line == end.Line {
+		return b.lines[start.Line][start.Column:end.Column]
+	}
+
+	lines := []string{b.lines[start.Line][start.Column:]}
+	lines = append(lines, b.lines[start.Line+1:end.Line]...)
+	lines = append(lines, b.lines[end.Line][:end.Column])
+	return strings.Join(lines, "\n")
+}
+
 func (b *buffer) getWordAtCursor(wordDelimiters map[byte]bool) (string, int) {
 	line := b.getCurrentLine()
 	if b.cursor.Column == len(line) || (b.cursor.Column < len(line) && line[b.cursor.Column] == ' ') {
@@ -653,6 +1036,65 @@ func (b *buffer) getWordAtCursor(wordDelimiters map[byte]bool) (string, int) {
 	return "", -1
 }
 
+// insertLine inserts the given line at the given index.
+func (b *buffer) insertLine(idx int, line string) {
+	lines := append([]string{}, b.lines[:idx]...)
+	lines = append(lines, line)
+	b.lines = append(lines, b.lines[idx:]...)
+	b.linesChanged.MarkAll()
+}
+
+func (b *buffer) setSelection(start, end CursorLocation, cursorAtEnd bool) {
+	if cursorAtEnd {
+		b.selection, b.cursor = &start, end
+	} else {
+		b.selection, b.cursor = &end, start
+	}
+	b.linesChanged.MarkAll()
+}
+
+// shiftColumns moves the cursor and the selection (if they are on the given
+// line at or after the given column) by delta columns to follow the text after
+// some text was inserted or removed at the column.
+func (b *buffer) shiftColumns(line int, column int, delta int) {
+	for _, location := range []*CursorLocation{&b.cursor, b.selection} {
+		if location != nil && location.Line == line && location.Column >= column {
+			location.Column += delta
+			if location.Column < column {
+				location.Column = column
+			}
+		}
+	}
+}
+
+// shiftLines indents (or dedents) the current line, or all the lines with some
+// text selected, and moves the cursor and the selection along with the text.
+func (b *buffer) shiftLines(indent bool) {
+	startLine, endLine := b.getLinesToEdit()
+	for idx := startLine; idx <= endLine; idx++ {
+		line, delta := b.lines[idx], 0
+		if indent {
+			b.lines[idx] = b.tab + line
+			delta = len(b.tab)
+		} else {
+			for delta < len(b.tab) && delta < len(line) && line[delta] == ' ' {
+				delta++
+			}
+			b.lines[idx] = line[delta:]
+			delta = -delta
+		}
+		b.linesChanged.Mark(idx)
+		b.shiftColumns(idx, 0, delta)
+	}
+}
+
+// swapLines swaps the lines at the given indices.
+func (b *buffer) swapLines(idx1 int, idx2 int) {
+	b.lines[idx1], b.lines[idx2] = b.lines[idx2], b.lines[idx1]
+	b.linesChanged.Mark(idx1)
+	b.linesChanged.Mark(idx2)
+}
+
 type linesChangedMap map[int]bool
 
 func (lc linesChangedMap) Clear() {
@@ -714,3 +1156,19 @@ var (
 func isPartOfWord(r byte) bool {
 	return !nonWordRunes[r]
 }
+
+// makeCapitalCase converts the first character of every word in the string to
+// upper case, like MakeWordCapitalCase does for a single word.
+func makeCapitalCase(str string) string {
+	out := strings.Builder{}
+	prevIsPartOfWord := false
+	for idx, r := range str {
+		isPoW := isPartOfWord(str[idx])
+		if isPoW && !prevIsPartOfWord {
+			r = unicode.ToUpper(r)
+		}
+		out.WriteRune(r)
+		prevIsPartOfWord = isPoW
+	}
+	return out.String()
+}
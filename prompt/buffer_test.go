@@ -1,6 +1,7 @@
 package prompt
 
 import (
+	"strings"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
@@ -13,6 +14,19 @@ func getNewBuffer(t *testing.T) *buffer {
 	return b
 }
 
+func TestBuffer_ClearSelection(t *testing.T) {
+	b := getNewBuffer(t)
+	b.Set("foo bar")
+	b.StartSelection()
+	b.MoveLeft(3)
+	assert.True(t, b.HasSelection())
+
+	b.ClearSelection()
+	assert.False(t, b.HasSelection())
+	assert.Equal(t, "foo bar", b.String())
+	assert.Equal(t, CursorLocation{Line: 0, Column: 4}, b.cursor)
+}
+
 func TestBuffer_Cursor(t *testing.T) {
 	b := getNewBuffer(t)
 	assert.Equal(t, CursorLocation{Line: 0, Column: 0}, b.Cursor())
@@ -21,6 +35,28 @@ func TestBuffer_Cursor(t *testing.T) {
 	assert.Equal(t, CursorLocation{Line: 1, Column: 3}, b.Cursor())
 }
 
+func TestBuffer_Dedent(t *testing.T) {
+	b := getNewBuffer(t)
+	b.SetTab("  ")
+
+	b.Set("   foo\n bar\nbaz")
+	b.MoveTo(CursorLocation{Line: 0, Column: 4})
+	b.Dedent()
+	assert.Equal(t, " foo\n bar\nbaz", b.String())
+	assert.Equal(t, CursorLocation{Line: 0, Column: 2}, b.Cursor())
+	b.Dedent()
+	assert.Equal(t, "foo\n bar\nbaz", b.String())
+	assert.Equal(t, CursorLocation{Line: 0, Column: 1}, b.Cursor())
+
+	// all the selected lines
+	b.MoveTo(CursorLocation{Line: 1, Column: 2})
+	b.StartSelection()
+	b.MoveTo(CursorLocation{Line: 2, Column: 1})
+	b.Dedent()
+	assert.Equal(t, "foo\nbar\nbaz", b.String())
+	assert.Equal(t, "ar\nb", b.SelectionText())
+}
+
 func TestBuffer_DeleteBackward(t *testing.T) {
 	b := getNewBuffer(t)
 
@@ -142,6 +178,38 @@ func TestBuffer_DeleteForwardToEndOfLine(t *testing.T) {
 	assert.Equal(t, "foo ", b.String())
 }
 
+func TestBuffer_DeleteLine(t *testing.T) {
+	b := getNewBuffer(t)
+
+	b.Set("foo\nbar baz\nx")
+	b.MoveTo(CursorLocation{Line: 1, Column: 5})
+	b.DeleteLine()
+	assert.Equal(t, "foo\nx", b.String())
+	assert.Equal(t, CursorLocation{Line: 1, Column: 1}, b.Cursor())
+	b.DeleteLine()
+	assert.Equal(t, "foo", b.String())
+	assert.Equal(t, CursorLocation{Line: 0, Column: 1}, b.Cursor())
+	b.DeleteLine()
+	assert.Equal(t, "", b.String())
+	assert.Equal(t, CursorLocation{Line: 0, Column: 0}, b.Cursor())
+}
+
+func TestBuffer_DeleteSelection(t *testing.T) {
+	b := getNewBuffer(t)
+	b.Set("foo bar\nbaz")
+	assert.False(t, b.DeleteSelection())
+	assert.Equal(t, "foo bar\nbaz", b.String())
+
+	b.cursor = CursorLocation{Line: 0, Column: 4}
+	b.StartSelection()
+	b.MoveDown(1)
+	assert.True(t, b.DeleteSelection())
+	assert.Equal(t, []string{"foo "}, b.lines)
+	assert.Equal(t, CursorLocation{Line: 0, Column: 4}, b.cursor)
+	assert.False(t, b.HasSelection())
+	assert.Nil(t, b.selection)
+}
+
 func TestBuffer_DeleteWordBackward(t *testing.T) {
 	b := getNewBuffer(t)
 
@@ -274,6 +342,16 @@ func TestBuffer_Display(t *testing.T) {
 	assert.Equal(t, CursorLocation{Line: 1, Column: 3}, cur)
 }
 
+func TestBuffer_DuplicateLine(t *testing.T) {
+	b := getNewBuffer(t)
+
+	b.Set("foo\nbar")
+	b.MoveTo(CursorLocation{Line: 0, Column: 2})
+	b.DuplicateLine()
+	assert.Equal(t, "foo\nfoo\nbar", b.String())
+	assert.Equal(t, CursorLocation{Line: 1, Column: 2}, b.Cursor())
+}
+
 func TestBuffer_HasChanges(t *testing.T) {
 	b := getNewBuffer(t)
 
@@ -293,6 +371,25 @@ func TestBuffer_HasChanges(t *testing.T) {
 	assert.False(t, b.HasChanges())
 }
 
+func TestBuffer_Indent(t *testing.T) {
+	b := getNewBuffer(t)
+	b.SetTab("  ")
+
+	b.Set("foo\nbar\nbaz")
+	b.MoveTo(CursorLocation{Line: 0, Column: 1})
+	b.Indent()
+	assert.Equal(t, "  foo\nbar\nbaz", b.String())
+	assert.Equal(t, CursorLocation{Line: 0, Column: 3}, b.Cursor())
+
+	// all the selected lines, except the last one if nothing on it is selected
+	b.StartSelection()
+	b.MoveTo(CursorLocation{Line: 2, Column: 0})
+	b.Indent()
+	assert.Equal(t, "    foo\n  bar\nbaz", b.String())
+	assert.Equal(t, "oo\n  bar\n", b.SelectionText())
+	assert.Equal(t, CursorLocation{Line: 2, Column: 0}, b.Cursor())
+}
+
 func TestBuffer_Insert(t *testing.T) {
 	b := getNewBuffer(t)
 
@@ -375,6 +472,23 @@ func TestBuffer_IsDone(t *testing.T) {
 	assert.True(t, b.IsDone())
 }
 
+func TestBuffer_JoinWithNextLine(t *testing.T) {
+	b := getNewBuffer(t)
+
+	b.Set("foo\n    bar\n\nbaz")
+	b.MoveTo(CursorLocation{Line: 0, Column: 1})
+	b.JoinWithNextLine()
+	assert.Equal(t, "foo bar\n\nbaz", b.String())
+	assert.Equal(t, CursorLocation{Line: 0, Column: 4}, b.Cursor())
+	b.JoinWithNextLine()
+	assert.Equal(t, "foo bar\nbaz", b.String())
+	assert.Equal(t, CursorLocation{Line: 0, Column: 7}, b.Cursor())
+	b.MoveTo(CursorLocation{Line: 1, Column: 0})
+	b.JoinWithNextLine()
+	assert.Equal(t, "foo bar\nbaz", b.String())
+	assert.Equal(t, CursorLocation{Line: 1, Column: 0}, b.Cursor())
+}
+
 func TestBuffer_Length(t *testing.T) {
 	b := getNewBuffer(t)
 	assert.Equal(t, 0, b.Length())
@@ -539,6 +653,32 @@ func TestBuffer_MoveLeft(t *testing.T) {
 	assert.Equal(t, CursorLocation{Line: 0, Column: 0}, b.cursor)
 }
 
+func TestBuffer_MoveLineDown(t *testing.T) {
+	b := getNewBuffer(t)
+
+	b.Set("foo\nbar\nbaz")
+	b.MoveTo(CursorLocation{Line: 1, Column: 1})
+	b.MoveLineDown()
+	assert.Equal(t, "foo\nbaz\nbar", b.String())
+	assert.Equal(t, CursorLocation{Line: 2, Column: 1}, b.Cursor())
+	b.MoveLineDown()
+	assert.Equal(t, "foo\nbaz\nbar", b.String())
+	assert.Equal(t, CursorLocation{Line: 2, Column: 1}, b.Cursor())
+}
+
+func TestBuffer_MoveLineUp(t *testing.T) {
+	b := getNewBuffer(t)
+
+	b.Set("foo\nbar\nbaz")
+	b.MoveTo(CursorLocation{Line: 1, Column: 1})
+	b.MoveLineUp()
+	assert.Equal(t, "bar\nfoo\nbaz", b.String())
+	assert.Equal(t, CursorLocation{Line: 0, Column: 1}, b.Cursor())
+	b.MoveLineUp()
+	assert.Equal(t, "bar\nfoo\nbaz", b.String())
+	assert.Equal(t, CursorLocation{Line: 0, Column: 1}, b.Cursor())
+}
+
 func TestBuffer_MoveRight(t *testing.T) {
 	b := getNewBuffer(t)
 
@@ -591,6 +731,20 @@ func TestBuffer_MoveRight(t *testing.T) {
 	assert.Equal(t, CursorLocation{Line: 1, Column: 3}, b.cursor)
 }
 
+func TestBuffer_MoveTo(t *testing.T) {
+	b := getNewBuffer(t)
+
+	b.InsertString("foo\nbar baz")
+	b.MoveTo(CursorLocation{Line: 0, Column: 2})
+	assert.Equal(t, CursorLocation{Line: 0, Column: 2}, b.Cursor())
+	b.MoveTo(CursorLocation{Line: 1, Column: 100})
+	assert.Equal(t, CursorLocation{Line: 1, Column: 7}, b.Cursor())
+	b.MoveTo(CursorLocation{Line: 5, Column: 1})
+	assert.Equal(t, CursorLocation{Line: 1, Column: 1}, b.Cursor())
+	b.MoveTo(CursorLocation{Line: -1, Column: -1})
+	assert.Equal(t, CursorLocation{Line: 0, Column: 0}, b.Cursor())
+}
+
 func TestBuffer_MoveToBeginning(t *testing.T) {
 	b := getNewBuffer(t)
 
@@ -756,6 +910,26 @@ func TestBuffer_NumLines(t *testing.T) {
 	assert.Equal(t, 2, b.NumLines())
 }
 
+func TestBuffer_OpenLineAbove(t *testing.T) {
+	b := getNewBuffer(t)
+
+	b.Set("foo\n  bar")
+	b.MoveTo(CursorLocation{Line: 1, Column: 3})
+	b.OpenLineAbove("  ")
+	assert.Equal(t, "foo\n  \n  bar", b.String())
+	assert.Equal(t, CursorLocation{Line: 1, Column: 2}, b.Cursor())
+}
+
+func TestBuffer_OpenLineBelow(t *testing.T) {
+	b := getNewBuffer(t)
+
+	b.Set("foo\nbar")
+	b.MoveTo(CursorLocation{Line: 0, Column: 1})
+	b.OpenLineBelow("")
+	assert.Equal(t, "foo\n\nbar", b.String())
+	assert.Equal(t, CursorLocation{Line: 1, Column: 0}, b.Cursor())
+}
+
 func TestBuffer_Reset(t *testing.T) {
 	b := getNewBuffer(t)
 	b.InsertString("foo")
@@ -766,6 +940,61 @@ func TestBuffer_Reset(t *testing.T) {
 	assert.Equal(t, CursorLocation{Line: 0, Column: 0}, b.cursor)
 }
 
+func TestBuffer_RunesAroundCursor(t *testing.T) {
+	b := getNewBuffer(t)
+
+	prev, next := b.RunesAroundCursor()
+	assert.Equal(t, rune(0), prev)
+	assert.Equal(t, rune(0), next)
+
+	b.InsertString("fö(o")
+	prev, next = b.RunesAroundCursor()
+	assert.Equal(t, 'o', prev)
+	assert.Equal(t, rune(0), next)
+	b.MoveTo(CursorLocation{Line: 0, Column: 3})
+	prev, next = b.RunesAroundCursor()
+	assert.Equal(t, 'ö', prev)
+	assert.Equal(t, '(', next)
+}
+
+func TestBuffer_Selection(t *testing.T) {
+	b := getNewBuffer(t)
+	b.Set("foo bar\nbaz")
+	_, _, ok := b.Selection()
+	assert.False(t, ok)
+	assert.Equal(t, "", b.SelectionText())
+
+	// an empty selection is no selection
+	b.StartSelection()
+	_, _, ok = b.Selection()
+	assert.False(t, ok)
+
+	// selecting backwards returns the locations in order
+	b.MoveWordLeft()
+	b.MoveWordLeft()
+	start, end, ok := b.Selection()
+	assert.True(t, ok)
+	assert.Equal(t, CursorLocation{Line: 0, Column: 4}, start)
+	assert.Equal(t, CursorLocation{Line: 1, Column: 3}, end)
+	assert.Equal(t, "bar\nbaz", b.SelectionText())
+
+	// the selection grows/shrinks with the cursor from where it started
+	b.StartSelection()
+	b.MoveToEnd()
+	b.MoveLeft(1)
+	start, end, ok = b.Selection()
+	assert.True(t, ok)
+	assert.Equal(t, CursorLocation{Line: 1, Column: 2}, start)
+	assert.Equal(t, CursorLocation{Line: 1, Column: 3}, end)
+	assert.Equal(t, "z", b.SelectionText())
+
+	// setting the text clears the selection
+	b.Set("foo")
+	_, _, ok = b.Selection()
+	assert.False(t, ok)
+	assert.Nil(t, b.selection)
+}
+
 func TestBuffer_Set(t *testing.T) {
 	b := getNewBuffer(t)
 	b.tab = "    "
@@ -789,6 +1018,93 @@ func TestBuffer_String(t *testing.T) {
 	assert.Equal(t, "abc\ndef", b.String())
 }
 
+func TestBuffer_SurroundSelection(t *testing.T) {
+	b := getNewBuffer(t)
+	b.Set("foo bar\nbaz")
+	assert.False(t, b.SurroundSelection('(', ')'))
+
+	b.StartSelection()
+	b.MoveWordLeft()
+	assert.True(t, b.SurroundSelection('(', ')'))
+	assert.Equal(t, "foo bar\n(baz)", b.String())
+	assert.Equal(t, "baz", b.SelectionText())
+	assert.Equal(t, CursorLocation{Line: 1, Column: 1}, b.cursor)
+
+	b.ClearSelection()
+	b.cursor = CursorLocation{Line: 0, Column: 4}
+	b.StartSelection()
+	b.MoveDown(1)
+	b.MoveToEndOfLine()
+	assert.True(t, b.SurroundSelection('"', '"'))
+	assert.Equal(t, "foo \"bar\n(baz)\"", b.String())
+	assert.Equal(t, "bar\n(baz)", b.SelectionText())
+	assert.Equal(t, CursorLocation{Line: 1, Column: 5}, b.cursor)
+}
+
+func TestBuffer_ToggleLineComment(t *testing.T) {
+	b := getNewBuffer(t)
+
+	b.Set("  foo\n\n    bar\nbaz")
+	b.MoveTo(CursorLocation{Line: 0, Column: 3})
+	b.ToggleLineComment("-- ")
+	assert.Equal(t, "  -- foo\n\n    bar\nbaz", b.String())
+	assert.Equal(t, CursorLocation{Line: 0, Column: 6}, b.Cursor())
+	b.ToggleLineComment("-- ")
+	assert.Equal(t, "  foo\n\n    bar\nbaz", b.String())
+	assert.Equal(t, CursorLocation{Line: 0, Column: 3}, b.Cursor())
+
+	// selected lines get the marker at the common indentation
+	b.StartSelection()
+	b.MoveTo(CursorLocation{Line: 2, Column: 5})
+	b.ToggleLineComment("-- ")
+	assert.Equal(t, "  -- foo\n\n  --   bar\nbaz", b.String())
+	assert.Equal(t, "oo\n\n  --   b", b.SelectionText())
+	b.ToggleLineComment("-- ")
+	assert.Equal(t, "  foo\n\n    bar\nbaz", b.String())
+
+	// partially commented lines get commented out again
+	b.Set("--foo\nbar")
+	b.MoveTo(CursorLocation{Line: 0, Column: 0})
+	b.StartSelection()
+	b.MoveTo(CursorLocation{Line: 1, Column: 1})
+	b.ToggleLineComment("-- ")
+	assert.Equal(t, "-- --foo\n-- bar", b.String())
+	b.ToggleLineComment("-- ")
+	assert.Equal(t, "--foo\nbar", b.String())
+	b.ClearSelection()
+	b.MoveTo(CursorLocation{Line: 0, Column: 0})
+	b.ToggleLineComment("-- ")
+	assert.Equal(t, "foo\nbar", b.String())
+
+	// nothing to do
+	b.ToggleLineComment(" ")
+	assert.Equal(t, "foo\nbar", b.String())
+}
+
+func TestBuffer_TransformSelection(t *testing.T) {
+	b := getNewBuffer(t)
+	b.Set("foo bar\nbaz")
+	assert.False(t, b.TransformSelection(strings.ToUpper))
+
+	b.cursor = CursorLocation{Line: 0, Column: 4}
+	b.StartSelection()
+	b.MoveToEnd()
+	assert.True(t, b.TransformSelection(strings.ToUpper))
+	assert.Equal(t, "foo BAR\nBAZ", b.String())
+	assert.Equal(t, "BAR\nBAZ", b.SelectionText())
+	assert.Equal(t, CursorLocation{Line: 1, Column: 3}, b.cursor)
+
+	b.ClearSelection()
+	b.StartSelection()
+	b.MoveToBeginning()
+	assert.True(t, b.TransformSelection(func(s string) string {
+		return "x"
+	}))
+	assert.Equal(t, "x", b.String())
+	assert.Equal(t, "x", b.SelectionText())
+	assert.Equal(t, CursorLocation{Line: 0, Column: 0}, b.cursor)
+}
+
 func TestBuffer_getWordAtCursor(t *testing.T) {
 	b := getNewBuffer(t)
 	b.InsertString("foo bar baz foo")
@@ -842,3 +1158,9 @@ func Test_linesChangedMap(t *testing.T) {
 
 	assert.Equal(t, "[-1 1]", lcm.String())
 }
+
+func Test_makeCapitalCase(t *testing.T) {
+	assert.Equal(t, "", makeCapitalCase(""))
+	assert.Equal(t, "Foo", makeCapitalCase("foo"))
+	assert.Equal(t, "Foo(Bar, BAZ)\n  Qux", makeCapitalCase("foo(bar, BAZ)\n  qux"))
+}
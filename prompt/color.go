@@ -1,10 +1,13 @@
 package prompt
 
 import (
+	"encoding/json"
 	"fmt"
+	"strconv"
 	"strings"
 	"sync"
 
+	"github.com/jedib0t/go-pretty/v6/text"
 	"github.com/muesli/termenv"
 )
 
@@ -13,6 +16,11 @@ const (
 	escSeqSep   = ';'
 	escSeqStop  = 'm'
 	escSeqReset = string(escSeqStart) + "[0" + string(escSeqStop)
+
+	escSeqReverse      = string(escSeqStart) + "[7" + string(escSeqStop)
+	escSeqReverseOff   = string(escSeqStart) + "[27" + string(escSeqStop)
+	escSeqUnderline    = string(escSeqStart) + "[4" + string(escSeqStop)
+	escSeqUnderlineOff = string(escSeqStart) + "[24" + string(escSeqStop)
 )
 
 // Color contain the foreground and background colors to use to format text.
@@ -26,6 +34,27 @@ type Color struct {
 var (
 	escSeqCache      = make(map[string]string)
 	escSeqCacheMutex = sync.RWMutex{}
+
+	// colorNames maps the human-readable names of the basic 16 ANSI colors to
+	// the termenv equivalents; used when (un)marshalling colors.
+	colorNames = map[string]termenv.ANSIColor{
+		"black":          termenv.ANSIBlack,
+		"red":            termenv.ANSIRed,
+		"green":          termenv.ANSIGreen,
+		"yellow":         termenv.ANSIYellow,
+		"blue":           termenv.ANSIBlue,
+		"magenta":        termenv.ANSIMagenta,
+		"cyan":           termenv.ANSICyan,
+		"white":          termenv.ANSIWhite,
+		"bright-black":   termenv.ANSIBrightBlack,
+		"bright-red":     termenv.ANSIBrightRed,
+		"bright-green":   termenv.ANSIBrightGreen,
+		"bright-yellow":  termenv.ANSIBrightYellow,
+		"bright-blue":    termenv.ANSIBrightBlue,
+		"bright-magenta": termenv.ANSIBrightMagenta,
+		"bright-cyan":    termenv.ANSIBrightCyan,
+		"bright-white":   termenv.ANSIBrightWhite,
+	}
 )
 
 // Invert flips the background and foreground colors and returns a new Color
@@ -37,7 +66,62 @@ func (c Color) Invert() Color {
 	}
 }
 
+// MarshalJSON marshals the Color into a JSON object with the "foreground" and
+// "background" colors represented as one of:
+//   - "#rrggbb" for true colors
+//   - "0" to "255" for ANSI 256 colors
+//   - a name like "red" or "bright-red" for the basic 16 ANSI colors
+//   - "default" for the terminal's default color (termenv.NoColor)
+//
+// Colors that are not set are omitted.
+func (c Color) MarshalJSON() ([]byte, error) {
+	obj := make(map[string]string)
+	if fg := colorToString(c.Foreground); fg != "" {
+		obj["foreground"] = fg
+	}
+	if bg := colorToString(c.Background); bg != "" {
+		obj["background"] = bg
+	}
+	return json.Marshal(obj)
+}
+
+// UnmarshalJSON unmarshals a JSON object in the format generated by
+// MarshalJSON into the Color. Values for "foreground" and "background" can be
+// strings or ANSI 256 indices as numbers. Colors not present in the input are
+// left untouched, while "" unsets them and "default" sets them to the
+// terminal's default color.
+func (c *Color) UnmarshalJSON(data []byte) error {
+	var obj map[string]any
+	if err := json.Unmarshal(data, &obj); err != nil {
+		return fmt.Errorf("%w: %v", ErrInvalidColor, err)
+	}
+
+	for k, v := range obj {
+		var target *termenv.Color
+		switch k {
+		case "foreground":
+			target = &c.Foreground
+		case "background":
+			target = &c.Background
+		default:
+			return fmt.Errorf("%w: unknown field %#v (allowed: background, foreground)", ErrInvalidColor, k)
+		}
+		if v == nil {
+			continue
+		}
+
+		color, err := parseColor(v)
+		if err != nil {
+			return fmt.Errorf("%w: %s: %v", ErrInvalidColor, k, err)
+		}
+		*target = color
+	}
+	return nil
+}
+
 // Sprint behaves like fmt.Sprint but with color sequence wrapping the output.
+// The prompt downsamples the colors to the ones supported by the color profile
+// of its output while rendering.
 func (c Color) Sprint(a ...any) string {
 	if c.Foreground == nil {
 		c.Foreground = termenv.ForegroundColor()
@@ -45,7 +129,9 @@ func (c Color) Sprint(a ...any) string {
 	if c.Background == nil {
 		c.Background = termenv.BackgroundColor()
 	}
-	cacheKey := fmt.Sprintf("%s%s", c.Foreground, c.Background)
+	// the type is a part of the key as ANSI and ANSI 256 colors with the same
+	// index share the same string representation
+	cacheKey := fmt.Sprintf("%T%s%T%s", c.Foreground, c.Foreground, c.Background, c.Background)
 
 	// find value in cache
 	escSeqCacheMutex.RLock()
@@ -54,17 +140,15 @@ func (c Color) Sprint(a ...any) string {
 
 	// if none found, generate and store in cache
 	if !ok {
-		sb := strings.Builder{}
-		sb.WriteRune(escSeqStart)
-		sb.WriteRune('[')
-		sb.WriteString(c.Foreground.Sequence(false))
-		sb.WriteRune(escSeqSep)
-		sb.WriteString(c.Background.Sequence(true))
-		sb.WriteRune(escSeqStop)
-		if sb.String() == "\x1b[;m" {
-			cacheVal = ""
-		} else {
-			cacheVal = sb.String()
+		var sequences []string
+		if seq := c.Foreground.Sequence(false); seq != "" {
+			sequences = append(sequences, seq)
+		}
+		if seq := c.Background.Sequence(true); seq != "" {
+			sequences = append(sequences, seq)
+		}
+		if len(sequences) > 0 {
+			cacheVal = fmt.Sprintf("%c[%s%c", escSeqStart, strings.Join(sequences, string(escSeqSep)), escSeqStop)
 		}
 
 		escSeqCacheMutex.Lock()
@@ -91,4 +175,188 @@ func (c Color) Sprintf(msg string, a ...any) string {
 	return c.Sprint(fmt.Sprintf(msg, a...))
 }
 
+func colorToString(color termenv.Color) string {
+	switch obj := color.(type) {
+	case termenv.ANSIColor:
+		for name, ansiColor := range colorNames {
+			if ansiColor == obj {
+				return name
+			}
+		}
+		return fmt.Sprint(int(obj))
+	case termenv.ANSI256Color:
+		return fmt.Sprint(int(obj))
+	case termenv.RGBColor:
+		return strings.ToLower(string(obj))
+	case termenv.NoColor:
+		return "default"
+	}
+	return ""
+}
+
+// ConvertColors downsamples the colors in the escape sequences in the given
+// text to the ones supported by the given color profile, the same way the
+// prompt does for everything it renders. This is useful when text generated
+// using Color is written to the output outside the prompt.
+func ConvertColors(str string, profile termenv.Profile) string {
+	return convertEscSeqs(str, profile)
+}
+
+// convertEscSeqs downsamples the colors in the SGR escape sequences in the
+// given text (like the ones from a SyntaxHighlighter) to the ones supported by
+// the given color profile. If it supports no colors, all the escape sequences
+// are stripped except for the reverse video used to render the cursor.
+func convertEscSeqs(str string, profile termenv.Profile) string {
+	if profile == termenv.TrueColor || !strings.ContainsRune(str, escSeqStart) {
+		return str
+	}
+	isAscii, isReversed := profile == termenv.Ascii, false
+	writeText := func(out *strings.Builder, str string) {
+		if isAscii {
+			str = text.StripEscape(str)
+		}
+		out.WriteString(str)
+	}
+
+	out := strings.Builder{}
+	csi := string(escSeqStart) + "["
+	for {
+		start := strings.Index(str, csi)
+		if start < 0 {
+			break
+		}
+		writeText(&out, str[:start])
+		str = str[start+len(csi):]
+
+		// find the end of the parameters of the control sequence
+		end := 0
+		for end < len(str) && str[end] >= 0x30 && str[end] <= 0x3f {
+			end++
+		}
+		if end < len(str) && str[end] == escSeqStop {
+			if !isAscii {
+				out.WriteString(csi + convertSGRParams(str[:end], profile) + string(escSeqStop))
+			} else if params := reverseSGRParams(str[:end], &isReversed); params != "" {
+				out.WriteString(csi + params + string(escSeqStop))
+			}
+			str = str[end+1:]
+		} else if isAscii {
+			// skip the intermediate and the final bytes of the control sequence
+			for end < len(str) && str[end] >= 0x20 && str[end] <= 0x2f {
+				end++
+			}
+			if end < len(str) {
+				end++
+			}
+			str = str[end:]
+		} else {
+			out.WriteString(csi)
+		}
+	}
+	writeText(&out, str)
+	return out.String()
+}
+
+// convertSGRParams converts the 256 and true color parameters in the given SGR
+// parameters to the ones supported by the given color profile.
+func convertSGRParams(params string, profile termenv.Profile) string {
+	parts := strings.Split(params, string(escSeqSep))
+	rsp := make([]string, 0, len(parts))
+	for idx := 0; idx < len(parts); idx++ {
+		part := parts[idx]
+		if (part == "38" || part == "48") && idx+1 < len(parts) {
+			color, numParams := parseSGRColor(parts[idx+1:])
+			if color != nil {
+				if seq := profile.Convert(color).Sequence(part == "48"); seq != "" {
+					rsp = append(rsp, seq)
+				}
+				idx += numParams
+				continue
+			}
+		}
+		rsp = append(rsp, part)
+	}
+	return strings.Join(rsp, string(escSeqSep))
+}
+
+func parseColor(v any) (termenv.Color, error) {
+	switch obj := v.(type) {
+	case float64:
+		if obj != float64(int(obj)) || obj < 0 || obj > 255 {
+			return nil, fmt.Errorf("ANSI color index %v not in range [0, 255]", obj)
+		}
+		return termenv.ANSI256Color(int(obj)), nil
+	case string:
+		str := strings.ToLower(strings.TrimSpace(obj))
+		if str == "" {
+			return nil, nil
+		}
+		if str == "default" {
+			return termenv.NoColor{}, nil
+		}
+		if ansiColor, ok := colorNames[str]; ok {
+			return ansiColor, nil
+		}
+		if strings.HasPrefix(str, "#") {
+			if _, err := strconv.ParseUint(str[1:], 16, 32); err != nil || len(str) != 7 {
+				return nil, fmt.Errorf("%#v is not in the format #rrggbb", obj)
+			}
+			return termenv.RGBColor(str), nil
+		}
+		if idx, err := strconv.Atoi(str); err == nil {
+			return parseColor(float64(idx))
+		}
+		return nil, fmt.Errorf("%#v is not a known color name, ANSI color index or #rrggbb", obj)
+	}
+	return nil, fmt.Errorf("%#v is not a string or a number", v)
+}
+
+// parseSGRColor parses the parameters following a 38 (foreground) or a 48
+// (background) in a SGR escape sequence, and returns the color along with the
+// number of parameters it spans.
+func parseSGRColor(params []string) (termenv.Color, int) {
+	var values []int
+	for _, param := range params {
+		value, err := strconv.Atoi(param)
+		if err != nil || value < 0 || value > 255 {
+			break
+		}
+		values = append(values, value)
+	}
+
+	switch {
+	case len(values) >= 2 && values[0] == 5:
+		return termenv.ANSI256Color(values[1]), 2
+	case len(values) >= 4 && values[0] == 2:
+		return termenv.RGBColor(fmt.Sprintf("#%02x%02x%02x", values[1], values[2], values[3])), 4
+	}
+	return nil, 0
+}
+
+// reverseSGRParams drops all the parameters in the given SGR parameters except
+// the ones turning the reverse video on and off. A reset turns the reverse
+// video off if it is on (as tracked using isReversed), and is dropped if not.
+func reverseSGRParams(params string, isReversed *bool) string {
+	parts := strings.Split(params, string(escSeqSep))
+	var rsp []string
+	for idx := 0; idx < len(parts); idx++ {
+		switch part := parts[idx]; part {
+		case "38", "48":
+			_, numParams := parseSGRColor(parts[idx+1:])
+			idx += numParams
+		case "7":
+			if !*isReversed {
+				rsp = append(rsp, part)
+				*isReversed = true
+			}
+		case "", "0", "27":
+			if *isReversed {
+				rsp = append(rsp, "27")
+				*isReversed = false
+			}
+		}
+	}
+	return strings.Join(rsp, string(escSeqSep))
+}
+
 // Ref.: https://talyian.github.io/ansicolors/
@@ -1,6 +1,8 @@
 package prompt
 
 import (
+	"encoding/json"
+	"errors"
 	"testing"
 
 	"github.com/muesli/termenv"
@@ -29,6 +31,12 @@ func TestColor_Sprint(t *testing.T) {
 		Background: termenv.ANSI256Color(56),
 	}
 	assert.Equal(t, "\x1b[38;5;194;48;5;56mfoo\x1b[0m", c.Sprint("foo"))
+
+	c = Color{Foreground: termenv.ANSI256Color(194)}
+	assert.Equal(t, "\x1b[38;5;194mfoo\x1b[0m", c.Sprint("foo"))
+
+	c = Color{Background: termenv.ANSI256Color(56)}
+	assert.Equal(t, "\x1b[48;5;56mfoo\x1b[0m", c.Sprint("foo"))
 }
 
 func TestColor_Sprintf(t *testing.T) {
@@ -38,3 +46,95 @@ func TestColor_Sprintf(t *testing.T) {
 	}
 	assert.Equal(t, "\x1b[38;5;194;48;5;56mfoo   bar\x1b[0m", c.Sprintf("foo %5s", "bar"))
 }
+
+func TestColor_MarshalJSON(t *testing.T) {
+	c := Color{}
+	data, err := json.Marshal(c)
+	assert.Nil(t, err)
+	assert.Equal(t, `{}`, string(data))
+
+	c = Color{
+		Foreground: termenv.RGBColor("#FF8700"),
+		Background: termenv.ANSI256Color(56),
+	}
+	data, err = json.Marshal(c)
+	assert.Nil(t, err)
+	assert.Equal(t, `{"background":"56","foreground":"#ff8700"}`, string(data))
+
+	c = Color{
+		Foreground: termenv.ANSIBrightRed,
+		Background: termenv.ANSIBlack,
+	}
+	data, err = json.Marshal(c)
+	assert.Nil(t, err)
+	assert.Equal(t, `{"background":"black","foreground":"bright-red"}`, string(data))
+
+	c = Color{
+		Foreground: termenv.ANSIRed,
+		Background: termenv.NoColor{},
+	}
+	data, err = json.Marshal(c)
+	assert.Nil(t, err)
+	assert.Equal(t, `{"background":"default","foreground":"red"}`, string(data))
+}
+
+func TestColor_UnmarshalJSON(t *testing.T) {
+	c := Color{}
+	err := json.Unmarshal([]byte(`{"foreground": "#FF8700", "background": 56}`), &c)
+	assert.Nil(t, err)
+	assert.Equal(t, termenv.RGBColor("#ff8700"), c.Foreground)
+	assert.Equal(t, termenv.ANSI256Color(56), c.Background)
+
+	err = json.Unmarshal([]byte(`{"foreground": "Red"}`), &c)
+	assert.Nil(t, err)
+	assert.Equal(t, termenv.ANSIRed, c.Foreground)
+	assert.Equal(t, termenv.ANSI256Color(56), c.Background)
+
+	err = json.Unmarshal([]byte(`{"background": "default"}`), &c)
+	assert.Nil(t, err)
+	assert.Equal(t, termenv.ANSIRed, c.Foreground)
+	assert.Equal(t, termenv.NoColor{}, c.Background)
+
+	err = json.Unmarshal([]byte(`{"background": ""}`), &c)
+	assert.Nil(t, err)
+	assert.Equal(t, termenv.ANSIRed, c.Foreground)
+	assert.Nil(t, c.Background)
+
+	for _, in := range []string{
+		`{"foreground": "#ff87"}`,
+		`{"foreground": "#gg8700"}`,
+		`{"foreground": 256}`,
+		`{"foreground": "reddish"}`,
+		`{"foreground": true}`,
+		`{"fg": "red"}`,
+		`"red"`,
+	} {
+		err = json.Unmarshal([]byte(in), &c)
+		assert.NotNil(t, err, in)
+		assert.True(t, errors.Is(err, ErrInvalidColor), in)
+	}
+}
+
+func TestConvertColors(t *testing.T) {
+	input := "\x1b[38;5;208mfoo\x1b[0m"
+
+	assert.Equal(t, input, ConvertColors(input, termenv.ANSI256))
+	assert.Equal(t, "\x1b[91mfoo\x1b[0m", ConvertColors(input, termenv.ANSI))
+	assert.Equal(t, "foo", ConvertColors(input, termenv.Ascii))
+}
+
+func Test_convertEscSeqs(t *testing.T) {
+	input := "\x1b[1;38;2;255;135;0mfoo\x1b[0m \x1b[48;5;56mbar\x1b[0m\x1b[2K"
+
+	assert.Equal(t, input, convertEscSeqs(input, termenv.TrueColor))
+	assert.Equal(t, "\x1b[1;38;5;208mfoo\x1b[0m \x1b[48;5;56mbar\x1b[0m\x1b[2K", convertEscSeqs(input, termenv.ANSI256))
+	assert.Equal(t, "\x1b[1;91mfoo\x1b[0m \x1b[104mbar\x1b[0m\x1b[2K", convertEscSeqs(input, termenv.ANSI))
+	assert.Equal(t, "foo bar", convertEscSeqs(input, termenv.Ascii))
+	assert.Equal(t, "\x1b[38;5;foomfoo", convertEscSeqs("\x1b[38;5;foomfoo", termenv.ANSI))
+
+	// retains just the reverse video (of the cursor) if there are no colors
+	input = "\x1b[38;5;7mse\x1b[0m\x1b[7ml\x1b[27m\x1b[38;5;7;4mect\x1b[0m"
+	assert.Equal(t, "se\x1b[7ml\x1b[27mect", convertEscSeqs(input, termenv.Ascii))
+	input = "\x1b[7mfoo\x1b[0m bar\x1b[m"
+	assert.Equal(t, "\x1b[7mfoo\x1b[27m bar", convertEscSeqs(input, termenv.Ascii))
+}
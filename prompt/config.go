@@ -0,0 +1,268 @@
+package prompt
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"gopkg.in/yaml.v3"
+)
+
+// ConfigFormat defines the format of the contents of a Config file.
+type ConfigFormat string
+
+// Supported ConfigFormat(s).
+const (
+	ConfigFormatJSON ConfigFormat = "json"
+	ConfigFormatYAML ConfigFormat = "yaml"
+)
+
+// Config contains the KeyMap and the Style for a Prompter, and can be loaded
+// from (or saved to) JSON or YAML files so that users can customize the key
+// bindings and the theme without code changes. An example in YAML:
+//
+//	key_map:
+//	  insert:
+//	    delete_word_previous: ["ctrl+w", "alt+h"]
+//	style:
+//	  cursor:
+//	    blink_interval: 250ms
+//	    color: { foreground: "#1c1c1c", background: cyan }
+//	  line_numbers:
+//	    enabled: true
+//	    color: { foreground: 239, background: 235 }
+//
+// Fields not present in the file retain the values they had before the file
+// was loaded (i.e., the file gets merged over the defaults).
+type Config struct {
+	KeyMap KeyMap `json:"key_map"`
+	Style  Style  `json:"style"`
+}
+
+// ConfigDefault returns a Config with KeyMapDefault and StyleDefault.
+func ConfigDefault() Config {
+	return Config{
+		KeyMap: KeyMapDefault,
+		Style:  StyleDefault,
+	}
+}
+
+// Apply sets up the KeyMap and the Style on the given Prompter.
+func (c Config) Apply(p Prompter) error {
+	if err := p.SetKeyMap(c.KeyMap); err != nil {
+		return err
+	}
+	p.SetStyle(c.Style)
+	return nil
+}
+
+// Load reads the file at the given path and merges the contents over the
+// current values in the Config. The format of the file is determined by the
+// extension (".json", ".yaml" or ".yml").
+func (c *Config) Load(path string) error {
+	format, err := configFormatFromPath(path)
+	if err != nil {
+		return err
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return err
+	}
+	return c.Unmarshal(data, format)
+}
+
+// Marshal returns the Config in the given format.
+func (c Config) Marshal(format ConfigFormat) ([]byte, error) {
+	data, err := json.MarshalIndent(c, "", "  ")
+	if err != nil {
+		return nil, err
+	}
+
+	switch format {
+	case ConfigFormatJSON:
+		return data, nil
+	case ConfigFormatYAML:
+		var obj any
+		if err := yaml.Unmarshal(data, &obj); err != nil {
+			return nil, err
+		}
+		return yaml.Marshal(obj)
+	default:
+		return nil, fmt.Errorf("%w: format %#v (allowed: json, yaml)", ErrInvalidConfig, format)
+	}
+}
+
+// Save writes the Config to the file at the given path. The format of the file
+// is determined by the extension (".json", ".yaml" or ".yml").
+func (c Config) Save(path string) error {
+	format, err := configFormatFromPath(path)
+	if err != nil {
+		return err
+	}
+
+	data, err := c.Marshal(format)
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(path, data, 0644)
+}
+
+// Unmarshal parses the given data in the given format and merges the contents
+// over the current values in the Config. Errors point to the offending field,
+// for example: "invalid config: style.cursor.color: invalid color: ...".
+func (c *Config) Unmarshal(data []byte, format ConfigFormat) error {
+	var obj any
+	switch format {
+	case ConfigFormatJSON:
+		if err := json.Unmarshal(data, &obj); err != nil {
+			return fmt.Errorf("%w: %v", ErrInvalidConfig, err)
+		}
+	case ConfigFormatYAML:
+		if err := yaml.Unmarshal(data, &obj); err != nil {
+			return fmt.Errorf("%w: %v", ErrInvalidConfig, err)
+		}
+		obj = normalizeYAML(obj)
+	default:
+		return fmt.Errorf("%w: format %#v (allowed: json, yaml)", ErrInvalidConfig, format)
+	}
+	if obj == nil { // empty file
+		return nil
+	}
+
+	cfg := *c
+	if err := mergeConfigValue(reflect.ValueOf(&cfg).Elem(), obj, ""); err != nil {
+		return err
+	}
+	keyMap := cfg.KeyMap // reverse() leaves behind state in the object
+	if _, err := keyMap.reverse(); err != nil {
+		return fmt.Errorf("%w: key_map: %v", ErrInvalidConfig, err)
+	}
+	if err := cfg.Style.Validate(); err != nil {
+		return fmt.Errorf("%w: style: %v", ErrInvalidConfig, err)
+	}
+	*c = cfg
+	return nil
+}
+
+var (
+	typeColor        = reflect.TypeOf(Color{})
+	typeDuration     = reflect.TypeOf(time.Duration(0))
+	typeKeySequences = reflect.TypeOf(KeySequences{})
+)
+
+func configFormatFromPath(path string) (ConfigFormat, error) {
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".json":
+		return ConfigFormatJSON, nil
+	case ".yaml", ".yml":
+		return ConfigFormatYAML, nil
+	}
+	return "", fmt.Errorf("%w: unknown format for file %#v (allowed: .json, .yaml, .yml)",
+		ErrInvalidConfig, path)
+}
+
+// mergeConfigValue merges the generic (JSON-like) value src into dst while
+// keeping track of the path to the field to report meaningful errors. Struct
+// fields are merged one by one so that values not present in src are retained,
+// while everything else is overwritten.
+//
+//gocyclo:ignore
+func mergeConfigValue(dst reflect.Value, src any, path string) error {
+	// structs without custom unmarshalling logic get merged field by field
+	if dst.Kind() == reflect.Struct && dst.Type() != typeColor {
+		obj, ok := src.(map[string]any)
+		if !ok {
+			return fmt.Errorf("%w: %s: expected an object, got %#v", ErrInvalidConfig, path, src)
+		}
+		fields := make(map[string]int)
+		for idx := 0; idx < dst.NumField(); idx++ {
+			if tag := strings.Split(dst.Type().Field(idx).Tag.Get("json"), ",")[0]; tag != "" && tag != "-" {
+				fields[tag] = idx
+			}
+		}
+		for k, v := range obj {
+			fieldPath := k
+			if path != "" {
+				fieldPath = path + "." + k
+			}
+			fieldIdx, ok := fields[k]
+			if !ok {
+				return fmt.Errorf("%w: %s: unknown field", ErrInvalidConfig, fieldPath)
+			}
+			if err := mergeConfigValue(dst.Field(fieldIdx), v, fieldPath); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+
+	// friendlier forms for durations ("500ms") and runes ("█")
+	if str, ok := src.(string); ok {
+		if dst.Type() == typeDuration {
+			d, err := time.ParseDuration(str)
+			if err != nil {
+				return fmt.Errorf("%w: %s: %v", ErrInvalidConfig, path, err)
+			}
+			dst.SetInt(int64(d))
+			return nil
+		}
+		if dst.Kind() == reflect.Int32 && utf8.RuneCountInString(str) == 1 {
+			r, _ := utf8.DecodeRuneInString(str)
+			dst.SetInt(int64(r))
+			return nil
+		}
+	}
+
+	// everything else is overwritten; slices and maps are reset first to not
+	// modify the defaults they may be sharing memory with
+	if dst.Kind() == reflect.Slice || dst.Kind() == reflect.Map {
+		dst.Set(reflect.Zero(dst.Type()))
+	}
+	data, err := json.Marshal(src)
+	if err != nil {
+		return fmt.Errorf("%w: %s: %v", ErrInvalidConfig, path, err)
+	}
+	if err := json.Unmarshal(data, dst.Addr().Interface()); err != nil {
+		return fmt.Errorf("%w: %s: %v", ErrInvalidConfig, path, err)
+	}
+
+	// make sure the key sequences are known ones
+	if dst.Type() == typeKeySequences {
+		for idx, ks := range dst.Interface().(KeySequences) {
+			if _, ok := keySequenceKeyMsgMap[ks]; !ok {
+				return fmt.Errorf("%w: %s[%d]: unknown key sequence %#v", ErrInvalidConfig, path, idx, ks)
+			}
+		}
+	}
+	return nil
+}
+
+// normalizeYAML converts all the maps in the YAML decoded value to have string
+// keys so that it is compatible with the JSON decoded values.
+func normalizeYAML(v any) any {
+	switch obj := v.(type) {
+	case map[string]any:
+		for k, val := range obj {
+			obj[k] = normalizeYAML(val)
+		}
+		return obj
+	case map[any]any:
+		rsp := make(map[string]any, len(obj))
+		for k, val := range obj {
+			rsp[fmt.Sprint(k)] = normalizeYAML(val)
+		}
+		return rsp
+	case []any:
+		for idx, val := range obj {
+			obj[idx] = normalizeYAML(val)
+		}
+		return obj
+	}
+	return v
+}
@@ -0,0 +1,188 @@
+package prompt
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/muesli/termenv"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestConfigDefault(t *testing.T) {
+	c := ConfigDefault()
+	assert.Equal(t, KeyMapDefault, c.KeyMap)
+	assert.Equal(t, StyleDefault, c.Style)
+}
+
+func TestConfig_Apply(t *testing.T) {
+	p, err := New()
+	assert.Nil(t, err)
+
+	c := ConfigDefault()
+	c.KeyMap = KeyMapMultiLine
+	c.Style.TabString = "  "
+	err = c.Apply(p)
+	assert.Nil(t, err)
+	assert.Equal(t, KeyMapMultiLine.Insert, p.KeyMap().Insert)
+	assert.Equal(t, "  ", p.Style().TabString)
+
+	c.KeyMap.Insert.MoveToEndOfLine = c.KeyMap.Insert.Abort
+	err = c.Apply(p)
+	assert.NotNil(t, err)
+	assert.True(t, errors.Is(err, ErrDuplicateKeyAssignment))
+}
+
+func TestConfig_Load(t *testing.T) {
+	dir := t.TempDir()
+
+	t.Run("json", func(t *testing.T) {
+		path := filepath.Join(dir, "prompt.json")
+		err := os.WriteFile(path, []byte(`{
+			"key_map": {"insert": {"delete_word_previous": ["ctrl+w", "alt+backspace"]}},
+			"style": {"cursor": {"color": {"background": "#00ffff"}}}
+		}`), 0644)
+		assert.Nil(t, err)
+
+		c := ConfigDefault()
+		err = c.Load(path)
+		assert.NotNil(t, err)
+		assert.True(t, errors.Is(err, ErrInvalidConfig))
+		assert.Contains(t, err.Error(), `key_map.insert.delete_word_previous[1]: unknown key sequence "alt+backspace"`)
+		assert.Equal(t, ConfigDefault(), c)
+
+		err = os.WriteFile(path, []byte(`{
+			"key_map": {"insert": {"delete_word_previous": ["ctrl+w", "alt+h"]}},
+			"style": {"cursor": {"color": {"background": "#00ffff"}}}
+		}`), 0644)
+		assert.Nil(t, err)
+		err = c.Load(path)
+		assert.Nil(t, err)
+		assert.Equal(t, KeySequences{CtrlW, AltH}, c.KeyMap.Insert.DeleteWordPrevious)
+		assert.Equal(t, KeySequences{CtrlW}, KeyMapDefault.Insert.DeleteWordPrevious)
+		assert.Equal(t, StyleCursorDefault.Color.Foreground, c.Style.Cursor.Color.Foreground)
+		assert.Equal(t, termenv.RGBColor("#00ffff"), c.Style.Cursor.Color.Background)
+	})
+
+	t.Run("yaml", func(t *testing.T) {
+		path := filepath.Join(dir, "prompt.yaml")
+		err := os.WriteFile(path, []byte(`
+key_map:
+  insert:
+    abort: ["ctrl+c", "ctrl+d"]
+style:
+  auto_complete:
+    word_delimiters: {32: true}
+  cursor:
+    blink_interval: 250ms
+  line_numbers:
+    enabled: true
+    color: {foreground: 239, background: bright-black}
+  scrollbar:
+    indicator: "#"
+`), 0644)
+		assert.Nil(t, err)
+
+		c := ConfigDefault()
+		err = c.Load(path)
+		assert.Nil(t, err)
+		assert.Equal(t, KeySequences{CtrlC, CtrlD}, c.KeyMap.Insert.Abort)
+		assert.Equal(t, map[byte]bool{' ': true}, c.Style.AutoComplete.WordDelimiters)
+		assert.True(t, StyleDefault.AutoComplete.WordDelimiters['('])
+		assert.Equal(t, time.Millisecond*250, c.Style.Cursor.BlinkInterval)
+		assert.True(t, c.Style.LineNumbers.Enabled)
+		assert.Equal(t, termenv.ANSI256Color(239), c.Style.LineNumbers.Color.Foreground)
+		assert.Equal(t, termenv.ANSIBrightBlack, c.Style.LineNumbers.Color.Background)
+		assert.Equal(t, '#', c.Style.Scrollbar.Indicator)
+		assert.Equal(t, StyleScrollbarDefault.IndicatorEmpty, c.Style.Scrollbar.IndicatorEmpty)
+	})
+
+	t.Run("errors", func(t *testing.T) {
+		c := ConfigDefault()
+		err := c.Load(filepath.Join(dir, "prompt.toml"))
+		assert.NotNil(t, err)
+		assert.True(t, errors.Is(err, ErrInvalidConfig))
+
+		err = c.Load(filepath.Join(dir, "does-not-exist.yml"))
+		assert.NotNil(t, err)
+		assert.True(t, errors.Is(err, os.ErrNotExist))
+	})
+}
+
+func TestConfig_Marshal(t *testing.T) {
+	c := ConfigDefault()
+
+	for _, format := range []ConfigFormat{ConfigFormatJSON, ConfigFormatYAML} {
+		data, err := c.Marshal(format)
+		assert.Nil(t, err, format)
+
+		c2 := Config{}
+		err = c2.Unmarshal(data, format)
+		assert.Nil(t, err, format)
+		assert.Equal(t, c, c2, format)
+	}
+
+	_, err := c.Marshal("toml")
+	assert.NotNil(t, err)
+	assert.True(t, errors.Is(err, ErrInvalidConfig))
+}
+
+func TestConfig_Save(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "prompt.yml")
+
+	c := ConfigDefault()
+	c.Style.TabString = "\t"
+	err := c.Save(path)
+	assert.Nil(t, err)
+
+	c2 := Config{}
+	err = c2.Load(path)
+	assert.Nil(t, err)
+	assert.Equal(t, c, c2)
+
+	err = c.Save(filepath.Join(t.TempDir(), "prompt.txt"))
+	assert.NotNil(t, err)
+	assert.True(t, errors.Is(err, ErrInvalidConfig))
+}
+
+func TestConfig_Unmarshal(t *testing.T) {
+	for _, tc := range []struct {
+		in       string
+		errorMsg string
+	}{
+		{`{"foo": 1}`, "invalid config: foo: unknown field"},
+		{`{"style": []}`, "invalid config: style: expected an object"},
+		{`{"style": {"cursor": {"colour": {}}}}`, "invalid config: style.cursor.colour: unknown field"},
+		{`{"style": {"cursor": {"blink_interval": "5 minutes"}}}`, "invalid config: style.cursor.blink_interval: time: unknown unit"},
+		{`{"style": {"cursor": {"color": {"foreground": "puce"}}}}`, `invalid config: style.cursor.color: invalid color: foreground: "puce" is not a known color name`},
+		{`{"style": {"dimensions": {"height_min": -1}}}`, "invalid config: style.dimensions.height_min: json: cannot unmarshal number -1"},
+		{`{"style": {"dimensions": {"height_min": 5, "height_max": 4}}}`, "invalid config: style: invalid dimensions: height-min [5] cannot be greater than height-max [4]"},
+		{`{"key_map": {"insert": {"abort": ["enter"]}}}`, "invalid config: key_map: possible duplicate key assignment"},
+		{`{"key_map": {"insert": {"abort": "ctrl+c"}}}`, "invalid config: key_map.insert.abort: json: cannot unmarshal string"},
+		{`{`, "invalid config: unexpected end of JSON input"},
+	} {
+		c := ConfigDefault()
+		err := c.Unmarshal([]byte(tc.in), ConfigFormatJSON)
+		assert.NotNil(t, err, tc.in)
+		assert.True(t, errors.Is(err, ErrInvalidConfig), tc.in)
+		if err != nil {
+			assert.Contains(t, err.Error(), tc.errorMsg, tc.in)
+		}
+		assert.Equal(t, ConfigDefault(), c, tc.in)
+	}
+
+	c := ConfigDefault()
+	err := c.Unmarshal([]byte(""), ConfigFormatYAML)
+	assert.Nil(t, err)
+	assert.Equal(t, ConfigDefault(), c)
+
+	err = c.Unmarshal([]byte("style: ["), ConfigFormatYAML)
+	assert.NotNil(t, err)
+	assert.True(t, errors.Is(err, ErrInvalidConfig))
+
+	err = c.Unmarshal([]byte("{}"), "toml")
+	assert.NotNil(t, err)
+	assert.True(t, errors.Is(err, ErrInvalidConfig))
+}
@@ -1,6 +1,9 @@
 package prompt
 
-import "fmt"
+import (
+	"fmt"
+	"strings"
+)
 
 // CursorLocation contains the current cursor position in a 2d-wall-of-text; the
 // values are 0-indexed to keep it simple to manipulate the wall of text
@@ -13,3 +16,54 @@ type CursorLocation struct {
 func (cl CursorLocation) String() string {
 	return fmt.Sprintf("[%d, %d]", cl.Line+1, cl.Column+1)
 }
+
+// CursorShape is the shape of the terminal's cursor, set using the DECSCUSR
+// escape sequence.
+type CursorShape int
+
+// Supported cursor shapes.
+const (
+	CursorShapeDefault           CursorShape = iota // the terminal's default shape
+	CursorShapeBlinkingBlock                        // █ (blinking)
+	CursorShapeSteadyBlock                          // █
+	CursorShapeBlinkingUnderline                    // _ (blinking)
+	CursorShapeSteadyUnderline                      // _
+	CursorShapeBlinkingBar                          // | (blinking)
+	CursorShapeSteadyBar                            // |
+)
+
+var cursorShapeNames = []string{
+	"default",
+	"blinking-block",
+	"steady-block",
+	"blinking-underline",
+	"steady-underline",
+	"blinking-bar",
+	"steady-bar",
+}
+
+// MarshalText marshals the CursorShape into a human-readable name like
+// "blinking-bar".
+func (cs CursorShape) MarshalText() ([]byte, error) {
+	if cs < 0 || int(cs) >= len(cursorShapeNames) {
+		return nil, fmt.Errorf("%w: %d", ErrInvalidCursorShape, cs)
+	}
+	return []byte(cursorShapeNames[cs]), nil
+}
+
+// UnmarshalText unmarshals a name generated by MarshalText into the
+// CursorShape.
+func (cs *CursorShape) UnmarshalText(data []byte) error {
+	for idx, name := range cursorShapeNames {
+		if name == string(data) {
+			*cs = CursorShape(idx)
+			return nil
+		}
+	}
+	return fmt.Errorf("%w: %#v (allowed: %s)", ErrInvalidCursorShape, string(data), strings.Join(cursorShapeNames, ", "))
+}
+
+// sequence returns the DECSCUSR escape sequence to set the cursor shape.
+func (cs CursorShape) sequence() string {
+	return fmt.Sprintf("%c[%d q", escSeqStart, cs)
+}
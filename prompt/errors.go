@@ -10,10 +10,26 @@ var ErrAborted = errors.New("aborted")
 // defined for multiple incompatible actions.
 var ErrDuplicateKeyAssignment = errors.New("duplicate key assignment")
 
+// ErrInvalidColor is returned when a Color could not be parsed from the given
+// input.
+var ErrInvalidColor = errors.New("invalid color")
+
+// ErrInvalidConfig is returned when the Config being loaded has an invalid
+// value or an unknown field.
+var ErrInvalidConfig = errors.New("invalid config")
+
+// ErrInvalidCursorShape is returned when a CursorShape could not be parsed
+// from the given input.
+var ErrInvalidCursorShape = errors.New("invalid cursor shape")
+
 // ErrInvalidDimensions is returned when the style sheet has dimensions that
 // does not make sense.
 var ErrInvalidDimensions = errors.New("invalid dimensions")
 
+// ErrInvalidInput is returned (wrapped in a DiagnosticsError) when the input
+// read in the non-interactive mode has errors in it as per the Validator.
+var ErrInvalidInput = errors.New("invalid input")
+
 // ErrUnsupportedChromaLanguage is returned when Syntax-Highlighting is
 // requested with Chroma library with a language that it does not understand.
 var ErrUnsupportedChromaLanguage = errors.New("unsupported language for chroma")
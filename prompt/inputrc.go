@@ -0,0 +1,622 @@
+package prompt
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+)
+
+// InputRC contains the results of parsing a GNU Readline init file (usually
+// ~/.inputrc).
+type InputRC struct {
+	// KeyMap is the KeyMap with all the supported key bindings applied.
+	KeyMap KeyMap
+	// Unsupported contains everything that could not be translated into the
+	// KeyMap, like unknown/unsupported functions, keys and macros.
+	Unsupported []InputRCIssue
+	// Variables contains all the variables defined with "set" in the active
+	// parts of the file. The ones in inputRCVariables are mapped onto a Config
+	// by ApplyTo, while the rest are reported in Unsupported.
+	Variables map[string]string
+}
+
+// ApplyTo sets the KeyMap on the given Config, and maps the supported
+// Variables onto its Style:
+//   - blink-matching-paren: Style.Brackets.Highlight
+//   - horizontal-scroll-mode: Style.HorizontalScroll.Enabled
+func (rc *InputRC) ApplyTo(c *Config) {
+	c.KeyMap = rc.KeyMap
+	for name, value := range rc.Variables {
+		if apply, ok := inputRCVariables[name]; ok && apply != nil {
+			apply(c, inputRCBool(value))
+		}
+	}
+}
+
+// InputRCIssue describes a line in the inputrc file that could not be handled.
+type InputRCIssue struct {
+	File   string
+	Line   int
+	Text   string
+	Reason string
+}
+
+// String returns the issue in human-readable format.
+func (i InputRCIssue) String() string {
+	return fmt.Sprintf("%s:%d: %s [%s]", i.File, i.Line, i.Reason, i.Text)
+}
+
+// LoadInputRC reads the inputrc file at the given path and applies the key
+// bindings in it over the given KeyMap. The appName is used to evaluate
+// "$if <application>" blocks.
+func LoadInputRC(path string, appName string, keyMap KeyMap) (*InputRC, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	return parseInputRC(f, path, appName, keyMap)
+}
+
+// ParseInputRC parses the inputrc contents from the given io.Reader and applies
+// the key bindings in it over the given KeyMap. The appName is used to
+// evaluate "$if <application>" blocks.
+//
+// Only the bindings in the emacs key-maps are applied, as the readline
+// functions they refer to are translated into the equivalent Actions. Anything
+// that cannot be translated is reported in InputRC.Unsupported.
+func ParseInputRC(r io.Reader, appName string, keyMap KeyMap) (*InputRC, error) {
+	return parseInputRC(r, "inputrc", appName, keyMap)
+}
+
+func parseInputRC(r io.Reader, file string, appName string, keyMap KeyMap) (*InputRC, error) {
+	p := &inputRCParser{
+		appName: appName,
+		includes: map[string]bool{
+			file: true,
+		},
+		keyMap: "emacs",
+		rc: &InputRC{
+			KeyMap:    keyMap,
+			Variables: make(map[string]string),
+		},
+	}
+	if err := p.parse(r, file); err != nil {
+		return nil, err
+	}
+	for _, cond := range p.conditions {
+		p.report(file, cond.line, "$if", "missing $endif")
+	}
+	if _, err := p.rc.KeyMap.reverse(); err != nil {
+		return nil, err
+	}
+	return p.rc, nil
+}
+
+// inputRCFunctionActionMap maps readline functions to Actions.
+var inputRCFunctionActionMap = map[string]Action{
+	"abort":                    Abort,
+	"accept-line":              Terminate,
+	"backward-char":            MoveLeftOneCharacter,
+	"backward-delete-char":     DeleteCharPrevious,
+	"backward-kill-line":       EraseToBeginningOfLine,
+	"backward-kill-word":       DeleteWordPrevious,
+	"backward-word":            MoveToWordPrevious,
+	"beginning-of-line":        MoveToBeginningOfLine,
+	"capitalize-word":          MakeWordCapitalCase,
+	"complete":                 AutoComplete,
+	"copy-region-as-kill":      Copy,
+	"delete-char":              DeleteCharCurrent,
+	"downcase-word":            MakeWordLowerCase,
+	"edit-and-execute-command": EditInExternalEditor,
+	"end-of-file":              Abort,
+	"end-of-line":              MoveToEndOfLine,
+	"forward-char":             MoveRightOneCharacter,
+	"forward-word":             MoveToWordNext,
+	"kill-line":                EraseToEndOfLine,
+	"kill-region":              Cut,
+	"kill-whole-line":          EraseEverything,
+	"kill-word":                DeleteWordNext,
+	"menu-complete":            AutoCompleteChooseNext,
+	"menu-complete-backward":   AutoCompleteChoosePrevious,
+	"next-history":             HistoryNext,
+	"next-screen-line":         MoveDownOneLine,
+	"previous-history":         HistoryPrevious,
+	"previous-screen-line":     MoveUpOneLine,
+	"unix-line-discard":        EraseToBeginningOfLine,
+	"unix-word-rubout":         DeleteWordPrevious,
+	"upcase-word":              MakeWordUpperCase,
+	"yank":                     Paste,
+}
+
+// inputRCVariables contains the supported variables, along with the functions
+// to map their values onto a Config. The ones without a function are handled
+// by the parser itself.
+var inputRCVariables = map[string]func(c *Config, on bool){
+	"blink-matching-paren": func(c *Config, on bool) {
+		c.Style.Brackets.Highlight = on
+	},
+	"editing-mode": nil,
+	"horizontal-scroll-mode": func(c *Config, on bool) {
+		c.Style.HorizontalScroll.Enabled = on
+	},
+	"keymap": nil,
+}
+
+// inputRCEscapeSequences maps the escape sequences sent by terminals for
+// special keys to the KeySequences.
+var inputRCEscapeSequences = map[string]KeySequence{
+	"\x1b[A":    ArrowUp,
+	"\x1b[B":    ArrowDown,
+	"\x1b[C":    ArrowRight,
+	"\x1b[D":    ArrowLeft,
+	"\x1bOA":    ArrowUp,
+	"\x1bOB":    ArrowDown,
+	"\x1bOC":    ArrowRight,
+	"\x1bOD":    ArrowLeft,
+	"\x1b[1;2A": ShiftArrowUp,
+	"\x1b[1;2B": ShiftArrowDown,
+	"\x1b[1;2C": ShiftArrowRight,
+	"\x1b[1;2D": ShiftArrowLeft,
+	"\x1b[1;2F": ShiftEnd,
+	"\x1b[1;2H": ShiftHome,
+	"\x1b[1;5A": CtrlArrowUp,
+	"\x1b[1;5B": CtrlArrowDown,
+	"\x1b[1;5C": CtrlArrowRight,
+	"\x1b[1;5D": CtrlArrowLeft,
+	"\x1b[1;5F": CtrlEnd,
+	"\x1b[1;5H": CtrlHome,
+	"\x1b[1;6A": CtrlShiftArrowUp,
+	"\x1b[1;6B": CtrlShiftArrowDown,
+	"\x1b[1;6C": CtrlShiftArrowRight,
+	"\x1b[1;6D": CtrlShiftArrowLeft,
+	"\x1b[1;6F": CtrlShiftEnd,
+	"\x1b[1;6H": CtrlShiftHome,
+	"\x1b[1~":   Home,
+	"\x1b[2~":   Insert,
+	"\x1b[3~":   Delete,
+	"\x1b[4~":   End,
+	"\x1b[5~":   PageUp,
+	"\x1b[6~":   PageDown,
+	"\x1b[7~":   Home,
+	"\x1b[8~":   End,
+	"\x1b[F":    End,
+	"\x1b[H":    Home,
+	"\x1bOF":    End,
+	"\x1bOH":    Home,
+	"\x1b[Z":    ShiftTab,
+	"\x1bOP":    F1,
+	"\x1bOQ":    F2,
+	"\x1bOR":    F3,
+	"\x1bOS":    F4,
+	"\x1b[15~":  F5,
+	"\x1b[17~":  F6,
+	"\x1b[18~":  F7,
+	"\x1b[19~":  F8,
+	"\x1b[20~":  F9,
+	"\x1b[21~":  F10,
+	"\x1b[23~":  F11,
+	"\x1b[24~":  F12,
+}
+
+// inputRCKeyNames maps the symbolic key names to the characters.
+var inputRCKeyNames = map[string]byte{
+	"del":     0x7f,
+	"esc":     0x1b,
+	"escape":  0x1b,
+	"lfd":     '\n',
+	"newline": '\n',
+	"ret":     '\r',
+	"return":  '\r',
+	"rubout":  0x7f,
+	"space":   ' ',
+	"spc":     ' ',
+	"tab":     '\t',
+}
+
+type inputRCCondition struct {
+	line         int
+	parentActive bool
+	result       bool
+	active       bool
+}
+
+type inputRCParser struct {
+	appName    string
+	conditions []inputRCCondition
+	includes   map[string]bool
+	keyMap     string
+	rc         *InputRC
+}
+
+func (p *inputRCParser) isActive() bool {
+	if len(p.conditions) == 0 {
+		return true
+	}
+	return p.conditions[len(p.conditions)-1].active
+}
+
+func (p *inputRCParser) parse(r io.Reader, file string) error {
+	scanner := bufio.NewScanner(r)
+	for lineNum := 1; scanner.Scan(); lineNum++ {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+
+		if strings.HasPrefix(line, "$") {
+			if err := p.parseDirective(file, lineNum, line); err != nil {
+				return err
+			}
+		} else if p.isActive() {
+			if fields := strings.Fields(line); strings.ToLower(fields[0]) == "set" {
+				p.parseVariable(file, lineNum, line, fields)
+			} else {
+				p.parseBinding(file, lineNum, line)
+			}
+		}
+	}
+	return scanner.Err()
+}
+
+func (p *inputRCParser) parseBinding(file string, lineNum int, line string) {
+	keyStr, value, ok := splitInputRCBinding(line)
+	if !ok {
+		p.report(file, lineNum, line, "invalid key binding")
+		return
+	}
+	if strings.HasPrefix(value, "\"") || strings.HasPrefix(value, "'") {
+		p.report(file, lineNum, line, "macros are not supported")
+		return
+	}
+	function := strings.ToLower(strings.Fields(value)[0])
+	action, ok := inputRCFunctionActionMap[function]
+	if !ok {
+		p.report(file, lineNum, line, fmt.Sprintf("unsupported function %#v", function))
+		return
+	}
+
+	if !strings.HasPrefix(p.keyMap, "emacs") {
+		p.report(file, lineNum, line, fmt.Sprintf("bindings for key-map %#v are not supported", p.keyMap))
+		return
+	}
+	raw, err := parseInputRCKey(keyStr)
+	if err != nil {
+		p.report(file, lineNum, line, err.Error())
+		return
+	}
+	keySequence, err := inputRCKeySequence(p.keyMapPrefix() + raw)
+	if err != nil {
+		p.report(file, lineNum, line, err.Error())
+		return
+	}
+	p.rc.KeyMap.bind(keySequence, action)
+}
+
+//gocyclo:ignore
+func (p *inputRCParser) parseDirective(file string, lineNum int, line string) error {
+	directive, arg := line, ""
+	if idx := strings.IndexAny(line, " \t"); idx > 0 {
+		directive, arg = line[:idx], strings.TrimSpace(line[idx:])
+	}
+
+	switch strings.ToLower(directive) {
+	case "$if":
+		parentActive := p.isActive()
+		result := p.evaluateCondition(file, lineNum, arg)
+		p.conditions = append(p.conditions, inputRCCondition{
+			line:         lineNum,
+			parentActive: parentActive,
+			result:       result,
+			active:       parentActive && result,
+		})
+	case "$else":
+		if len(p.conditions) == 0 {
+			p.report(file, lineNum, line, "$else without $if")
+			return nil
+		}
+		cond := &p.conditions[len(p.conditions)-1]
+		cond.active = cond.parentActive && !cond.result
+	case "$endif":
+		if len(p.conditions) == 0 {
+			p.report(file, lineNum, line, "$endif without $if")
+			return nil
+		}
+		p.conditions = p.conditions[:len(p.conditions)-1]
+	case "$include":
+		if !p.isActive() {
+			return nil
+		}
+		path := arg
+		if strings.HasPrefix(path, "~/") {
+			if home, err := os.UserHomeDir(); err == nil {
+				path = filepath.Join(home, path[2:])
+			}
+		}
+		if p.includes[path] {
+			p.report(file, lineNum, line, "recursive $include")
+			return nil
+		}
+		f, err := os.Open(path)
+		if err != nil {
+			p.report(file, lineNum, line, err.Error())
+			return nil
+		}
+		defer f.Close()
+
+		p.includes[path] = true
+		defer delete(p.includes, path)
+		return p.parse(f, path)
+	default:
+		p.report(file, lineNum, line, "unknown directive")
+	}
+	return nil
+}
+
+func (p *inputRCParser) parseVariable(file string, lineNum int, line string, fields []string) {
+	if len(fields) < 3 {
+		p.report(file, lineNum, line, "invalid variable definition")
+		return
+	}
+	name, value := strings.ToLower(fields[1]), strings.Join(fields[2:], " ")
+	p.rc.Variables[name] = value
+
+	switch name {
+	case "editing-mode":
+		p.keyMap = strings.ToLower(value)
+		if p.keyMap == "vi" {
+			p.report(file, lineNum, line, "vi editing-mode is not supported")
+		}
+	case "keymap":
+		p.keyMap = strings.ToLower(value)
+	default:
+		if _, ok := inputRCVariables[name]; !ok {
+			p.report(file, lineNum, line, fmt.Sprintf("unsupported variable %#v", name))
+		}
+	}
+}
+
+func (p *inputRCParser) evaluateCondition(file string, lineNum int, cond string) bool {
+	if strings.HasPrefix(cond, "mode=") {
+		mode := "emacs"
+		if strings.HasPrefix(p.keyMap, "vi") {
+			mode = "vi"
+		}
+		return strings.EqualFold(strings.TrimPrefix(cond, "mode="), mode)
+	}
+	if strings.HasPrefix(cond, "term=") {
+		term, termWanted := os.Getenv("TERM"), strings.TrimPrefix(cond, "term=")
+		return term == termWanted || strings.Split(term, "-")[0] == termWanted
+	}
+	if parts := strings.SplitN(cond, "==", 2); len(parts) == 2 {
+		return p.rc.Variables[strings.TrimSpace(parts[0])] == strings.TrimSpace(parts[1])
+	}
+	if parts := strings.SplitN(cond, "!=", 2); len(parts) == 2 {
+		return p.rc.Variables[strings.TrimSpace(parts[0])] != strings.TrimSpace(parts[1])
+	}
+	if strings.ContainsAny(cond, "<>= \t") {
+		p.report(file, lineNum, "$if "+cond, "unsupported condition")
+		return false
+	}
+	return strings.EqualFold(cond, p.appName)
+}
+
+// inputRCBool returns the value of a boolean variable; readline treats "on"
+// (in any case) and "1" as on, and everything else as off.
+func inputRCBool(value string) bool {
+	return strings.EqualFold(value, "on") || value == "1"
+}
+
+func (p *inputRCParser) keyMapPrefix() string {
+	switch p.keyMap {
+	case "emacs-meta":
+		return "\x1b"
+	case "emacs-ctlx":
+		return "\x18"
+	}
+	return ""
+}
+
+func (p *inputRCParser) report(file string, lineNum int, line string, reason string) {
+	p.rc.Unsupported = append(p.rc.Unsupported, InputRCIssue{
+		File:   file,
+		Line:   lineNum,
+		Text:   line,
+		Reason: reason,
+	})
+}
+
+// parseInputRCKey converts the key portion of a key binding (either a quoted
+// escape sequence like "\C-w" or a symbolic name like Control-w) into the raw
+// characters sent by the terminal.
+func parseInputRCKey(key string) (string, error) {
+	var raw string
+	if strings.HasPrefix(key, "\"") {
+		var err error
+		if raw, err = unescapeInputRCKey(strings.Trim(key, "\"")); err != nil {
+			return "", err
+		}
+		if raw == "" {
+			return "", fmt.Errorf("empty key sequence")
+		}
+	} else {
+		parts := strings.Split(key, "-")
+		name := parts[len(parts)-1]
+		if name == "" && len(parts) > 1 { // Meta--
+			name = "-"
+		}
+		if c, ok := inputRCKeyNames[strings.ToLower(name)]; ok {
+			raw = string(c)
+		} else if len(name) == 1 {
+			raw = name
+		} else {
+			return "", fmt.Errorf("unknown key name %#v", name)
+		}
+		for idx := len(parts) - 2; idx >= 0; idx-- {
+			switch strings.ToLower(parts[idx]) {
+			case "c", "control", "ctrl":
+				raw = string(inputRCControl(raw[len(raw)-1]))
+			case "m", "meta":
+				raw = "\x1b" + raw
+			case "":
+			default:
+				return "", fmt.Errorf("unknown modifier %#v", parts[idx])
+			}
+		}
+	}
+	return raw, nil
+}
+
+func unescapeInputRCKey(key string) (string, error) {
+	out := strings.Builder{}
+	for idx := 0; idx < len(key); {
+		chars, next, err := unescapeInputRCKeyChar(key, idx)
+		if err != nil {
+			return "", err
+		}
+		out.WriteString(chars)
+		idx = next
+	}
+	return out.String(), nil
+}
+
+// unescapeInputRCKeyChar unescapes the character (or the escape sequence)
+// beginning at idx and returns the resulting characters and the index of the
+// next character.
+//
+//gocyclo:ignore
+func unescapeInputRCKeyChar(key string, idx int) (string, int, error) {
+	if key[idx] != '\\' || idx == len(key)-1 {
+		return key[idx : idx+1], idx + 1, nil
+	}
+
+	idx++
+	switch c := key[idx]; c {
+	case 'C', 'M':
+		if idx+2 >= len(key) || key[idx+1] != '-' {
+			return "", 0, fmt.Errorf("incomplete escape sequence \"\\%c\"", c)
+		}
+		chars, next, err := unescapeInputRCKeyChar(key, idx+2)
+		if err != nil {
+			return "", 0, err
+		}
+		if c == 'M' {
+			return "\x1b" + chars, next, nil
+		}
+		return string(inputRCControl(chars[len(chars)-1])), next, nil
+	case 'e':
+		return "\x1b", idx + 1, nil
+	case 'a':
+		return "\a", idx + 1, nil
+	case 'b':
+		return "\b", idx + 1, nil
+	case 'd':
+		return "\x7f", idx + 1, nil
+	case 'f':
+		return "\f", idx + 1, nil
+	case 'n':
+		return "\n", idx + 1, nil
+	case 'r':
+		return "\r", idx + 1, nil
+	case 't':
+		return "\t", idx + 1, nil
+	case 'v':
+		return "\v", idx + 1, nil
+	case 'x':
+		end := idx + 1
+		for end < len(key) && end < idx+3 && strings.ContainsRune("0123456789abcdefABCDEF", rune(key[end])) {
+			end++
+		}
+		v, err := strconv.ParseUint(key[idx+1:end], 16, 8)
+		if err != nil {
+			return "", 0, fmt.Errorf("invalid hex escape sequence in %#v", key)
+		}
+		return string([]byte{byte(v)}), end, nil
+	case '0', '1', '2', '3', '4', '5', '6', '7':
+		end := idx
+		for end < len(key) && end < idx+3 && key[end] >= '0' && key[end] <= '7' {
+			end++
+		}
+		v, err := strconv.ParseUint(key[idx:end], 8, 8)
+		if err != nil {
+			return "", 0, fmt.Errorf("invalid octal escape sequence in %#v", key)
+		}
+		return string([]byte{byte(v)}), end, nil
+	default: // \\, \", \' and others map to themselves
+		return key[idx : idx+1], idx + 1, nil
+	}
+}
+
+func inputRCControl(c byte) byte {
+	if c == '?' {
+		return 0x7f
+	}
+	if c >= 'a' && c <= 'z' {
+		c -= 'a' - 'A'
+	}
+	return c & 0x1f
+}
+
+// inputRCKeySequence translates the raw characters of a key into the
+// KeySequence.
+func inputRCKeySequence(raw string) (KeySequence, error) {
+	if ks, ok := inputRCEscapeSequences[raw]; ok {
+		return ks, nil
+	}
+	if len(raw) == 2 && raw[0] == 0x1b {
+		if ks, ok := altKeySequenceMap[rune(raw[1])]; ok {
+			return ks, nil
+		}
+	}
+	if len(raw) == 1 {
+		switch c := raw[0]; {
+		case c == 0:
+			return CtrlSpace, nil
+		case c == '\t':
+			return Tab, nil
+		case c == '\r':
+			return Enter, nil
+		case c == 0x1b:
+			return Escape, nil
+		case c == 0x7f:
+			return Backspace, nil
+		case c == ' ':
+			return Space, nil
+		case c < 0x1b:
+			return KeySequence(fmt.Sprintf("ctrl+%c", 'a'+c-1)), nil
+		}
+	}
+	if len(raw) > 1 && raw[0] != 0x1b {
+		return "", fmt.Errorf("key chords like %#v are not supported", raw)
+	}
+	return "", fmt.Errorf("unsupported key sequence %#v", raw)
+}
+
+// splitInputRCBinding splits a key binding line into the key and the value.
+func splitInputRCBinding(line string) (string, string, bool) {
+	idx := 0
+	if strings.HasPrefix(line, "\"") {
+		for idx = 1; idx < len(line) && line[idx] != '"'; idx++ {
+			if line[idx] == '\\' {
+				idx++
+			}
+		}
+		if idx >= len(line) {
+			return "", "", false
+		}
+	}
+	colonIdx := strings.IndexByte(line[idx:], ':')
+	if colonIdx < 0 {
+		return "", "", false
+	}
+	key, value := strings.TrimSpace(line[:idx+colonIdx]), strings.TrimSpace(line[idx+colonIdx+1:])
+	if key == "" || value == "" {
+		return "", "", false
+	}
+	return key, value, true
+}
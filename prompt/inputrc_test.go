@@ -0,0 +1,216 @@
+package prompt
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestInputRCIssue_String(t *testing.T) {
+	issue := InputRCIssue{File: "~/.inputrc", Line: 3, Text: `"\C-o": operate-and-get-next`, Reason: "unsupported function"}
+	assert.Equal(t, `~/.inputrc:3: unsupported function ["\C-o": operate-and-get-next]`, issue.String())
+}
+
+func TestInputRC_ApplyTo(t *testing.T) {
+	in := `
+set blink-matching-paren Off
+set horizontal-scroll-mode on
+set bell-style none
+"\C-y": kill-whole-line
+`
+	rc, err := ParseInputRC(strings.NewReader(in), "foo", KeyMapDefault)
+	assert.Nil(t, err)
+	assert.NotNil(t, rc)
+	if rc == nil {
+		return
+	}
+
+	c := ConfigDefault()
+	rc.ApplyTo(&c)
+	assert.Equal(t, KeySequences{AltW, CtrlY}, c.KeyMap.Insert.EraseEverything)
+	assert.False(t, c.Style.Brackets.Highlight)
+	assert.True(t, c.Style.HorizontalScroll.Enabled)
+
+	// the defaults should be untouched
+	assert.True(t, StyleDefault.Brackets.Highlight)
+	assert.False(t, StyleDefault.HorizontalScroll.Enabled)
+}
+
+func TestLoadInputRC(t *testing.T) {
+	dir := t.TempDir()
+	pathIncluded := filepath.Join(dir, "included")
+	err := os.WriteFile(pathIncluded, []byte(`"\C-y": kill-whole-line`), 0644)
+	assert.Nil(t, err)
+	path := filepath.Join(dir, "inputrc")
+	err = os.WriteFile(path, []byte("$include "+pathIncluded+"\n$include "+path+"\n"), 0644)
+	assert.Nil(t, err)
+
+	rc, err := LoadInputRC(path, "foo", KeyMapDefault)
+	assert.Nil(t, err)
+	assert.NotNil(t, rc)
+	if rc != nil {
+		assert.Equal(t, KeySequences{AltW, CtrlY}, rc.KeyMap.Insert.EraseEverything)
+		assert.Len(t, rc.Unsupported, 1)
+		assert.Equal(t, "recursive $include", rc.Unsupported[0].Reason)
+	}
+
+	rc, err = LoadInputRC(filepath.Join(dir, "does-not-exist"), "foo", KeyMapDefault)
+	assert.NotNil(t, err)
+	assert.Nil(t, rc)
+}
+
+func TestParseInputRC(t *testing.T) {
+	in := `
+# comment
+set bell-style none
+set completion-ignore-case on
+
+"\C-w": unix-word-rubout
+Control-y: backward-kill-word
+Meta-Rubout: backward-kill-word
+"\e[1;5C": forward-word
+"\eOD": backward-char
+"\M-u": capitalize-word
+"\C-x\C-e": edit-and-execute-command
+"\C-o": operate-and-get-next
+"\C-t": "macro text"
+"\C-q" quoted-insert
+
+$if Foo
+  "\C-p": previous-history
+$else
+  "\C-p": next-history
+$endif
+
+$if mode=emacs
+  Tab: menu-complete
+  "\e[Z": menu-complete-backward
+$endif
+
+$if version >= 8.0
+  "\C-n": next-history
+$endif
+
+set keymap emacs-meta
+"d": kill-word
+
+set editing-mode vi
+"\C-a": beginning-of-line
+$endif
+$else
+$unknown
+`
+	rc, err := ParseInputRC(strings.NewReader(in), "foo", KeyMapDefault)
+	assert.Nil(t, err)
+	assert.NotNil(t, rc)
+	if rc == nil {
+		return
+	}
+
+	assert.Equal(t, KeySequences{CtrlW, CtrlY}, rc.KeyMap.Insert.DeleteWordPrevious)
+	assert.Equal(t, KeySequences{AltF, CtrlArrowRight}, rc.KeyMap.Insert.MoveToWordNext)
+	assert.Equal(t, KeySequences{ArrowLeft}, rc.KeyMap.Insert.MoveLeftOneCharacter)
+	assert.Equal(t, KeySequences{AltC, AltU}, rc.KeyMap.Insert.MakeWordCapitalCase)
+	assert.Equal(t, KeySequences{}, rc.KeyMap.Insert.MakeWordUpperCase)
+	assert.Equal(t, KeySequences{ArrowUp, CtrlP}, rc.KeyMap.Insert.HistoryPrevious)
+	assert.Equal(t, KeySequences{ArrowDown}, rc.KeyMap.Insert.HistoryNext)
+	assert.Equal(t, KeySequences{ArrowDown, Tab}, rc.KeyMap.AutoComplete.ChooseNext)
+	assert.Equal(t, KeySequences{ArrowUp, ShiftTab}, rc.KeyMap.AutoComplete.ChoosePrevious)
+	assert.Equal(t, KeySequences{}, rc.KeyMap.AutoComplete.Select)
+	assert.Equal(t, KeySequences{AltD}, rc.KeyMap.Insert.DeleteWordNext)
+	assert.Equal(t, KeySequences{Home}, rc.KeyMap.Insert.MoveToBeginningOfLine)
+	assert.Equal(t, map[string]string{
+		"bell-style":             "none",
+		"completion-ignore-case": "on",
+		"editing-mode":           "vi",
+		"keymap":                 "emacs-meta",
+	}, rc.Variables)
+
+	// the defaults should be untouched
+	assert.Equal(t, KeySequences{CtrlW}, KeyMapDefault.Insert.DeleteWordPrevious)
+	assert.Equal(t, KeySequences{Tab}, KeyMapDefault.AutoComplete.Select)
+
+	var unsupported []string
+	for _, issue := range rc.Unsupported {
+		unsupported = append(unsupported, issue.String())
+	}
+	assert.Equal(t, []string{
+		`inputrc:3: unsupported variable "bell-style" [set bell-style none]`,
+		`inputrc:4: unsupported variable "completion-ignore-case" [set completion-ignore-case on]`,
+		`inputrc:8: unsupported key sequence "\x1b\x7f" [Meta-Rubout: backward-kill-word]`,
+		`inputrc:12: key chords like "\x18\x05" are not supported ["\C-x\C-e": edit-and-execute-command]`,
+		`inputrc:13: unsupported function "operate-and-get-next" ["\C-o": operate-and-get-next]`,
+		`inputrc:14: macros are not supported ["\C-t": "macro text"]`,
+		`inputrc:15: invalid key binding ["\C-q" quoted-insert]`,
+		`inputrc:28: unsupported condition [$if version >= 8.0]`,
+		`inputrc:35: vi editing-mode is not supported [set editing-mode vi]`,
+		`inputrc:36: bindings for key-map "vi" are not supported ["\C-a": beginning-of-line]`,
+		`inputrc:37: $endif without $if [$endif]`,
+		`inputrc:38: $else without $if [$else]`,
+		`inputrc:39: unknown directive [$unknown]`,
+	}, unsupported)
+}
+
+func TestParseInputRC_MissingEndIf(t *testing.T) {
+	rc, err := ParseInputRC(strings.NewReader("$if foo\n\"\\C-y\": kill-whole-line\n"), "bar", KeyMapDefault)
+	assert.Nil(t, err)
+	assert.NotNil(t, rc)
+	if rc != nil {
+		assert.Equal(t, KeyMapDefault.Insert.EraseEverything, rc.KeyMap.Insert.EraseEverything)
+		assert.Len(t, rc.Unsupported, 1)
+		assert.Equal(t, "missing $endif", rc.Unsupported[0].Reason)
+	}
+}
+
+func TestParseInputRCKey(t *testing.T) {
+	for in, expected := range map[string]KeySequence{
+		`"\C-a"`:       CtrlA,
+		`"\C-?"`:       Backspace,
+		`"\C-@"`:       CtrlSpace,
+		`"\C-i"`:       Tab,
+		`"\C-m"`:       Enter,
+		`"\d"`:         Backspace,
+		`"\t"`:         Tab,
+		`"\r"`:         Enter,
+		`"\n"`:         CtrlJ,
+		`"\e"`:         Escape,
+		`"\ef"`:        AltF,
+		`"\M-f"`:       AltF,
+		`"\033[A"`:     ArrowUp,
+		`"\x1b[B"`:     ArrowDown,
+		`"\e[3~"`:      Delete,
+		`"\e[1;2D"`:    ShiftArrowLeft,
+		`"\eOP"`:       F1,
+		`"\e[24~"`:     F12,
+		`C-w`:          CtrlW,
+		`Control-W`:    CtrlW,
+		`Meta-b`:       AltB,
+		`M-C-?`:        "",
+		`Rubout`:       Backspace,
+		`ESC`:          Escape,
+		`SPC`:          Space,
+		`Return`:       Enter,
+		`"\M-\C-h"`:    "",
+		`Foo-a`:        "",
+		`Control-Blah`: "",
+		`"\C"`:         "",
+		`"\xZZ"`:       "",
+		`""`:           "",
+		`"a"`:          "",
+	} {
+		raw, err := parseInputRCKey(in)
+		var actual KeySequence
+		if err == nil {
+			actual, err = inputRCKeySequence(raw)
+		}
+		if expected == "" {
+			assert.NotNil(t, err, in)
+		} else {
+			assert.Nil(t, err, in)
+		}
+		assert.Equal(t, expected, actual, in)
+	}
+}
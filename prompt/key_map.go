@@ -9,8 +9,8 @@ import (
 // KeyMap can be used to customize or define the behavior of the Prompt for each
 // special Key sequences that is entered by the User.
 type KeyMap struct {
-	AutoComplete AutoCompleteKeyMap
-	Insert       InsertKeyMap
+	AutoComplete AutoCompleteKeyMap `json:"auto_complete"`
+	Insert       InsertKeyMap       `json:"insert"`
 
 	errors []error
 }
@@ -28,35 +28,60 @@ var KeyMapSingleLine = KeyMap{
 		Select:         KeySequences{Tab},
 	},
 	Insert: InsertKeyMap{
-		Abort:                  KeySequences{CtrlC, CtrlD, Escape},
-		AutoComplete:           KeySequences{CtrlSpace},
-		DeleteCharCurrent:      KeySequences{Delete},
-		DeleteCharPrevious:     KeySequences{Backspace, CtrlH},
-		DeleteWordNext:         KeySequences{AltD},
-		DeleteWordPrevious:     KeySequences{CtrlW},
-		EraseEverything:        KeySequences{AltW},
-		EraseToBeginningOfLine: KeySequences{CtrlU},
-		EraseToEndOfLine:       KeySequences{CtrlK},
-		HistoryNext:            KeySequences{ArrowDown},
-		HistoryPrevious:        KeySequences{ArrowUp},
-		MakeWordCapitalCase:    KeySequences{AltC},
-		MakeWordLowerCase:      KeySequences{AltL},
-		MakeWordUpperCase:      KeySequences{AltU},
-		MoveDownOneLine:        KeySequences{},
-		MoveLeftOneCharacter:   KeySequences{ArrowLeft},
-		MoveRightOneCharacter:  KeySequences{ArrowRight},
-		MoveToBeginning:        KeySequences{CtrlHome},
-		MoveToBeginningOfLine:  KeySequences{Home},
-		MoveToEnd:              KeySequences{CtrlEnd},
-		MoveToEndOfLine:        KeySequences{End},
-		MoveToWordNext:         KeySequences{CtrlArrowRight, AltF},
-		MoveToWordPrevious:     KeySequences{CtrlArrowLeft, AltB},
-		MoveUpOneLine:          KeySequences{},
-		SwapCharacterNext:      KeySequences{CtrlN},
-		SwapCharacterPrevious:  KeySequences{CtrlT},
-		SwapWordNext:           KeySequences{AltN},
-		SwapWordPrevious:       KeySequences{AltT},
-		Terminate:              KeySequences{Enter},
+		Abort:                   KeySequences{CtrlC, CtrlD, Escape},
+		AutoComplete:            KeySequences{CtrlSpace},
+		Copy:                    KeySequences{AltX},
+		Cut:                     KeySequences{CtrlX},
+		Dedent:                  KeySequences{},
+		DeleteCharCurrent:       KeySequences{Delete},
+		DeleteCharPrevious:      KeySequences{Backspace, CtrlH},
+		DeleteLine:              KeySequences{},
+		DeleteWordNext:          KeySequences{AltD},
+		DeleteWordPrevious:      KeySequences{CtrlW},
+		DuplicateLine:           KeySequences{},
+		EditInExternalEditor:    KeySequences{AltE}, // not Ctrl+X Ctrl+E as in bash: no key chords, and Ctrl+X is Cut
+		EraseEverything:         KeySequences{AltW},
+		EraseToBeginningOfLine:  KeySequences{CtrlU},
+		EraseToEndOfLine:        KeySequences{CtrlK},
+		HistoryNext:             KeySequences{ArrowDown},
+		HistoryPrevious:         KeySequences{ArrowUp},
+		Indent:                  KeySequences{},
+		JoinWithNextLine:        KeySequences{},
+		JumpToMatchingBracket:   KeySequences{AltM},
+		MakeWordCapitalCase:     KeySequences{AltC},
+		MakeWordLowerCase:       KeySequences{AltL},
+		MakeWordUpperCase:       KeySequences{AltU},
+		MoveDownOneLine:         KeySequences{},
+		MoveLeftOneCharacter:    KeySequences{ArrowLeft},
+		MoveLineDown:            KeySequences{},
+		MoveLineUp:              KeySequences{},
+		MoveRightOneCharacter:   KeySequences{ArrowRight},
+		MoveToBeginning:         KeySequences{CtrlHome},
+		MoveToBeginningOfLine:   KeySequences{Home},
+		MoveToEnd:               KeySequences{CtrlEnd},
+		MoveToEndOfLine:         KeySequences{End},
+		MoveToWordNext:          KeySequences{CtrlArrowRight, AltF},
+		MoveToWordPrevious:      KeySequences{CtrlArrowLeft, AltB},
+		MoveUpOneLine:           KeySequences{},
+		OpenLineAbove:           KeySequences{},
+		OpenLineBelow:           KeySequences{},
+		Paste:                   KeySequences{CtrlV, CtrlY},
+		SelectDownOneLine:       KeySequences{ShiftArrowDown},
+		SelectLeftOneCharacter:  KeySequences{ShiftArrowLeft},
+		SelectRightOneCharacter: KeySequences{ShiftArrowRight},
+		SelectToBeginning:       KeySequences{CtrlShiftHome},
+		SelectToBeginningOfLine: KeySequences{ShiftHome},
+		SelectToEnd:             KeySequences{CtrlShiftEnd},
+		SelectToEndOfLine:       KeySequences{ShiftEnd},
+		SelectToWordNext:        KeySequences{CtrlShiftArrowRight},
+		SelectToWordPrevious:    KeySequences{CtrlShiftArrowLeft},
+		SelectUpOneLine:         KeySequences{ShiftArrowUp},
+		SwapCharacterNext:       KeySequences{CtrlN},
+		SwapCharacterPrevious:   KeySequences{CtrlT},
+		SwapWordNext:            KeySequences{AltN},
+		SwapWordPrevious:        KeySequences{AltT},
+		Terminate:               KeySequences{Enter},
+		ToggleLineComment:       KeySequences{},
 	},
 }
 
@@ -69,76 +94,196 @@ var KeyMapMultiLine = KeyMap{
 		Select:         KeySequences{Tab},
 	},
 	Insert: InsertKeyMap{
-		Abort:                  KeySequences{CtrlC, CtrlD, Escape},
-		AutoComplete:           KeySequences{CtrlSpace},
-		DeleteCharCurrent:      KeySequences{Delete},
-		DeleteCharPrevious:     KeySequences{Backspace, CtrlH},
-		DeleteWordNext:         KeySequences{AltD},
-		DeleteWordPrevious:     KeySequences{CtrlW},
-		EraseEverything:        KeySequences{AltW},
-		EraseToBeginningOfLine: KeySequences{CtrlU},
-		EraseToEndOfLine:       KeySequences{CtrlK},
-		HistoryNext:            KeySequences{ShiftArrowDown},
-		HistoryPrevious:        KeySequences{ShiftArrowUp},
-		MakeWordCapitalCase:    KeySequences{AltC},
-		MakeWordLowerCase:      KeySequences{AltL},
-		MakeWordUpperCase:      KeySequences{AltU},
-		MoveDownOneLine:        KeySequences{ArrowDown},
-		MoveLeftOneCharacter:   KeySequences{ArrowLeft},
-		MoveRightOneCharacter:  KeySequences{ArrowRight},
-		MoveToBeginning:        KeySequences{CtrlHome},
-		MoveToBeginningOfLine:  KeySequences{Home},
-		MoveToEnd:              KeySequences{CtrlEnd},
-		MoveToEndOfLine:        KeySequences{End},
-		MoveToWordNext:         KeySequences{CtrlArrowRight, AltF},
-		MoveToWordPrevious:     KeySequences{CtrlArrowLeft, AltB},
-		MoveUpOneLine:          KeySequences{ArrowUp},
-		SwapCharacterNext:      KeySequences{CtrlN},
-		SwapCharacterPrevious:  KeySequences{CtrlT},
-		SwapWordNext:           KeySequences{AltN},
-		SwapWordPrevious:       KeySequences{AltT},
-		Terminate:              KeySequences{Enter},
+		Abort:                   KeySequences{CtrlC, CtrlD, Escape},
+		AutoComplete:            KeySequences{CtrlSpace},
+		Copy:                    KeySequences{AltX},
+		Cut:                     KeySequences{CtrlX},
+		Dedent:                  KeySequences{ShiftTab},
+		DeleteCharCurrent:       KeySequences{Delete},
+		DeleteCharPrevious:      KeySequences{Backspace, CtrlH},
+		DeleteLine:              KeySequences{AltK},
+		DeleteWordNext:          KeySequences{AltD},
+		DeleteWordPrevious:      KeySequences{CtrlW},
+		DuplicateLine:           KeySequences{AltP},
+		EditInExternalEditor:    KeySequences{AltE}, // not Ctrl+X Ctrl+E as in bash: no key chords, and Ctrl+X is Cut
+		EraseEverything:         KeySequences{AltW},
+		EraseToBeginningOfLine:  KeySequences{CtrlU},
+		EraseToEndOfLine:        KeySequences{CtrlK},
+		HistoryNext:             KeySequences{ShiftArrowDown},
+		HistoryPrevious:         KeySequences{ShiftArrowUp},
+		Indent:                  KeySequences{Tab},
+		JoinWithNextLine:        KeySequences{AltJ},
+		JumpToMatchingBracket:   KeySequences{AltM},
+		MakeWordCapitalCase:     KeySequences{AltC},
+		MakeWordLowerCase:       KeySequences{AltL},
+		MakeWordUpperCase:       KeySequences{AltU},
+		MoveDownOneLine:         KeySequences{ArrowDown},
+		MoveLeftOneCharacter:    KeySequences{ArrowLeft},
+		MoveLineDown:            KeySequences{CtrlArrowDown},
+		MoveLineUp:              KeySequences{CtrlArrowUp},
+		MoveRightOneCharacter:   KeySequences{ArrowRight},
+		MoveToBeginning:         KeySequences{CtrlHome},
+		MoveToBeginningOfLine:   KeySequences{Home},
+		MoveToEnd:               KeySequences{CtrlEnd},
+		MoveToEndOfLine:         KeySequences{End},
+		MoveToWordNext:          KeySequences{CtrlArrowRight, AltF},
+		MoveToWordPrevious:      KeySequences{CtrlArrowLeft, AltB},
+		MoveUpOneLine:           KeySequences{ArrowUp},
+		OpenLineAbove:           KeySequences{AltO},
+		OpenLineBelow:           KeySequences{CtrlO},
+		Paste:                   KeySequences{CtrlV, CtrlY},
+		SelectDownOneLine:       KeySequences{CtrlShiftArrowDown},
+		SelectLeftOneCharacter:  KeySequences{ShiftArrowLeft},
+		SelectRightOneCharacter: KeySequences{ShiftArrowRight},
+		SelectToBeginning:       KeySequences{CtrlShiftHome},
+		SelectToBeginningOfLine: KeySequences{ShiftHome},
+		SelectToEnd:             KeySequences{CtrlShiftEnd},
+		SelectToEndOfLine:       KeySequences{ShiftEnd},
+		SelectToWordNext:        KeySequences{CtrlShiftArrowRight},
+		SelectToWordPrevious:    KeySequences{CtrlShiftArrowLeft},
+		SelectUpOneLine:         KeySequences{CtrlShiftArrowUp},
+		SwapCharacterNext:       KeySequences{CtrlN},
+		SwapCharacterPrevious:   KeySequences{CtrlT},
+		SwapWordNext:            KeySequences{AltN},
+		SwapWordPrevious:        KeySequences{AltT},
+		Terminate:               KeySequences{Enter},
+		ToggleLineComment:       KeySequences{AltQ},
 	},
 }
 
 // AutoCompleteKeyMap is the KeyMap used in AutoComplete mode.
 type AutoCompleteKeyMap struct {
-	ChooseNext     KeySequences
-	ChoosePrevious KeySequences
-	Select         KeySequences
+	ChooseNext     KeySequences `json:"choose_next"`
+	ChoosePrevious KeySequences `json:"choose_previous"`
+	Select         KeySequences `json:"select"`
 }
 
 // InsertKeyMap is the KeyMap used in Insert mode.
 type InsertKeyMap struct {
-	Abort                  KeySequences
-	AutoComplete           KeySequences
-	DeleteCharCurrent      KeySequences
-	DeleteCharPrevious     KeySequences
-	DeleteWordNext         KeySequences
-	DeleteWordPrevious     KeySequences
-	EraseEverything        KeySequences
-	EraseToBeginningOfLine KeySequences
-	EraseToEndOfLine       KeySequences
-	HistoryNext            KeySequences
-	HistoryPrevious        KeySequences
-	MakeWordCapitalCase    KeySequences
-	MakeWordLowerCase      KeySequences
-	MakeWordUpperCase      KeySequences
-	MoveDownOneLine        KeySequences
-	MoveLeftOneCharacter   KeySequences
-	MoveRightOneCharacter  KeySequences
-	MoveToBeginning        KeySequences
-	MoveToBeginningOfLine  KeySequences
-	MoveToEnd              KeySequences
-	MoveToEndOfLine        KeySequences
-	MoveToWordNext         KeySequences
-	MoveToWordPrevious     KeySequences
-	MoveUpOneLine          KeySequences
-	SwapCharacterNext      KeySequences
-	SwapCharacterPrevious  KeySequences
-	SwapWordNext           KeySequences
-	SwapWordPrevious       KeySequences
-	Terminate              KeySequences
+	Abort                   KeySequences `json:"abort"`
+	AutoComplete            KeySequences `json:"auto_complete"`
+	Copy                    KeySequences `json:"copy"`
+	Cut                     KeySequences `json:"cut"`
+	Dedent                  KeySequences `json:"dedent"`
+	DeleteCharCurrent       KeySequences `json:"delete_char_current"`
+	DeleteCharPrevious      KeySequences `json:"delete_char_previous"`
+	DeleteLine              KeySequences `json:"delete_line"`
+	DeleteWordNext          KeySequences `json:"delete_word_next"`
+	DeleteWordPrevious      KeySequences `json:"delete_word_previous"`
+	DuplicateLine           KeySequences `json:"duplicate_line"`
+	EditInExternalEditor    KeySequences `json:"edit_in_external_editor"`
+	EraseEverything         KeySequences `json:"erase_everything"`
+	EraseToBeginningOfLine  KeySequences `json:"erase_to_beginning_of_line"`
+	EraseToEndOfLine        KeySequences `json:"erase_to_end_of_line"`
+	HistoryNext             KeySequences `json:"history_next"`
+	HistoryPrevious         KeySequences `json:"history_previous"`
+	Indent                  KeySequences `json:"indent"`
+	JoinWithNextLine        KeySequences `json:"join_with_next_line"`
+	JumpToMatchingBracket   KeySequences `json:"jump_to_matching_bracket"`
+	MakeWordCapitalCase     KeySequences `json:"make_word_capital_case"`
+	MakeWordLowerCase       KeySequences `json:"make_word_lower_case"`
+	MakeWordUpperCase       KeySequences `json:"make_word_upper_case"`
+	MoveDownOneLine         KeySequences `json:"move_down_one_line"`
+	MoveLeftOneCharacter    KeySequences `json:"move_left_one_character"`
+	MoveLineDown            KeySequences `json:"move_line_down"`
+	MoveLineUp              KeySequences `json:"move_line_up"`
+	MoveRightOneCharacter   KeySequences `json:"move_right_one_character"`
+	MoveToBeginning         KeySequences `json:"move_to_beginning"`
+	MoveToBeginningOfLine   KeySequences `json:"move_to_beginning_of_line"`
+	MoveToEnd               KeySequences `json:"move_to_end"`
+	MoveToEndOfLine         KeySequences `json:"move_to_end_of_line"`
+	MoveToWordNext          KeySequences `json:"move_to_word_next"`
+	MoveToWordPrevious      KeySequences `json:"move_to_word_previous"`
+	MoveUpOneLine           KeySequences `json:"move_up_one_line"`
+	OpenLineAbove           KeySequences `json:"open_line_above"`
+	OpenLineBelow           KeySequences `json:"open_line_below"`
+	Paste                   KeySequences `json:"paste"`
+	SelectDownOneLine       KeySequences `json:"select_down_one_line"`
+	SelectLeftOneCharacter  KeySequences `json:"select_left_one_character"`
+	SelectRightOneCharacter KeySequences `json:"select_right_one_character"`
+	SelectToBeginning       KeySequences `json:"select_to_beginning"`
+	SelectToBeginningOfLine KeySequences `json:"select_to_beginning_of_line"`
+	SelectToEnd             KeySequences `json:"select_to_end"`
+	SelectToEndOfLine       KeySequences `json:"select_to_end_of_line"`
+	SelectToWordNext        KeySequences `json:"select_to_word_next"`
+	SelectToWordPrevious    KeySequences `json:"select_to_word_previous"`
+	SelectUpOneLine         KeySequences `json:"select_up_one_line"`
+	SwapCharacterNext       KeySequences `json:"swap_character_next"`
+	SwapCharacterPrevious   KeySequences `json:"swap_character_previous"`
+	SwapWordNext            KeySequences `json:"swap_word_next"`
+	SwapWordPrevious        KeySequences `json:"swap_word_previous"`
+	Terminate               KeySequences `json:"terminate"`
+	ToggleLineComment       KeySequences `json:"toggle_line_comment"`
+}
+
+// keyMapBinding ties an Action to the KeySequences in the KeyMap that trigger
+// it.
+type keyMapBinding struct {
+	Action       Action
+	KeySequences *KeySequences
+}
+
+func (k *KeyMap) bindingsAutoComplete() []keyMapBinding {
+	return []keyMapBinding{
+		{AutoCompleteChooseNext, &k.AutoComplete.ChooseNext},
+		{AutoCompleteChoosePrevious, &k.AutoComplete.ChoosePrevious},
+		{AutoCompleteSelect, &k.AutoComplete.Select},
+	}
+}
+
+func (k *KeyMap) bindingsInsert() []keyMapBinding {
+	return []keyMapBinding{
+		{Abort, &k.Insert.Abort},
+		{AutoComplete, &k.Insert.AutoComplete},
+		{Copy, &k.Insert.Copy},
+		{Cut, &k.Insert.Cut},
+		{Dedent, &k.Insert.Dedent},
+		{DeleteCharCurrent, &k.Insert.DeleteCharCurrent},
+		{DeleteCharPrevious, &k.Insert.DeleteCharPrevious},
+		{DeleteLine, &k.Insert.DeleteLine},
+		{DeleteWordNext, &k.Insert.DeleteWordNext},
+		{DeleteWordPrevious, &k.Insert.DeleteWordPrevious},
+		{DuplicateLine, &k.Insert.DuplicateLine},
+		{EditInExternalEditor, &k.Insert.EditInExternalEditor},
+		{EraseEverything, &k.Insert.EraseEverything},
+		{EraseToBeginningOfLine, &k.Insert.EraseToBeginningOfLine},
+		{EraseToEndOfLine, &k.Insert.EraseToEndOfLine},
+		{HistoryNext, &k.Insert.HistoryNext},
+		{HistoryPrevious, &k.Insert.HistoryPrevious},
+		{Indent, &k.Insert.Indent},
+		{JoinWithNextLine, &k.Insert.JoinWithNextLine},
+		{JumpToMatchingBracket, &k.Insert.JumpToMatchingBracket},
+		{MakeWordCapitalCase, &k.Insert.MakeWordCapitalCase},
+		{MakeWordLowerCase, &k.Insert.MakeWordLowerCase},
+		{MakeWordUpperCase, &k.Insert.MakeWordUpperCase},
+		{MoveDownOneLine, &k.Insert.MoveDownOneLine},
+		{MoveLeftOneCharacter, &k.Insert.MoveLeftOneCharacter},
+		{MoveLineDown, &k.Insert.MoveLineDown},
+		{MoveLineUp, &k.Insert.MoveLineUp},
+		{MoveRightOneCharacter, &k.Insert.MoveRightOneCharacter},
+		{MoveToBeginning, &k.Insert.MoveToBeginning},
+		{MoveToBeginningOfLine, &k.Insert.MoveToBeginningOfLine},
+		{MoveToEnd, &k.Insert.MoveToEnd},
+		{MoveToEndOfLine, &k.Insert.MoveToEndOfLine},
+		{MoveToWordNext, &k.Insert.MoveToWordNext},
+		{MoveToWordPrevious, &k.Insert.MoveToWordPrevious},
+		{MoveUpOneLine, &k.Insert.MoveUpOneLine},
+		{OpenLineAbove, &k.Insert.OpenLineAbove},
+		{OpenLineBelow, &k.Insert.OpenLineBelow},
+		{Paste, &k.Insert.Paste},
+		{SelectDownOneLine, &k.Insert.SelectDownOneLine},
+		{SelectLeftOneCharacter, &k.Insert.SelectLeftOneCharacter},
+		{SelectRightOneCharacter, &k.Insert.SelectRightOneCharacter},
+		{SelectToBeginning, &k.Insert.SelectToBeginning},
+		{SelectToBeginningOfLine, &k.Insert.SelectToBeginningOfLine},
+		{SelectToEnd, &k.Insert.SelectToEnd},
+		{SelectToEndOfLine, &k.Insert.SelectToEndOfLine},
+		{SelectToWordNext, &k.Insert.SelectToWordNext},
+		{SelectToWordPrevious, &k.Insert.SelectToWordPrevious},
+		{SelectUpOneLine, &k.Insert.SelectUpOneLine},
+		{Terminate, &k.Insert.Terminate},
+		{ToggleLineComment, &k.Insert.ToggleLineComment},
+	}
 }
 
 // keyMapReversed is an internal representation of the KeyMap for easy
@@ -155,34 +300,12 @@ func (k *KeyMap) reverse() (*keyMapReversed, error) {
 	}
 
 	k.errors = make([]error, 0)
-	k.reverseAddKeySequences(rsp.AutoComplete, k.AutoComplete.ChooseNext, AutoCompleteChooseNext)
-	k.reverseAddKeySequences(rsp.AutoComplete, k.AutoComplete.ChoosePrevious, AutoCompleteChoosePrevious)
-	k.reverseAddKeySequences(rsp.AutoComplete, k.AutoComplete.Select, AutoCompleteSelect)
-	k.reverseAddKeySequences(rsp.Insert, k.Insert.Abort, Abort)
-	k.reverseAddKeySequences(rsp.Insert, k.Insert.AutoComplete, AutoComplete)
-	k.reverseAddKeySequences(rsp.Insert, k.Insert.DeleteCharCurrent, DeleteCharCurrent)
-	k.reverseAddKeySequences(rsp.Insert, k.Insert.DeleteCharPrevious, DeleteCharPrevious)
-	k.reverseAddKeySequences(rsp.Insert, k.Insert.DeleteWordNext, DeleteWordNext)
-	k.reverseAddKeySequences(rsp.Insert, k.Insert.DeleteWordPrevious, DeleteWordPrevious)
-	k.reverseAddKeySequences(rsp.Insert, k.Insert.EraseEverything, EraseEverything)
-	k.reverseAddKeySequences(rsp.Insert, k.Insert.EraseToBeginningOfLine, EraseToBeginningOfLine)
-	k.reverseAddKeySequences(rsp.Insert, k.Insert.EraseToEndOfLine, EraseToEndOfLine)
-	k.reverseAddKeySequences(rsp.Insert, k.Insert.HistoryNext, HistoryNext)
-	k.reverseAddKeySequences(rsp.Insert, k.Insert.HistoryPrevious, HistoryPrevious)
-	k.reverseAddKeySequences(rsp.Insert, k.Insert.MakeWordCapitalCase, MakeWordCapitalCase)
-	k.reverseAddKeySequences(rsp.Insert, k.Insert.MakeWordLowerCase, MakeWordLowerCase)
-	k.reverseAddKeySequences(rsp.Insert, k.Insert.MakeWordUpperCase, MakeWordUpperCase)
-	k.reverseAddKeySequences(rsp.Insert, k.Insert.MoveDownOneLine, MoveDownOneLine)
-	k.reverseAddKeySequences(rsp.Insert, k.Insert.MoveLeftOneCharacter, MoveLeftOneCharacter)
-	k.reverseAddKeySequences(rsp.Insert, k.Insert.MoveRightOneCharacter, MoveRightOneCharacter)
-	k.reverseAddKeySequences(rsp.Insert, k.Insert.MoveToBeginning, MoveToBeginning)
-	k.reverseAddKeySequences(rsp.Insert, k.Insert.MoveToBeginningOfLine, MoveToBeginningOfLine)
-	k.reverseAddKeySequences(rsp.Insert, k.Insert.MoveToEnd, MoveToEnd)
-	k.reverseAddKeySequences(rsp.Insert, k.Insert.MoveToEndOfLine, MoveToEndOfLine)
-	k.reverseAddKeySequences(rsp.Insert, k.Insert.MoveToWordNext, MoveToWordNext)
-	k.reverseAddKeySequences(rsp.Insert, k.Insert.MoveToWordPrevious, MoveToWordPrevious)
-	k.reverseAddKeySequences(rsp.Insert, k.Insert.MoveUpOneLine, MoveUpOneLine)
-	k.reverseAddKeySequences(rsp.Insert, k.Insert.Terminate, Terminate)
+	for _, binding := range k.bindingsAutoComplete() {
+		k.reverseAddKeySequences(rsp.AutoComplete, *binding.KeySequences, binding.Action)
+	}
+	for _, binding := range k.bindingsInsert() {
+		k.reverseAddKeySequences(rsp.Insert, *binding.KeySequences, binding.Action)
+	}
 	if len(k.errors) > 0 {
 		errStrings := make([]string, len(k.errors))
 		for idx, err := range k.errors {
@@ -207,3 +330,33 @@ func (k *KeyMap) reverseAddKeySequences(m map[KeySequence]Action, keySequences K
 		m[keySequence] = action
 	}
 }
+
+// bind binds the KeySequence to the given Action after un-binding it from any
+// other Action in the same mode. Returns false if the Action is unknown.
+func (k *KeyMap) bind(keySequence KeySequence, action Action) bool {
+	for _, bindings := range [][]keyMapBinding{k.bindingsAutoComplete(), k.bindingsInsert()} {
+		var target *KeySequences
+		for _, binding := range bindings {
+			if binding.Action == action {
+				target = binding.KeySequences
+			}
+		}
+		if target == nil {
+			continue
+		}
+
+		// always make new slices to not modify the shared default KeyMaps
+		for _, binding := range bindings {
+			keySequences := make(KeySequences, 0, len(*binding.KeySequences)+1)
+			for _, ks := range *binding.KeySequences {
+				if ks != keySequence {
+					keySequences = append(keySequences, ks)
+				}
+			}
+			*binding.KeySequences = keySequences
+		}
+		*target = append(*target, keySequence)
+		return true
+	}
+	return false
+}
@@ -12,6 +12,52 @@ const (
 // Prefixer returns the string to precede any new prompt.
 type Prefixer func() string
 
+// ContinuationPrefixer returns the string to precede the lines after the first
+// one (including wrapped lines) in a multi-line prompt, given the 0-indexed
+// line number and all the text before the start of the line.
+type ContinuationPrefixer func(lineNum int, textSoFar string) string
+
+// RightGutter returns the text to be rendered right-aligned on the first row
+// of every line of input, given the 0-indexed line number and the text of the
+// line (without any escape sequences).
+type RightGutter func(lineNum int, line string) string
+
+// ContinuationPrefixText uses the given text as the continuation prefix.
+func ContinuationPrefixText(text string) ContinuationPrefixer {
+	return func(lineNum int, textSoFar string) string {
+		return text
+	}
+}
+
+// ContinuationPrefixOpenBrackets uses the given text as the continuation
+// prefix, preceded by the unclosed brackets and quotes in the text so far (if
+// any) to indicate what is pending; ex.: "(' -> ".
+func ContinuationPrefixOpenBrackets(text string) ContinuationPrefixer {
+	return func(lineNum int, textSoFar string) string {
+		var open []rune
+		for _, r := range textSoFar {
+			isInQuotes := len(open) > 0 && (open[len(open)-1] == '\'' || open[len(open)-1] == '"' || open[len(open)-1] == '`')
+			switch {
+			case isInQuotes && r == open[len(open)-1]:
+				open = open[:len(open)-1]
+			case isInQuotes:
+				// ignore everything inside quotes
+			case r == '(' || r == '[' || r == '{' || r == '\'' || r == '"' || r == '`':
+				open = append(open, r)
+			case len(open) > 0 && r == closingBrackets[open[len(open)-1]]:
+				open = open[:len(open)-1]
+			}
+		}
+		return string(open) + text
+	}
+}
+
+var closingBrackets = map[rune]rune{
+	'(': ')',
+	'[': ']',
+	'{': '}',
+}
+
 // PrefixNone uses no prompt prefix.
 func PrefixNone() Prefixer {
 	return PrefixText("")
@@ -8,6 +8,22 @@ import (
 	"github.com/stretchr/testify/assert"
 )
 
+func TestContinuationPrefixOpenBrackets(t *testing.T) {
+	prefixer := ContinuationPrefixOpenBrackets("-> ")
+
+	assert.Equal(t, "-> ", prefixer(1, ""))
+	assert.Equal(t, "-> ", prefixer(1, "select count(*) from dual\n"))
+	assert.Equal(t, "(-> ", prefixer(1, "select count(\n"))
+	assert.Equal(t, "('-> ", prefixer(2, "select concat(\n'foo (\n"))
+	assert.Equal(t, "[{-> ", prefixer(1, "{[]}\n[{\"}\"\n"))
+}
+
+func TestContinuationPrefixText(t *testing.T) {
+	prefixer := ContinuationPrefixText("... ")
+
+	assert.Equal(t, "... ", prefixer(1, "foo\n"))
+}
+
 func TestPrefixNone(t *testing.T) {
 	prefixer := PrefixNone()
 
@@ -1,6 +1,7 @@
 package prompt
 
 import (
+	"bufio"
 	"context"
 	"fmt"
 	"io"
@@ -21,13 +22,25 @@ const (
 	DefaultHistoryListPrefix = "!!"             // !! => list all history
 	DefaultRefreshInterval   = time.Second / 60 // 60hz
 
-	debugMarginWidth = 4
+	debugMarginWidth     = 4
+	defaultDisplayHeight = 24 // if the height of the terminal is not known
 )
 
+// terminalSizer is implemented by outputs that know the size of the terminal
+// they write to, like the virtual terminal in the prompttest package.
+type terminalSizer interface {
+	TerminalSize() (width int, height int)
+}
+
 type prompt struct {
+	accessible              bool
+	activeColorProfile      termenv.Profile
 	autoCompleter           AutoCompleter
 	autoCompleterContextual AutoCompleter
+	colorProfile            *termenv.Profile
+	continuationPrefixer    ContinuationPrefixer
 	debug                   bool
+	editorFileExtension     string
 	footerGenerator         LineGenerator
 	footerGeneratorMutex    sync.RWMutex
 	isInAutoComplete        bool
@@ -37,46 +50,87 @@ type prompt struct {
 	historyExecPrefix       string
 	historyListPrefix       string
 	input                   io.Reader
+	interactive             *bool
 	keyMap                  KeyMap
 	keyMapReversed          *keyMapReversed
+	lineReader              *bufio.Reader
+	lineReaderInput         io.Reader
+	lineReaderMutex         sync.Mutex
+	lineReaderPending       chan lineRead
+	mouseSupport            bool
 	output                  io.Writer
+	placeholder             string
 	prefixer                Prefixer
 	promptMutex             sync.Mutex
+	recorder                Recorder
 	refreshInterval         time.Duration
+	rightGutter             RightGutter
+	rightPrompter           Prefixer
 	shortcuts               map[KeySequence]string
 	style                   *Style
+	syncUpdates             *bool
 	syntaxHighlighter       SyntaxHighlighter
 	terminationChecker      TerminationChecker
+	transientPrefixer       Prefixer
+	validator               Validator
+	visualHomeEnd           bool
 	widthEnforcer           WidthEnforcer
 
 	// render state
+	accessibleDiagnostics       []Diagnostic
+	accessibleEchoed            string
+	accessiblePrompted          bool
+	accessibleSuggestions       []Suggestion
+	accessibleSuggestionsIdx    int
 	active                      bool
 	activeMutex                 sync.RWMutex
 	autoCompleteForced          bool
 	autoCompleteForcedMutex     sync.RWMutex
 	buffer                      *buffer
+	bytesRendered               int
+	clipboard                   string
 	cursorColor                 Color
 	cursorColorMutex            sync.RWMutex
 	debugData                   map[string]string
+	diagnostics                 []Diagnostic
+	diagnosticsInput            string
+	diagnosticsMutex            sync.RWMutex
 	debugDataMutex              sync.RWMutex
+	displayHeight               int
+	displayHeightMutex          sync.RWMutex
 	displayWidth                int
 	displayWidthMutex           sync.RWMutex
 	footer                      string
 	footerMutex                 sync.RWMutex
+	fullScreen                  bool
+	fullScreenInput             string
+	fullScreenMutex             sync.RWMutex
+	fullScreenPrinted           []string
 	header                      string
 	headerMutex                 sync.RWMutex
+	horizontalScrollOffset      int
 	linesMutex                  sync.Mutex
 	linesRendered               []string
 	linesToRender               []string
+	mouseEventsPending          []tea.MouseMsg
+	mouseMapRendered            *mouseMap
+	mouseMapToRender            *mouseMap
+	mouseQueryRows              int
 	reader                      input.Reader
 	readerMutex                 sync.Mutex
 	renderingPaused             bool
 	renderingPausedMutex        sync.RWMutex
+	screenCursorColumn          int
+	screenRows                  []screenRow
 	suggestions                 []Suggestion
 	suggestionsIdx              int
 	suggestionsMutex            sync.RWMutex
 	syntaxHighlighterCache      map[string][]string
 	syntaxHighlighterCacheMutex sync.RWMutex
+	terminalCursor              *CursorLocation
+	terminalCursorRowsUp        int
+	terminalCursorShape         CursorShape
+	terminalCursorShapeRendered CursorShape
 	timeAutoComplete            time.Duration
 	timeBufferGen               time.Duration
 	timeGen                     time.Duration
@@ -122,9 +176,24 @@ func (p *prompt) NumLines() int {
 	return 0
 }
 
+// Print prints the given items (formatted like fmt.Sprint) above the active
+// prompt without corrupting it, and can be called safely from any goroutine. If
+// there is no active prompt, the text is printed as is.
+func (p *prompt) Print(a ...any) {
+	p.printAbove(fmt.Sprint(a...))
+}
+
+// Printf prints the formatted text (like fmt.Sprintf) above the active prompt
+// without corrupting it, and can be called safely from any goroutine. If there
+// is no active prompt, the text is printed as is.
+func (p *prompt) Printf(format string, a ...any) {
+	p.printAbove(fmt.Sprintf(format, a...))
+}
+
 // Prompt prompts. It also watches for cancel KeyEvents on the Context to abort the
-// prompt and return control to client.
-func (p *prompt) Prompt(ctx context.Context) (string, error) {
+// prompt and return control to client. The PromptOptions customize just this
+// one prompt.
+func (p *prompt) Prompt(ctx context.Context, opts ...PromptOption) (string, error) {
 	p.promptMutex.Lock()
 	defer p.promptMutex.Unlock()
 	ctx, cancel := context.WithCancel(ctx)
@@ -137,12 +206,28 @@ func (p *prompt) Prompt(ctx context.Context) (string, error) {
 		return "", err
 	}
 
+	// read plain lines without rendering anything if not on a terminal
+	if !p.isInteractive() {
+		userInput, err := p.promptNonInteractive(ctx)
+		if err == nil {
+			p.history.Append(userInput)
+		}
+		return userInput, err
+	}
+
 	// init output
-	output := termenv.NewOutput(p.getOutputWriter())
+	output := p.getOutput(true)
 	defer func() {
 		output.Reset()
 	}()
 
+	// render using the colors supported by the output
+	p.activeColorProfile = p.getColorProfile(output)
+
+	// use the whole terminal if asked for
+	options := newPromptOptions(opts...)
+	p.setFullScreen(options.fullScreen && !p.isAccessible())
+
 	userInput, err := p.render(ctx, output)
 	if err == nil {
 		p.history.Append(userInput)
@@ -150,8 +235,8 @@ func (p *prompt) Prompt(ctx context.Context) (string, error) {
 	return userInput, err
 }
 
-// SendInput lets you send strings/runes/KeySequence to the currently active
-// prompt.
+// SendInput lets you send strings/runes/KeySequence/KeyPress to the currently
+// active prompt.
 func (p *prompt) SendInput(a []any, delayBetweenRunes ...time.Duration) error {
 	delay := time.Duration(0)
 	if len(delayBetweenRunes) > 0 {
@@ -164,6 +249,13 @@ func (p *prompt) SendInput(a []any, delayBetweenRunes ...time.Duration) error {
 		}
 
 		switch obj := item.(type) {
+		case KeyPress:
+			if km, ok := obj.keyMsg(); ok {
+				err := p.reader.Send(km)
+				if err != nil {
+					return err
+				}
+			}
 		case KeySequence:
 			if km, ok := keySequenceKeyMsgMap[obj]; ok {
 				err := p.reader.Send(km)
@@ -188,13 +280,23 @@ func (p *prompt) SendInput(a []any, delayBetweenRunes ...time.Duration) error {
 				time.Sleep(delay)
 			}
 		default:
-			return fmt.Errorf("%w: [#%d] %#v (allowed: prompt.KeySequence, time.Duration, rune, string)",
+			return fmt.Errorf("%w: [#%d] %#v (allowed: prompt.KeyPress, prompt.KeySequence, time.Duration, rune, string)",
 				ErrUnsupportedInput, idx, item)
 		}
 	}
 	return nil
 }
 
+// SetAccessibleMode enables or disables the accessible mode meant for use with
+// screen readers. In this mode, the prompt never rewrites anything it printed
+// before: the cursor does not blink, the header, footer and auto-complete
+// drop-down are not rendered, edits are echoed as they are made, and the
+// suggestions are announced as plain lines of text. The mode can also be
+// enabled using the EnvVarAccessible environment variable.
+func (p *prompt) SetAccessibleMode(enabled bool) {
+	p.accessible = enabled
+}
+
 // SetAutoCompleter sets up the AutoCompleter that will be used to provide
 // suggestions. Consider this as an auto completer which will be useful for
 // suggesting global stuff like language keywords, or global variables.
@@ -212,6 +314,14 @@ func (p *prompt) SetAutoCompleterContextual(autoCompleter AutoCompleter) {
 	p.autoCompleterContextual = autoCompleter
 }
 
+// SetColorProfile forces the color profile to render the prompt with, instead
+// of detecting it from the output and the environment (NO_COLOR, CLICOLOR,
+// CLICOLOR_FORCE and TERM) every time Prompt is called. Colors not supported
+// by the profile get downsampled, and termenv.Ascii disables them entirely.
+func (p *prompt) SetColorProfile(profile termenv.Profile) {
+	p.colorProfile = &profile
+}
+
 // SetCommandShortcuts sets up command shortcuts. For example, if you want to
 // get the prompt input as "/help" when the user presses F1, you'd call this
 // function with the argument:
@@ -226,11 +336,31 @@ func (p *prompt) SetCommandShortcuts(shortcuts map[KeySequence]string) {
 	p.shortcuts = shortcuts
 }
 
+// SetContinuationPrefixer sets up the prefixer to be called to generate the
+// prefix for the lines after the first one (including wrapped lines) in a
+// multi-line prompt, instead of repeating the prefix. Prefixes are padded or
+// truncated to the width of the prefix of the first line to keep the text
+// aligned.
+func (p *prompt) SetContinuationPrefixer(prefixer ContinuationPrefixer) {
+	p.continuationPrefixer = prefixer
+}
+
 // SetDebug enables/disables debug logs/messages in the prompt.
 func (p *prompt) SetDebug(debug bool) {
 	p.debug = debug
 }
 
+// SetExternalEditorFileExtension sets up the extension (like ".sql") for the
+// temporary file used to edit the prompt text in an external editor with the
+// EditInExternalEditor Action. This helps the editor pick the right syntax
+// highlighting.
+func (p *prompt) SetExternalEditorFileExtension(extension string) {
+	if extension != "" && !strings.HasPrefix(extension, ".") {
+		extension = "." + extension
+	}
+	p.editorFileExtension = extension
+}
+
 // SetFooter sets up the footer line above the prompt line for each render
 // cycle.
 func (p *prompt) SetFooter(footer string) {
@@ -293,6 +423,15 @@ func (p *prompt) SetInput(r io.Reader) {
 	p.initReader(true)
 }
 
+// SetInteractive forces the prompt to render (or not render) an interactive
+// editor, instead of detecting if the input or the output is a file or a pipe
+// that is not a terminal every time Prompt is called. In the non-interactive
+// mode, input is read one line at a time until the TerminationChecker deems it
+// complete, and nothing is rendered.
+func (p *prompt) SetInteractive(interactive bool) {
+	p.interactive = &interactive
+}
+
 // SetKeyMap sets up the KeyMap used for interacting with the user's input.
 func (p *prompt) SetKeyMap(keyMap KeyMap) error {
 	kmr, err := keyMap.reverse()
@@ -305,10 +444,27 @@ func (p *prompt) SetKeyMap(keyMap KeyMap) error {
 	return nil
 }
 
+// SetMouseSupport enables or disables handling Mouse events. When enabled,
+// clicking within the prompt moves the cursor, dragging selects text, clicking
+// on a suggestion selects it, and the wheel scrolls the suggestions or the
+// lines. Note that this disables the Terminal's native text selection for as
+// long as the prompt is active.
+func (p *prompt) SetMouseSupport(enabled bool) {
+	p.mouseSupport = enabled
+	p.initReader(true)
+}
+
 // SetOutput sets up the output to go to the given io.Writer instead of
 // os.Stdout.
 func (p *prompt) SetOutput(o io.Writer) {
 	p.output = o
+	p.initReader(true)
+}
+
+// SetPlaceholder sets up the text to be shown (dimmed) when there is no user
+// input yet.
+func (p *prompt) SetPlaceholder(text string) {
+	p.placeholder = text
 }
 
 // SetPrefix sets up the prefix to use before the prompt.
@@ -328,6 +484,12 @@ func (p *prompt) SetPrefixer(prefixer Prefixer) {
 	p.prefixer = prefixer
 }
 
+// SetRecorder sets up the Recorder to record the key-presses and the output of
+// the prompt with, like to replay them later. Use nil to stop recording.
+func (p *prompt) SetRecorder(recorder Recorder) {
+	p.recorder = recorder
+}
+
 // SetRefreshInterval sets up the minimum interval between consecutive renders.
 // Note that this can be overridden in case of a mandatory override event like
 // a cursor blink.
@@ -339,11 +501,49 @@ func (p *prompt) SetRefreshInterval(interval time.Duration) {
 	}
 }
 
+// SetRightGutter sets up the generator to be called to generate the text to be
+// rendered right-aligned on the first row of every line of input, like the
+// status of each statement. The gutter of a line gets hidden whenever its text
+// (or the right prompt on the same row) would overlap with it.
+func (p *prompt) SetRightGutter(gutter RightGutter) {
+	p.rightGutter = gutter
+}
+
+// SetRightPrompt sets up the text to be rendered right-aligned on the first
+// (or last) line of the prompt.
+//
+// SetRightPrompt and SetRightPrompter override the same property and the last
+// function called takes priority.
+func (p *prompt) SetRightPrompt(text string) {
+	p.rightPrompter = PrefixText(text)
+}
+
+// SetRightPrompter sets up the prefixer to be called to generate the text to
+// be rendered right-aligned on the first (or last) line of the prompt for each
+// render cycle. The right prompt gets hidden whenever the text on that line
+// would overlap with it.
+//
+// SetRightPrompt and SetRightPrompter override the same property and the last
+// function called takes priority.
+func (p *prompt) SetRightPrompter(prompter Prefixer) {
+	p.rightPrompter = prompter
+}
+
 // SetStyle sets up the Style sheet to be followed for the render.
 func (p *prompt) SetStyle(s Style) {
 	p.style = &s
 }
 
+// SetSynchronizedUpdates enables or disables wrapping every frame rendered in
+// synchronized update sequences (DEC mode 2026), which make the terminal show
+// the frame all at once instead of as it arrives. This is on by default (except
+// for TERM=dumb and TERM=linux) on a best-effort basis without querying the
+// terminal, as terminals that do not support it are expected to ignore them.
+// Turn it off for terminals that misbehave with them.
+func (p *prompt) SetSynchronizedUpdates(enabled bool) {
+	p.syncUpdates = &enabled
+}
+
 // SetSyntaxHighlighter sets up the function that will colorize and highlight
 // keywords in the user-input
 func (p *prompt) SetSyntaxHighlighter(highlighter SyntaxHighlighter) {
@@ -356,6 +556,35 @@ func (p *prompt) SetTerminationChecker(checker TerminationChecker) {
 	p.terminationChecker = checker
 }
 
+// SetTransientPrefixer enables the transient mode where the final render of
+// the prompt, which is left behind on the terminal once the user is done,
+// skips the header, footer and line numbers, and uses the prefix generated by
+// the given prefixer instead of the usual one. This keeps the scrollback free
+// of clutter while the active prompt stays rich. Use nil to disable it.
+func (p *prompt) SetTransientPrefixer(prefixer Prefixer) {
+	p.transientPrefixer = prefixer
+}
+
+// SetValidator sets up the function to validate the user input every time it
+// changes. The problems found are shown as underlined text with the messages
+// below the input, and the input is not submitted as long as there are errors.
+func (p *prompt) SetValidator(validator Validator) {
+	p.diagnosticsMutex.Lock()
+	defer p.diagnosticsMutex.Unlock()
+
+	p.validator = validator
+	p.diagnostics = nil
+	p.diagnosticsInput = ""
+}
+
+// SetVisualHomeEnd enables or disables moving to the beginning/end of the
+// wrapped row the cursor is on (before moving to the beginning/end of the line
+// on the next key press) with the MoveToBeginningOfLine and MoveToEndOfLine
+// Actions.
+func (p *prompt) SetVisualHomeEnd(enabled bool) {
+	p.visualHomeEnd = enabled
+}
+
 // SetWidthEnforcer sets up the function to wrap lines longer than the prompt
 // width.
 func (p *prompt) SetWidthEnforcer(enforcer WidthEnforcer) {
@@ -368,6 +597,12 @@ func (p *prompt) Style() *Style {
 	return p.style
 }
 
+// Writer returns an io.Writer that prints everything written to it above the
+// active prompt (see Print). Useful for hooking up loggers.
+func (p *prompt) Writer() io.Writer {
+	return &printWriter{p: p}
+}
+
 func (p *prompt) changeSuggestionsIdx(v int) bool {
 	p.suggestionsMutex.Lock()
 	defer p.suggestionsMutex.Unlock()
@@ -453,6 +688,18 @@ func (p *prompt) forcedAutoComplete() bool {
 	return p.autoCompleteForced
 }
 
+// getColorProfile returns the color profile forced using SetColorProfile, or
+// the one detected for the given output otherwise.
+func (p *prompt) getColorProfile(output *termenv.Output) termenv.Profile {
+	if p.colorProfile != nil {
+		return *p.colorProfile
+	}
+	if os.Getenv("TERM") == "dumb" {
+		return termenv.Ascii
+	}
+	return output.EnvColorProfile()
+}
+
 func (p *prompt) getCursorColor() Color {
 	p.cursorColorMutex.RLock()
 	defer p.cursorColorMutex.RUnlock()
@@ -460,6 +707,27 @@ func (p *prompt) getCursorColor() Color {
 	return p.cursorColor
 }
 
+func (p *prompt) getDiagnostics(input string) []Diagnostic {
+	p.diagnosticsMutex.Lock()
+	defer p.diagnosticsMutex.Unlock()
+
+	if p.validator == nil {
+		return nil
+	}
+	if p.diagnostics == nil || p.diagnosticsInput != input {
+		p.diagnostics = append([]Diagnostic{}, p.validator(input)...)
+		p.diagnosticsInput = input
+	}
+	return p.diagnostics
+}
+
+func (p *prompt) getDisplayHeight() int {
+	p.displayHeightMutex.RLock()
+	defer p.displayHeightMutex.RUnlock()
+
+	return p.displayHeight
+}
+
 func (p *prompt) getDisplayWidth() int {
 	p.displayWidthMutex.RLock()
 	defer p.displayWidthMutex.RUnlock()
@@ -488,6 +756,32 @@ func (p *prompt) getInputReader() io.Reader {
 	return os.Stdin
 }
 
+// getHeightLimits returns the minimum and the maximum number of rows to render
+// the input in. In the full-screen mode, the input fills up all the rows of the
+// terminal left over after the given number of rows used by everything else.
+func (p *prompt) getHeightLimits(numOtherRows int) (int, int) {
+	if !p.isFullScreen() {
+		return int(p.style.Dimensions.HeightMin), int(p.style.Dimensions.HeightMax)
+	}
+
+	height := p.getDisplayHeight() - numOtherRows - 1 // for the status line
+	if p.debug {                                      // for the final debug footer
+		height--
+	}
+	if height < 1 {
+		height = 1
+	}
+	return height, height
+}
+
+func (p *prompt) getTerminalSize() (int, int) {
+	if sizer, ok := p.getOutputWriter().(terminalSizer); ok {
+		return sizer.TerminalSize()
+	}
+	termWidth, termHeight, _ := term.GetSize(int(os.Stdout.Fd()))
+	return termWidth, termHeight
+}
+
 func (p *prompt) getOutputWriter() io.Writer {
 	if p.output != nil {
 		return p.output
@@ -495,6 +789,26 @@ func (p *prompt) getOutputWriter() io.Writer {
 	return os.Stdout
 }
 
+// getOutput returns the Output to write to. When the terminal is in raw mode
+// (as it is while reading the input), line feeds are written along with
+// carriage returns as they would otherwise not move the cursor back to the
+// beginning of the line.
+func (p *prompt) getOutput(isRawMode bool) *termenv.Output {
+	writer := p.getOutputWriter()
+	if p.recorder == nil && !isRawMode {
+		return termenv.NewOutput(writer)
+	}
+
+	isTTY := isTerminal(writer)
+	if p.recorder != nil {
+		writer = &recordingWriter{recorder: p.recorder, writer: writer}
+	}
+	if isRawMode {
+		writer = &rawModeWriter{writer: writer}
+	}
+	return termenv.NewOutput(writer, termenv.WithTTY(isTTY))
+}
+
 func (p *prompt) getSuggestionsAndIdx() ([]Suggestion, int) {
 	p.suggestionsMutex.RLock()
 	defer p.suggestionsMutex.RUnlock()
@@ -505,7 +819,9 @@ func (p *prompt) getSuggestionsAndIdx() ([]Suggestion, int) {
 func (p *prompt) init(ctx context.Context) {
 	p.initSync(ctx)
 
-	go p.updateCursorColors(ctx)
+	if !p.isAccessible() && !p.useTerminalCursor() {
+		go p.updateCursorColors(ctx)
+	}
 	go p.updateSuggestions(ctx)
 	go p.updateHeaderAndFooterAsync(ctx)
 }
@@ -517,16 +833,22 @@ func (p *prompt) initReader(force bool) {
 	if p.reader != nil && !force {
 		_ = p.reader.Reset()
 	} else {
-		p.reader = input.NewReader(
+		opts := []input.Option{
 			input.WithInput(p.getInputReader()),
+			input.WithOutput(p.getOutputWriter()),
 			input.WatchWindowSize(),
-		)
+		}
+		if p.mouseSupport {
+			opts = append(opts, input.WatchMouseClick())
+		}
+		p.reader = input.NewReader(opts...)
 	}
 }
 
 func (p *prompt) initSync(ctx context.Context) {
-	termWidth, _, _ := term.GetSize(int(os.Stdout.Fd()))
+	termWidth, termHeight := p.getTerminalSize()
 	p.updateDisplayWidth(termWidth)
+	p.updateDisplayHeight(termHeight)
 	p.updateHeaderAndFooter()
 
 	// in the buffer or reset it to previous state
@@ -539,8 +861,18 @@ func (p *prompt) initSync(ctx context.Context) {
 
 	// clear the rendering state model
 	p.linesMutex.Lock()
+	p.horizontalScrollOffset = 0
+	p.resetAccessibleState()
 	p.linesRendered = make([]string, 0)
 	p.linesToRender = make([]string, 0)
+	p.screenCursorColumn = 0
+	p.screenRows = nil
+	p.terminalCursor = nil
+	p.terminalCursorRowsUp = 0
+	p.fullScreenPrinted = nil
+	p.mouseEventsPending = nil
+	p.mouseMapRendered = nil
+	p.mouseMapToRender = nil
 	p.linesMutex.Unlock()
 
 	// clear/reset the reader
@@ -560,6 +892,13 @@ func (p *prompt) initSync(ctx context.Context) {
 	p.timeAutoComplete = time.Duration(0)
 }
 
+func (p *prompt) isFullScreen() bool {
+	p.fullScreenMutex.RLock()
+	defer p.fullScreenMutex.RUnlock()
+
+	return p.fullScreen
+}
+
 func (p *prompt) isRenderPaused() bool {
 	p.renderingPausedMutex.RLock()
 	defer p.renderingPausedMutex.RUnlock()
@@ -621,6 +960,13 @@ func (p *prompt) setDebugData(k, v string) {
 	}
 }
 
+func (p *prompt) setDisplayHeight(h int) {
+	p.displayHeightMutex.Lock()
+	defer p.displayHeightMutex.Unlock()
+
+	p.displayHeight = h
+}
+
 func (p *prompt) setDisplayWidth(w int) {
 	p.displayWidthMutex.Lock()
 	defer p.displayWidthMutex.Unlock()
@@ -628,6 +974,13 @@ func (p *prompt) setDisplayWidth(w int) {
 	p.displayWidth = w
 }
 
+func (p *prompt) setFullScreen(fullScreen bool) {
+	p.fullScreenMutex.Lock()
+	defer p.fullScreenMutex.Unlock()
+
+	p.fullScreen = fullScreen
+}
+
 func (p *prompt) setSuggestions(s []Suggestion) {
 	p.suggestionsMutex.Lock()
 	defer p.suggestionsMutex.Unlock()
@@ -671,6 +1024,13 @@ func (p *prompt) updateCursorColors(ctx context.Context) {
 	}
 }
 
+func (p *prompt) updateDisplayHeight(termHeight int) {
+	if termHeight <= 0 {
+		termHeight = defaultDisplayHeight
+	}
+	p.setDisplayHeight(termHeight)
+}
+
 func (p *prompt) updateDisplayWidth(termWidth int) {
 	termWidth = clampValue(
 		termWidth,
@@ -0,0 +1,139 @@
+package prompt
+
+import (
+	"fmt"
+	"os"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/jedib0t/go-pretty/v6/text"
+	"github.com/muesli/termenv"
+)
+
+// EnvVarAccessible is the environment variable which, when set to a non-empty
+// value other than "0" or "false", enables the accessible mode by default.
+const EnvVarAccessible = "ACCESSIBLE"
+
+// isAccessible returns true if the accessible mode was enabled using
+// SetAccessibleMode or the EnvVarAccessible environment variable.
+func (p *prompt) isAccessible() bool {
+	if p.accessible {
+		return true
+	}
+	switch strings.ToLower(strings.TrimSpace(os.Getenv(EnvVarAccessible))) {
+	case "", "0", "false":
+		return false
+	}
+	return true
+}
+
+// renderViewAccessible renders the prompt without ever going back to rewrite
+// anything written before. The prompt prefix is printed once, and the changes
+// to the input are echoed as they happen: text typed at the end is appended,
+// text deleted from the end is backspaced over, and any other change restates
+// the whole input on a new line. Suggestions are announced as plain lines of
+// text, and so are the problems found in the input when it is refused on the
+// "Terminate" action. Forced renders (like when the input is done) end the
+// current line.
+func (p *prompt) renderViewAccessible(output *termenv.Output, forced bool) {
+	input := p.buffer.String()
+	prefix := ""
+	if p.prefixer != nil {
+		prefix = text.StripEscape(p.prefixer())
+	}
+	suggestions, suggestionsIdx := p.getSuggestionsAndIdx()
+	restate := func(out *strings.Builder) {
+		out.WriteString("\n" + prefix + input)
+	}
+
+	out := strings.Builder{}
+	if !p.accessiblePrompted {
+		out.WriteString(prefix + input)
+		p.accessiblePrompted = true
+	} else if input != p.accessibleEchoed {
+		removed := strings.TrimPrefix(p.accessibleEchoed, input)
+		if strings.HasPrefix(input, p.accessibleEchoed) {
+			out.WriteString(input[len(p.accessibleEchoed):])
+		} else if strings.HasPrefix(p.accessibleEchoed, input) && !strings.Contains(removed, "\n") {
+			out.WriteString(strings.Repeat("\b \b", utf8.RuneCountInString(removed)))
+		} else {
+			restate(&out)
+		}
+	}
+	p.accessibleEchoed = input
+
+	// announce the problems that stopped the input from being submitted
+	if len(p.accessibleDiagnostics) > 0 {
+		for _, diagnostic := range p.accessibleDiagnostics {
+			out.WriteString("\n" + accessibleDiagnostic(diagnostic))
+		}
+		restate(&out)
+		p.accessibleDiagnostics = nil
+	}
+
+	// announce the suggestions, or the chosen one if only that changed
+	if !forced && len(suggestions) > 0 {
+		if !suggestionsEqual(suggestions, p.accessibleSuggestions) {
+			out.WriteString(fmt.Sprintf("\n%d suggestion(s):", len(suggestions)))
+			for idx, suggestion := range suggestions {
+				if idx == p.style.AutoComplete.NumItems {
+					break
+				}
+				out.WriteString("\n" + accessibleSuggestion(suggestion, idx == suggestionsIdx))
+			}
+			restate(&out)
+		} else if suggestionsIdx != p.accessibleSuggestionsIdx {
+			out.WriteString("\n" + accessibleSuggestion(suggestions[suggestionsIdx], true))
+			restate(&out)
+		}
+	}
+	p.accessibleSuggestions, p.accessibleSuggestionsIdx = suggestions, suggestionsIdx
+
+	if forced {
+		out.WriteString("\n")
+		p.resetAccessibleState()
+	}
+	_, _ = output.WriteString(out.String())
+}
+
+// resetAccessibleState forgets everything echoed so far, so that the next
+// render starts afresh with the prompt prefix.
+func (p *prompt) resetAccessibleState() {
+	p.accessibleDiagnostics = nil
+	p.accessibleEchoed = ""
+	p.accessiblePrompted = false
+	p.accessibleSuggestions = nil
+	p.accessibleSuggestionsIdx = 0
+}
+
+func accessibleDiagnostic(diagnostic Diagnostic) string {
+	severity := "error"
+	if diagnostic.Severity == DiagnosticSeverityWarning {
+		severity = "warning"
+	}
+	return fmt.Sprintf("%s: %d:%d: %s", severity,
+		diagnostic.Start.Line+1, diagnostic.Start.Column+1, diagnostic.Message)
+}
+
+func accessibleSuggestion(suggestion Suggestion, selected bool) string {
+	rsp := "  " + suggestion.Value
+	if selected {
+		rsp = "> " + suggestion.Value
+	}
+	if suggestion.Hint != "" {
+		rsp += " - " + suggestion.Hint
+	}
+	return rsp
+}
+
+func suggestionsEqual(a []Suggestion, b []Suggestion) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for idx := range a {
+		if a[idx] != b[idx] {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,108 @@
+package prompt
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/muesli/termenv"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPrompt_isAccessible(t *testing.T) {
+	t.Setenv(EnvVarAccessible, "")
+	p := prompt{}
+	assert.False(t, p.isAccessible())
+
+	p.SetAccessibleMode(true)
+	assert.True(t, p.isAccessible())
+	p.SetAccessibleMode(false)
+	assert.False(t, p.isAccessible())
+
+	for _, value := range []string{"0", "false", "FALSE"} {
+		t.Setenv(EnvVarAccessible, value)
+		assert.False(t, p.isAccessible(), value)
+	}
+	for _, value := range []string{"1", "true", "yes"} {
+		t.Setenv(EnvVarAccessible, value)
+		assert.True(t, p.isAccessible(), value)
+	}
+}
+
+func TestPrompt_renderViewAccessible(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second*5)
+	defer cancel()
+
+	p := generateTestPrompt(t, ctx)
+	p.SetAccessibleMode(true)
+	out := strings.Builder{}
+	output := termenv.NewOutput(&out)
+	render := func() string {
+		out.Reset()
+		p.renderView(output, "test")
+		return out.String()
+	}
+
+	assert.Equal(t, "[TestPrompt_renderViewAccessible] ", render())
+	assert.Equal(t, "", render())
+
+	p.buffer.InsertString("selcet")
+	assert.Equal(t, "selcet", render())
+	p.buffer.DeleteBackward(3)
+	assert.Equal(t, "\b \b\b \b\b \b", render())
+	p.buffer.InsertString("ect")
+	assert.Equal(t, "ect", render())
+	p.buffer.MoveToBeginningOfLine()
+	p.buffer.InsertString("  ")
+	assert.Equal(t, "\n[TestPrompt_renderViewAccessible]   select", render())
+
+	p.setSuggestions([]Suggestion{{Value: "select", Hint: "keyword"}, {Value: "selected"}})
+	assert.Equal(t, "\n2 suggestion(s):\n> select - keyword\n  selected"+
+		"\n[TestPrompt_renderViewAccessible]   select", render())
+	assert.Equal(t, "", render())
+	p.setSuggestionsIdx(1)
+	assert.Equal(t, "\n> selected\n[TestPrompt_renderViewAccessible]   select", render())
+
+	out.Reset()
+	p.renderView(output, "done", true)
+	assert.Equal(t, "\n", out.String())
+	assert.False(t, p.accessiblePrompted)
+	p.resetSuggestions()
+	assert.Equal(t, "[TestPrompt_renderViewAccessible]   select", render())
+}
+
+func TestPrompt_renderViewAccessible_Diagnostics(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second*5)
+	defer cancel()
+
+	p := generateTestPrompt(t, ctx)
+	p.SetAccessibleMode(true)
+	p.SetPrefix("> ")
+	p.SetValidator(func(input string) []Diagnostic {
+		return []Diagnostic{
+			{Start: CursorLocation{Column: 7}, Message: "missing column list", Severity: DiagnosticSeverityError},
+			{Message: "lower-case keyword", Severity: DiagnosticSeverityWarning},
+		}
+	})
+	p.keyMapReversed.Insert[Enter] = Terminate
+	out := strings.Builder{}
+	output := termenv.NewOutput(&out)
+	render := func() string {
+		out.Reset()
+		p.renderView(output, "test")
+		return out.String()
+	}
+
+	p.buffer.InsertString("select")
+	assert.Equal(t, "> select", render())
+
+	// refused input gets the problems announced once, without going back
+	err := p.handleKeyInsert(output, tea.KeyMsg{Type: tea.KeyEnter})
+	assert.Nil(t, err)
+	assert.False(t, p.buffer.IsDone())
+	assert.Equal(t, "\nerror: 1:8: missing column list\nwarning: 1:1: lower-case keyword"+
+		"\n> select", render())
+	assert.Equal(t, "", render())
+}
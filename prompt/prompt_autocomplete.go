@@ -9,7 +9,7 @@ import (
 	"github.com/jedib0t/go-pretty/v6/text"
 )
 
-func (p *prompt) autoComplete(lines []string, cursorPos CursorLocation, startIdx int) []string {
+func (p *prompt) autoComplete(lines []string, cursorPos CursorLocation, cursorRow int, mm *mouseMap) []string {
 	suggestions, suggestionsIdx := p.getSuggestionsAndIdx()
 	if len(suggestions) == 0 {
 		p.isInAutoComplete = false
@@ -18,7 +18,7 @@ func (p *prompt) autoComplete(lines []string, cursorPos CursorLocation, startIdx
 	p.isInAutoComplete = true
 
 	// get the line styling
-	linePrefix, prefixWidth, _, numLen, _, _ := p.calculateLineStyling(lines)
+	linePrefix, prefixWidth, _, numLen, _, _ := p.calculateLineStyling(lines, false)
 	word, _ := p.buffer.getWordAtCursor(p.style.AutoComplete.WordDelimiters)
 	wordLen := len(word)
 
@@ -26,10 +26,10 @@ func (p *prompt) autoComplete(lines []string, cursorPos CursorLocation, startIdx
 	suggestionsDropDown := printSuggestionsDropDown(suggestions, suggestionsIdx, p.style.AutoComplete)
 
 	// if the suggestions are going beyond the last line, pad the lines
-	numEmptyLinesToAppend := (len(suggestionsDropDown) + 1 + cursorPos.Line - startIdx) - len(lines)
+	numEmptyLinesToAppend := (len(suggestionsDropDown) + 1 + cursorRow) - len(lines)
 	if numEmptyLinesToAppend > 0 {
 		prefix := linePrefix
-		if p.style.LineNumbers.Enabled {
+		if p.getLineNumbersStyle().Enabled {
 			prefix += strings.Repeat("", numLen)
 		}
 		if prefix != "" {
@@ -37,17 +37,24 @@ func (p *prompt) autoComplete(lines []string, cursorPos CursorLocation, startIdx
 		}
 		for numEmptyLinesToAppend > 0 {
 			lines = append(lines, prefix)
+			mm.rows = append(mm.rows, mouseMapRow{Line: -1})
 			numEmptyLinesToAppend--
 		}
 	}
 
 	displayWidth := p.getDisplayWidth()
+	insertIdx := prefixWidth + cursorPos.Column - p.horizontalScrollOffset - wordLen - 1
+	if cursorRow < len(mm.rows) && mm.rows[cursorRow].Line == cursorPos.Line { // relative to the (wrapped) row
+		insertIdx = prefixWidth + mm.rows[cursorRow].Indent + cursorPos.Column - mm.rows[cursorRow].Column - wordLen - 1
+	}
 	for idx, suggestion := range suggestionsDropDown {
-		lineIdx := idx + cursorPos.Line + 1 - startIdx
+		lineIdx := idx + cursorRow + 1
 		lines[lineIdx] = overwriteContents(
-			lines[lineIdx], suggestion, prefixWidth+cursorPos.Column-wordLen-1, displayWidth,
+			lines[lineIdx], suggestion, insertIdx, displayWidth,
 		)
 	}
+	mm.setDropDown(suggestionsDropDown, len(suggestions), suggestionsIdx, p.style.AutoComplete.NumItems,
+		cursorRow+1, insertIdx, displayWidth)
 	return lines
 }
 
@@ -0,0 +1,70 @@
+package prompt
+
+import (
+	"unicode"
+)
+
+// deleteCharPrevious deletes the character before the cursor, along with the
+// closing bracket/quote right after the cursor if they form an empty pair and
+// auto-pairing is enabled.
+func (p *prompt) deleteCharPrevious() {
+	if p.style.Brackets.AutoPair {
+		prev, next := p.buffer.RunesAroundCursor()
+		if closing, ok := p.style.Brackets.closingRune(prev); ok && next == closing {
+			p.buffer.DeleteForward(1)
+		}
+	}
+	p.buffer.DeleteBackward(1)
+}
+
+// getBracketsToHighlight returns the locations of the bracket at (or right
+// before) the cursor and the one matching it, if highlighting is enabled.
+func (p *prompt) getBracketsToHighlight(lines []string, cursor CursorLocation) []CursorLocation {
+	if !p.style.Brackets.Highlight {
+		return nil
+	}
+	bracket, match, ok := p.style.Brackets.findMatchingBracket(lines, cursor)
+	if !ok {
+		return nil
+	}
+	return []CursorLocation{bracket, match}
+}
+
+// insertRune inserts the given rune at the cursor. If auto-pairing is enabled,
+// typing a closing bracket/quote right before the same one moves over it, and
+// typing an opening one inserts the closing one too, when the cursor is not in
+// the middle of a word.
+func (p *prompt) insertRune(r rune) {
+	brackets := p.style.Brackets
+	if !brackets.AutoPair {
+		p.buffer.Insert(r)
+		return
+	}
+
+	prev, next := p.buffer.RunesAroundCursor()
+	if next == r && brackets.isClosingRune(r) {
+		p.buffer.MoveRight(1)
+		return
+	}
+
+	p.buffer.Insert(r)
+	closing, ok := brackets.closingRune(r)
+	if !ok {
+		return
+	}
+	isNextFree := next == 0 || unicode.IsSpace(next) || brackets.isClosingRune(next)
+	isQuoteAfterWord := closing == r && (unicode.IsLetter(prev) || unicode.IsDigit(prev) || prev == '_')
+	if isNextFree && !isQuoteAfterWord {
+		p.buffer.Insert(closing)
+		p.buffer.MoveLeft(1)
+	}
+}
+
+// jumpToMatchingBracket moves the cursor to the bracket matching the one at
+// (or right before) the cursor.
+func (p *prompt) jumpToMatchingBracket() {
+	lines, cursor := p.buffer.Lines(), p.buffer.Cursor()
+	if _, match, ok := p.style.Brackets.findMatchingBracket(lines, cursor); ok {
+		p.buffer.MoveTo(match)
+	}
+}
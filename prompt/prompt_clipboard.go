@@ -0,0 +1,30 @@
+package prompt
+
+import (
+	"os"
+	"strings"
+
+	"github.com/aymanbagabas/go-osc52/v2"
+	"github.com/muesli/termenv"
+)
+
+var (
+	// pastedTextNormalizer converts the line endings in pasted text (terminals
+	// usually send "\r" for every new line) to the ones used by the buffer.
+	pastedTextNormalizer = strings.NewReplacer("\r\n", "\n", "\r", "\n")
+)
+
+// copyToClipboard stores the text in the internal clipboard (used by Paste),
+// and pushes it to the system clipboard using the OSC 52 escape sequence
+// supported by most terminal emulators.
+func (p *prompt) copyToClipboard(output *termenv.Output, text string) {
+	p.clipboard = text
+
+	seq := osc52.New(text)
+	if os.Getenv("TMUX") != "" {
+		seq = seq.Tmux()
+	} else if strings.HasPrefix(os.Getenv("TERM"), "screen") {
+		seq = seq.Screen()
+	}
+	_, _ = seq.WriteTo(output)
+}
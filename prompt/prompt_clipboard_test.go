@@ -0,0 +1,104 @@
+package prompt
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/muesli/termenv"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPrompt_copyToClipboard(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second*5)
+	defer cancel()
+
+	p := generateTestPrompt(t, ctx)
+
+	t.Setenv("TMUX", "")
+	t.Setenv("TERM", "xterm")
+	output := strings.Builder{}
+	p.copyToClipboard(termenv.NewOutput(&output), "foo")
+	assert.Equal(t, "foo", p.clipboard)
+	assert.Equal(t, "\x1b]52;c;Zm9v\x07", output.String())
+
+	t.Setenv("TERM", "screen-256color")
+	output.Reset()
+	p.copyToClipboard(termenv.NewOutput(&output), "bar")
+	assert.Equal(t, "bar", p.clipboard)
+	assert.Equal(t, "\x1bP\x1b]52;c;YmFy\x07\x1b\\", output.String())
+
+	t.Setenv("TMUX", "/tmp/tmux-1000/default,1,0")
+	output.Reset()
+	p.copyToClipboard(termenv.NewOutput(&output), "baz")
+	assert.Equal(t, "baz", p.clipboard)
+	assert.Equal(t, "\x1bPtmux;\x1b\x1b]52;c;YmF6\x07\x1b\\", output.String())
+}
+
+func TestPrompt_handleKeyInsertClipboard(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second*5)
+	defer cancel()
+
+	t.Setenv("TMUX", "")
+	t.Setenv("TERM", "xterm")
+	testText := "test this thing\nnot this thing"
+
+	t.Run("Copy everything", func(t *testing.T) {
+		p := generateTestPromptWithBuffer(t, ctx, testText, CursorLocation{0, 5})
+		p.keyMapReversed.Insert[Enter] = Copy
+
+		output := strings.Builder{}
+		err := p.handleKeyInsert(termenv.NewOutput(&output), tea.KeyMsg{Type: tea.KeyEnter})
+		assert.Nil(t, err)
+		assert.Equal(t, testText, p.clipboard)
+		assert.Equal(t, testText, p.buffer.String())
+		assert.Contains(t, output.String(), "\x1b]52;c;")
+	})
+
+	t.Run("Cut everything", func(t *testing.T) {
+		p := generateTestPromptWithBuffer(t, ctx, testText, CursorLocation{0, 5})
+		p.keyMapReversed.Insert[Enter] = Cut
+
+		output := strings.Builder{}
+		err := p.handleKeyInsert(termenv.NewOutput(&output), tea.KeyMsg{Type: tea.KeyEnter})
+		assert.Nil(t, err)
+		assert.Equal(t, testText, p.clipboard)
+		assert.Equal(t, "", p.buffer.String())
+		assert.Contains(t, output.String(), "\x1b]52;c;")
+	})
+
+	t.Run("Copy selection", func(t *testing.T) {
+		p := generateTestPromptWithBuffer(t, ctx, testText, CursorLocation{0, 5})
+		p.keyMapReversed.Insert[Enter] = Copy
+		p.buffer.StartSelection()
+		p.buffer.MoveToEndOfLine()
+
+		output := strings.Builder{}
+		err := p.handleKeyInsert(termenv.NewOutput(&output), tea.KeyMsg{Type: tea.KeyEnter})
+		assert.Nil(t, err)
+		assert.Equal(t, "this thing", p.clipboard)
+		assert.Equal(t, testText, p.buffer.String())
+		assert.Equal(t, "\x1b]52;c;dGhpcyB0aGluZw==\x07", output.String())
+	})
+
+	t.Run("Paste bracketed", func(t *testing.T) {
+		p := generateTestPromptWithBuffer(t, ctx, "", CursorLocation{0, 0})
+		p.SetTerminationChecker(TerminationCheckerNone())
+
+		output := strings.Builder{}
+		key := tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("select 1;\r\nselect (2);\rselect 3;\n"), Paste: true}
+		err := p.handleKeyInsert(termenv.NewOutput(&output), key)
+		assert.Nil(t, err)
+		assert.Equal(t, "select 1;\nselect (2);\nselect 3;\n", p.buffer.String())
+		assert.False(t, p.buffer.IsDone())
+
+		// pasting over a selection replaces it instead of surrounding it
+		p.buffer.StartSelection()
+		p.buffer.MoveToBeginning()
+		err = p.handleKeyInsert(termenv.NewOutput(&output), tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("("), Paste: true})
+		assert.Nil(t, err)
+		assert.Equal(t, "(", p.buffer.String())
+	})
+}
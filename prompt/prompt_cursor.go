@@ -0,0 +1,124 @@
+package prompt
+
+import (
+	"io"
+	"os"
+	"strings"
+
+	"github.com/jedib0t/go-pretty/v6/text"
+	"github.com/muesli/termenv"
+)
+
+// escSeqCursorMarker marks the location of the cursor in the generated lines
+// when using the terminal's cursor; being an (unused) escape sequence, it gets
+// carried around by all the logic that works on the visible characters alone.
+const escSeqCursorMarker = string(escSeqStart) + "[9999" + string(escSeqStop)
+
+// terminalCursorUnsupportedTerms contains the values of TERM for which the
+// terminal's cursor cannot be placed or shaped reliably.
+var terminalCursorUnsupportedTerms = map[string]bool{
+	"dumb":  true,
+	"linux": true,
+}
+
+// getCursorShape returns the shape of the terminal's cursor for the current
+// mode.
+func (p *prompt) getCursorShape() CursorShape {
+	if _, _, isSelected := p.buffer.Selection(); isSelected {
+		return p.style.Cursor.ShapeSelection
+	}
+	if p.isInAutoComplete {
+		return p.style.Cursor.ShapeAutoComplete
+	}
+	return p.style.Cursor.Shape
+}
+
+// moveTerminalCursorToBottom moves the terminal's cursor back to the beginning
+// of the line after the rendered prompt, where all rendering starts from.
+func (p *prompt) moveTerminalCursorToBottom(output *termenv.Output) {
+	if p.terminalCursorRowsUp > 0 {
+		output.CursorDown(p.terminalCursorRowsUp)
+		_, _ = output.WriteString("\r")
+		p.screenCursorColumn = 0
+		p.terminalCursorRowsUp = 0
+	}
+}
+
+// setTerminalCursorVisibility hides the terminal's cursor if the cursor is
+// painted on to the text, and shows it otherwise (like in the accessible mode,
+// where nothing is painted).
+func (p *prompt) setTerminalCursorVisibility(output *termenv.Output) {
+	if p.isAccessible() || p.useTerminalCursor() {
+		output.ShowCursor()
+	} else {
+		output.HideCursor()
+	}
+}
+
+// setTerminalCursorShape sets the shape of the terminal's cursor for the
+// current mode if it is not already in that shape.
+func (p *prompt) setTerminalCursorShape(w io.StringWriter) {
+	if p.terminalCursorShape != p.terminalCursorShapeRendered {
+		_, _ = w.WriteString(p.terminalCursorShape.sequence())
+		p.terminalCursorShapeRendered = p.terminalCursorShape
+	}
+}
+
+// resetTerminalCursorShape restores the terminal's default cursor shape if it
+// was changed.
+func (p *prompt) resetTerminalCursorShape(output *termenv.Output) {
+	p.linesMutex.Lock()
+	defer p.linesMutex.Unlock()
+
+	if p.terminalCursorShapeRendered != CursorShapeDefault {
+		_, _ = output.WriteString(CursorShapeDefault.sequence())
+		p.terminalCursorShapeRendered = CursorShapeDefault
+	}
+}
+
+// useTerminalCursor returns true if the terminal's cursor is to be used
+// instead of painting one, which is not possible in the accessible mode or on
+// terminals known to not support it.
+func (p *prompt) useTerminalCursor() bool {
+	return p.style.Cursor.Enabled && p.style.Cursor.Terminal && !p.isAccessible() &&
+		!terminalCursorUnsupportedTerms[os.Getenv("TERM")]
+}
+
+// extractCursorMarker removes the cursor marker(s) from the given lines and
+// returns the location of the (first) marker in terms of the row and the
+// visible column.
+func extractCursorMarker(lines []string) ([]string, *CursorLocation) {
+	var location *CursorLocation
+	for idx, line := range lines {
+		markerIdx := strings.Index(line, escSeqCursorMarker)
+		if markerIdx < 0 {
+			continue
+		}
+		if location == nil {
+			location = &CursorLocation{
+				Line:   idx,
+				Column: text.RuneWidthWithoutEscSequences(line[:markerIdx]),
+			}
+		}
+		lines[idx] = strings.ReplaceAll(line, escSeqCursorMarker, "")
+	}
+	return lines, location
+}
+
+// insertCursorMarker inserts the cursor marker before the visible character at
+// the given index, or after the end of the input along with a space to make
+// room for the cursor.
+func insertCursorMarker(input string, insertIdx int) string {
+	visibleCharIdx, inEscSeq := 0, false
+	for idx, r := range input {
+		if r == escSeqStart || inEscSeq {
+			inEscSeq = r != escSeqStop
+			continue
+		}
+		if visibleCharIdx == insertIdx {
+			return input[:idx] + escSeqCursorMarker + input[idx:]
+		}
+		visibleCharIdx++
+	}
+	return input + escSeqCursorMarker + " "
+}
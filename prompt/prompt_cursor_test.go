@@ -0,0 +1,114 @@
+package prompt
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/muesli/termenv"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCursorShape_MarshalText(t *testing.T) {
+	data, err := json.Marshal(map[string]CursorShape{"a": CursorShapeDefault, "b": CursorShapeSteadyBar})
+	assert.Nil(t, err)
+	assert.Equal(t, `{"a":"default","b":"steady-bar"}`, string(data))
+
+	_, err = json.Marshal(CursorShape(7))
+	assert.NotNil(t, err)
+	assert.True(t, errors.Is(err, ErrInvalidCursorShape))
+}
+
+func TestCursorShape_UnmarshalText(t *testing.T) {
+	var cs CursorShape
+	assert.Nil(t, json.Unmarshal([]byte(`"blinking-underline"`), &cs))
+	assert.Equal(t, CursorShapeBlinkingUnderline, cs)
+
+	err := json.Unmarshal([]byte(`"round"`), &cs)
+	assert.NotNil(t, err)
+	assert.True(t, errors.Is(err, ErrInvalidCursorShape))
+	assert.Equal(t, CursorShapeBlinkingUnderline, cs)
+}
+
+func TestCursorShape_sequence(t *testing.T) {
+	assert.Equal(t, "\x1b[0 q", CursorShapeDefault.sequence())
+	assert.Equal(t, "\x1b[5 q", CursorShapeBlinkingBar.sequence())
+}
+
+func TestPrompt_terminalCursor(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second*5)
+	defer cancel()
+	t.Setenv("TERM", "xterm-256color")
+	t.Setenv(EnvVarAccessible, "")
+
+	p := generateTestPrompt(t, ctx)
+	p.SetTerminationChecker(TerminationCheckerSQL()) // enable multi-line
+	p.Style().Cursor = StyleCursorTerminal
+	assert.True(t, p.useTerminalCursor())
+
+	t.Run("placement and shape", func(t *testing.T) {
+		output := strings.Builder{}
+		p.buffer.InsertString("foo\nbar baz")
+		p.buffer.MoveLeft(4)
+		p.updateModel(true)
+		assert.Equal(t, &CursorLocation{Line: 1, Column: 31}, p.terminalCursor)
+		assert.Equal(t, CursorShapeBlinkingBar, p.terminalCursorShape)
+		compareLines(t, []string{
+			"[TestPrompt_terminalCursor] foo",
+			"[TestPrompt_terminalCursor] bar baz",
+		}, p.linesToRender)
+
+		p.renderView(termenv.NewOutput(&output), "test")
+		assert.True(t, strings.HasSuffix(output.String(), "bar baz\n\x1b[1A\x1b[32G\x1b[5 q\x1b[?2026l"))
+		assert.Equal(t, 1, p.terminalCursorRowsUp)
+
+		// selecting text changes the shape, and rendering starts from where the
+		// cursor was left, which is right where the selected cell is
+		output.Reset()
+		p.buffer.StartSelection()
+		p.buffer.MoveRight(1)
+		p.updateModel(true)
+		p.renderView(termenv.NewOutput(&output), "test")
+		assert.Equal(t, "\x1b[?2026h\x1b[38;5;231;48;5;24m \x1b[0m\x1b[2 q\x1b[?2026l", output.String())
+
+		// the cursor is back at the bottom once done, with the default shape
+		output.Reset()
+		p.updateModel(false, true)
+		p.renderView(termenv.NewOutput(&output), "done", true)
+		p.resetTerminalCursorShape(termenv.NewOutput(&output))
+		assert.Nil(t, p.terminalCursor)
+		assert.Equal(t, "\x1b[?2026h\x1b[32G \x1b[1B\r\x1b[?2026l\x1b[0 q", output.String())
+		assert.Equal(t, 0, p.terminalCursorRowsUp)
+	})
+
+	t.Run("unsupported", func(t *testing.T) {
+		t.Setenv("TERM", "dumb")
+		assert.False(t, p.useTerminalCursor())
+
+		p.buffer.Reset()
+		p.updateModel(true)
+		assert.Nil(t, p.terminalCursor)
+		compareLines(t, []string{
+			"[TestPrompt_terminalCursor] \x1b[38;5;232;48;5;6m \x1b[0m",
+		}, p.linesToRender)
+	})
+}
+
+func Test_extractCursorMarker(t *testing.T) {
+	lines, location := extractCursorMarker([]string{"foo", "\x1b[1mb" + escSeqCursorMarker + "ar\x1b[0m", escSeqCursorMarker})
+	assert.Equal(t, []string{"foo", "\x1b[1mbar\x1b[0m", ""}, lines)
+	assert.Equal(t, &CursorLocation{Line: 1, Column: 1}, location)
+
+	lines, location = extractCursorMarker([]string{"foo"})
+	assert.Equal(t, []string{"foo"}, lines)
+	assert.Nil(t, location)
+}
+
+func Test_insertCursorMarker(t *testing.T) {
+	assert.Equal(t, escSeqCursorMarker+"foo", insertCursorMarker("foo", 0))
+	assert.Equal(t, "\x1b[1mf\x1b[0m"+escSeqCursorMarker+"oo", insertCursorMarker("\x1b[1mf\x1b[0moo", 1))
+	assert.Equal(t, "foo"+escSeqCursorMarker+" ", insertCursorMarker("foo", 3))
+}
@@ -0,0 +1,109 @@
+package prompt
+
+import (
+	"fmt"
+	"os"
+	"os/exec"
+	"strings"
+
+	"github.com/muesli/termenv"
+)
+
+const (
+	// DefaultExternalEditor is the editor used to edit the prompt text when
+	// neither $VISUAL nor $EDITOR are set.
+	DefaultExternalEditor = "vi"
+)
+
+func (p *prompt) handleExternalEditor(output *termenv.Output) {
+	p.pauseRender()
+	defer p.resumeRender()
+
+	text, err := p.runExternalEditor(p.buffer.String())
+	if err != nil {
+		p.updateModel(false)
+		p.renderView(output, "editor.error", true)
+		_, _ = output.WriteString(convertEscSeqs(p.style.Colors.Error.Sprintf("ERROR: failed to edit in external editor: %v.\n", err), p.activeColorProfile))
+		_, _ = output.WriteString("\n")
+		p.linesRendered = make([]string, 0)
+		return
+	}
+	p.buffer.Set(text)
+
+	// the editor may have left behind stuff on the screen; force a full repaint
+	// of the prompt in the same place
+	p.linesMutex.Lock()
+	if p.isFullScreen() { // the editor may have left the alternate screen too
+		p.enterAltScreen(output)
+	}
+	p.setTerminalCursorVisibility(output)
+	for idx := range p.linesRendered {
+		p.linesRendered[idx] = ""
+	}
+	p.linesMutex.Unlock()
+}
+
+func (p *prompt) runExternalEditor(text string) (string, error) {
+	editor := getExternalEditor()
+
+	// write the text into a temporary file for the editor to work on
+	file, err := os.CreateTemp("", "prompt-*"+p.editorFileExtension)
+	if err != nil {
+		return "", err
+	}
+	defer os.Remove(file.Name())
+	if _, err = file.WriteString(text + "\n"); err != nil {
+		_ = file.Close()
+		return "", err
+	}
+	if err = file.Close(); err != nil {
+		return "", err
+	}
+
+	// hand over the terminal to the editor, and take it back when it is done
+	if err = p.reader.ReleaseTerminal(); err != nil {
+		return "", err
+	}
+	cmd := exec.Command(editor[0], append(editor[1:], file.Name())...)
+	cmd.Stdin, cmd.Stdout, cmd.Stderr = p.getEditorStreams()
+	errRun := cmd.Run()
+	if err = p.reader.RestoreTerminal(); err != nil {
+		return "", err
+	}
+	if errRun != nil {
+		return "", fmt.Errorf("%s: %w", strings.Join(editor, " "), errRun)
+	}
+
+	// read back the edited text
+	data, err := os.ReadFile(file.Name())
+	if err != nil {
+		return "", err
+	}
+	return strings.TrimSuffix(strings.TrimSuffix(string(data), "\n"), "\r"), nil
+}
+
+// getEditorStreams returns the terminal to hand over to the external editor,
+// which is the input and the output of the prompt if they are files (like a
+// terminal other than the one the program is running in), and the standard
+// streams otherwise.
+func (p *prompt) getEditorStreams() (stdin *os.File, stdout *os.File, stderr *os.File) {
+	stdin, stdout, stderr = os.Stdin, os.Stdout, os.Stderr
+	if f, ok := p.getInputReader().(*os.File); ok {
+		stdin = f
+	}
+	if f, ok := p.getOutputWriter().(*os.File); ok && f != os.Stdout {
+		stdout, stderr = f, f
+	}
+	return stdin, stdout, stderr
+}
+
+// getExternalEditor returns the editor command (and arguments if any) set up
+// through the $VISUAL or $EDITOR environment variables.
+func getExternalEditor() []string {
+	for _, envVar := range []string{"VISUAL", "EDITOR"} {
+		if editor := strings.Fields(os.Getenv(envVar)); len(editor) > 0 {
+			return editor
+		}
+	}
+	return []string{DefaultExternalEditor}
+}
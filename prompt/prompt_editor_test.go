@@ -0,0 +1,85 @@
+package prompt
+
+import (
+	"context"
+	"os"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/muesli/termenv"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPrompt_handleExternalEditor(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second*5)
+	defer cancel()
+
+	t.Run("success", func(t *testing.T) {
+		t.Setenv("VISUAL", "sed -i -e s/foo/bar/")
+		p := generateTestPromptWithBuffer(t, ctx, "select foo\nfrom baz;", CursorLocation{Line: 0, Column: 0})
+		p.SetExternalEditorFileExtension("sql")
+		assert.Equal(t, ".sql", p.editorFileExtension)
+		p.linesRendered = []string{"line 1", "line 2"}
+
+		output := strings.Builder{}
+		p.handleExternalEditor(termenv.NewOutput(&output))
+		assert.Equal(t, "select bar\nfrom baz;", p.buffer.String())
+		assert.Equal(t, CursorLocation{Line: 1, Column: 9}, p.buffer.Cursor())
+		assert.Equal(t, []string{"", ""}, p.linesRendered)
+		assert.Equal(t, "\x1b[?25l", output.String()) // the painted cursor is back
+		assert.False(t, p.isRenderPaused())
+	})
+
+	t.Run("failure", func(t *testing.T) {
+		t.Setenv("VISUAL", "")
+		t.Setenv("EDITOR", "false")
+		p := generateTestPromptWithBuffer(t, ctx, "select foo;", CursorLocation{Line: 0, Column: 3})
+
+		output := strings.Builder{}
+		p.handleExternalEditor(termenv.NewOutput(&output))
+		assert.Equal(t, "select foo;", p.buffer.String())
+		assert.Equal(t, CursorLocation{Line: 0, Column: 3}, p.buffer.Cursor())
+		assert.Contains(t, output.String(), "ERROR: failed to edit in external editor: false: exit status 1.")
+		assert.Empty(t, p.linesRendered)
+		assert.False(t, p.isRenderPaused())
+	})
+}
+
+func TestPrompt_getEditorStreams(t *testing.T) {
+	p := prompt{}
+	stdin, stdout, stderr := p.getEditorStreams()
+	assert.Equal(t, os.Stdin, stdin)
+	assert.Equal(t, os.Stdout, stdout)
+	assert.Equal(t, os.Stderr, stderr)
+
+	p.SetInput(strings.NewReader("foo"))
+	p.SetOutput(&strings.Builder{})
+	stdin, stdout, stderr = p.getEditorStreams()
+	assert.Equal(t, os.Stdin, stdin)
+	assert.Equal(t, os.Stdout, stdout)
+	assert.Equal(t, os.Stderr, stderr)
+
+	inputReader, outputWriter, err := os.Pipe()
+	assert.Nil(t, err)
+	defer inputReader.Close()
+	defer outputWriter.Close()
+	p.SetInput(inputReader)
+	p.SetOutput(outputWriter)
+	stdin, stdout, stderr = p.getEditorStreams()
+	assert.Equal(t, inputReader, stdin)
+	assert.Equal(t, outputWriter, stdout)
+	assert.Equal(t, outputWriter, stderr)
+}
+
+func TestGetExternalEditor(t *testing.T) {
+	t.Setenv("VISUAL", "")
+	t.Setenv("EDITOR", "")
+	assert.Equal(t, []string{DefaultExternalEditor}, getExternalEditor())
+
+	t.Setenv("EDITOR", "nano")
+	assert.Equal(t, []string{"nano"}, getExternalEditor())
+
+	t.Setenv("VISUAL", "code --wait")
+	assert.Equal(t, []string{"code", "--wait"}, getExternalEditor())
+}
@@ -0,0 +1,84 @@
+package prompt
+
+import (
+	"fmt"
+
+	"github.com/jedib0t/go-pretty/v6/text"
+	"github.com/muesli/termenv"
+)
+
+// enterFullScreen switches to the alternate screen buffer to render the prompt
+// on, and remembers the input it started with to tell if it got modified.
+func (p *prompt) enterFullScreen(output *termenv.Output) {
+	p.linesMutex.Lock()
+	defer p.linesMutex.Unlock()
+
+	p.fullScreenInput = p.buffer.String()
+	p.enterAltScreen(output)
+}
+
+// enterAltScreen switches to (and clears) the alternate screen buffer, and
+// forces a full repaint of the prompt on it.
+func (p *prompt) enterAltScreen(output *termenv.Output) {
+	output.AltScreen()
+	output.ClearScreen()
+	if !p.useTerminalCursor() { // the cursor is painted on to the text
+		output.HideCursor()
+	}
+	p.linesRendered = make([]string, 0)
+	p.screenCursorColumn = 0
+	p.terminalCursorRowsUp = 0
+}
+
+// exitFullScreen returns to the normal screen, and prints whatever was printed
+// while on the alternate screen so that the final render of the prompt can be
+// left behind below it.
+func (p *prompt) exitFullScreen(output *termenv.Output) {
+	p.linesMutex.Lock()
+	defer p.linesMutex.Unlock()
+
+	if !p.useTerminalCursor() {
+		output.ShowCursor()
+	}
+	output.ExitAltScreen()
+	p.setFullScreen(false)
+	p.linesRendered = make([]string, 0)
+	p.screenCursorColumn = 0
+	p.terminalCursorRowsUp = 0
+
+	for _, str := range p.fullScreenPrinted {
+		_, _ = output.WriteString(str)
+	}
+	p.fullScreenPrinted = nil
+}
+
+// generateStatusLine returns the status line rendered below the input in the
+// full-screen mode, with the location of the cursor and whether the input was
+// modified.
+func (p *prompt) generateStatusLine() string {
+	cursor := p.buffer.Cursor()
+	left := fmt.Sprintf(" Ln %d/%d, Col %d", cursor.Line+1, p.buffer.NumLines(), cursor.Column+1)
+	right := ""
+	if p.buffer.String() != p.fullScreenInput {
+		right = "[Modified] "
+	}
+
+	width := p.getDisplayWidth()
+	leftWidth := width - text.RuneWidthWithoutEscSequences(right)
+	if leftWidth < 0 {
+		leftWidth = 0
+	}
+	left = text.Pad(text.Trim(left, leftWidth), leftWidth, ' ')
+	return p.style.Colors.StatusLine.Sprint(text.Trim(left+right, width))
+}
+
+// repaintFullScreen clears the screen and forces a full repaint of the prompt
+// on the next render, like when the terminal got resized.
+func (p *prompt) repaintFullScreen(output *termenv.Output) {
+	p.linesMutex.Lock()
+	defer p.linesMutex.Unlock()
+
+	output.ClearScreen()
+	p.linesRendered = make([]string, len(p.linesRendered))
+	p.screenCursorColumn = -1 // the cursor may have been moved too
+}
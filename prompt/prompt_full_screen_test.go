@@ -0,0 +1,206 @@
+package prompt
+
+import (
+	"context"
+	"regexp"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/jedib0t/go-pretty/v6/text"
+	"github.com/muesli/termenv"
+	"github.com/stretchr/testify/assert"
+)
+
+var reCursorMove = regexp.MustCompile(`\x1b\[\d+;\d+H`)
+
+func generateTestPromptFullScreen(t *testing.T, ctx context.Context) *prompt {
+	p := generateTestPrompt(t, ctx)
+	p.SetPrefixer(PrefixNone())
+	p.SetTerminationChecker(TerminationCheckerSQL()) // enable multi-line
+	p.setFullScreen(true)
+	p.updateDisplayHeight(8)
+	return p
+}
+
+func TestPrompt_enterFullScreen(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second*5)
+	defer cancel()
+
+	p := generateTestPromptFullScreen(t, ctx)
+	p.buffer.InsertString("foo")
+	p.linesRendered = []string{"foo"}
+	p.Print("printed while editing")
+
+	output := strings.Builder{}
+	p.enterFullScreen(termenv.NewOutput(&output))
+	assert.Equal(t, "\x1b[?1049h\x1b[2J\x1b[1;1H\x1b[?25l", output.String())
+	assert.Empty(t, p.linesRendered)
+	assert.Equal(t, "foo", p.fullScreenInput)
+
+	output.Reset()
+	p.linesRendered = []string{"foo"}
+	p.exitFullScreen(termenv.NewOutput(&output))
+	assert.Equal(t, "\x1b[?25h\x1b[?1049l", output.String())
+	assert.Empty(t, p.linesRendered)
+	assert.False(t, p.isFullScreen())
+
+	t.Run("print", func(t *testing.T) {
+		p.markActive()
+		defer p.markInactive()
+		p.setFullScreen(true)
+
+		output.Reset()
+		p.Print("printed while editing")
+		assert.Equal(t, []string{"printed while editing\n"}, p.fullScreenPrinted)
+		p.exitFullScreen(termenv.NewOutput(&output))
+		assert.Equal(t, "\x1b[?25h\x1b[?1049lprinted while editing\n", output.String())
+		assert.Empty(t, p.fullScreenPrinted)
+	})
+
+	t.Run("terminal cursor", func(t *testing.T) {
+		p.Style().Cursor = StyleCursorTerminal
+
+		output.Reset()
+		p.enterFullScreen(termenv.NewOutput(&output))
+		assert.Equal(t, "\x1b[?1049h\x1b[2J\x1b[1;1H", output.String())
+
+		output.Reset()
+		p.exitFullScreen(termenv.NewOutput(&output))
+		assert.Equal(t, "\x1b[?1049l", output.String())
+	})
+}
+
+func TestPrompt_generateStatusLine(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second*5)
+	defer cancel()
+
+	p := generateTestPromptFullScreen(t, ctx)
+	p.Style().Colors.StatusLine = Color{}
+	p.setDisplayWidth(30)
+
+	statusLine := p.generateStatusLine()
+	assert.Equal(t, " Ln 1/1, Col 1                ", statusLine)
+
+	p.buffer.InsertString("foo\nbar baz")
+	statusLine = p.generateStatusLine()
+	assert.Equal(t, " Ln 2/2, Col 8     [Modified] ", statusLine)
+
+	p.fullScreenInput = "foo\nbar baz"
+	statusLine = p.generateStatusLine()
+	assert.Equal(t, " Ln 2/2, Col 8                ", statusLine)
+
+	p.buffer.InsertString(" and then some")
+	p.setDisplayWidth(12)
+	statusLine = p.generateStatusLine()
+	assert.Equal(t, " [Modified] ", statusLine)
+}
+
+func TestPrompt_getLineNumbersStyle(t *testing.T) {
+	p := prompt{}
+	p.SetStyle(StyleDefault)
+	assert.Equal(t, StyleLineNumbersNone, p.getLineNumbersStyle())
+
+	p.setFullScreen(true)
+	assert.Equal(t, StyleLineNumbersEnabled, p.getLineNumbersStyle())
+
+	p.Style().LineNumbers = StyleLineNumbersEnabled
+	p.Style().LineNumbers.ZeroPrefixed = true
+	assert.Equal(t, p.Style().LineNumbers, p.getLineNumbersStyle())
+}
+
+func TestPrompt_renderViewFullScreen(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second*5)
+	defer cancel()
+
+	t.Setenv("TERM", "xterm-256color")
+
+	p := generateTestPromptFullScreen(t, ctx)
+	p.SetHeader("header")
+	p.SetFooter("footer")
+	p.updateHeaderAndFooter()
+	p.Style().Colors.StatusLine = Color{}
+
+	testSubtitle := "The first render"
+	output := strings.Builder{}
+	p.buffer.InsertString("foo")
+	p.updateModel(true)
+	p.renderView(termenv.NewOutput(&output), "test")
+	expectedLines := []string{
+		"\x1b[?2026h\x1b[2Kheader",
+		"\x1b[2;1H\x1b[2K\x1b[38;5;239;48;5;235m 1 \x1b[0m foo\x1b[38;5;232;48;5;6m ",
+		"\x1b[3;1H\x1b[0m\x1b[2K\x1b[38;5;239;48;5;235m   ",
+		"\x1b[4;1H\x1b[0m\x1b[2K\x1b[38;5;239;48;5;235m   ",
+		"\x1b[5;1H\x1b[0m\x1b[2K\x1b[38;5;239;48;5;235m   ",
+		"\x1b[6;1H\x1b[0m\x1b[2K\x1b[38;5;239;48;5;235m   ",
+		"\x1b[7;1H\x1b[0m\x1b[2K\x1b[2GLn 1/1, Col 4\x1b[110G[Modified]",
+		"\x1b[8;1H\x1b[2Kfooter\x1b[?2026l",
+	}
+	compareLines(t, expectedLines, splitBeforeMoves(output.String()), testSubtitle)
+
+	testSubtitle = "Only the changed cells get rendered"
+	output.Reset()
+	p.buffer.InsertString("\nbar")
+	p.updateModel(true)
+	p.renderView(termenv.NewOutput(&output), "test")
+	expectedLines = []string{
+		"\x1b[?2026h",
+		"\x1b[2;8H\x1b[0K",
+		"\x1b[3;2H\x1b[38;5;239;48;5;235m2 \x1b[0m bar\x1b[38;5;232;48;5;6m ",
+		"\x1b[7;5H\x1b[0m2/2\x1b[?2026l",
+	}
+	compareLines(t, expectedLines, splitBeforeMoves(output.String()), testSubtitle)
+
+	testSubtitle = "The input scrolls within the rows left"
+	output.Reset()
+	p.buffer.InsertString("\n3\n4\n5\n6")
+	p.updateModel(true)
+	p.renderView(termenv.NewOutput(&output), "test")
+	assert.Len(t, p.linesRendered, 8, testSubtitle)
+	assert.Equal(t, "header", p.linesRendered[0], testSubtitle)
+	assert.Contains(t, text.StripEscape(p.linesRendered[1]), " 2  bar", testSubtitle)
+	assert.Contains(t, text.StripEscape(p.linesRendered[5]), " 6  6", testSubtitle)
+	assert.Contains(t, p.linesRendered[6], " Ln 6/6, Col 2", testSubtitle)
+	assert.Equal(t, "footer", p.linesRendered[7], testSubtitle)
+
+	testSubtitle = "The terminal's cursor is placed where the cursor is"
+	output.Reset()
+	p.Style().Cursor = StyleCursorTerminal
+	p.Style().Scrollbar.Color = Color{}
+	p.updateModel(true)
+	p.renderView(termenv.NewOutput(&output), "test")
+	assert.Equal(t, "\x1b[?2026h\x1b[2;120H░\x1b[3;120H░\x1b[4;120H░\x1b[5;120H░\x1b[6;6H \x1b[120G█\x1b[6G"+
+		CursorShapeBlinkingBar.sequence()+"\x1b[?2026l", output.String(), testSubtitle)
+
+	testSubtitle = "Rows no longer needed get cleared"
+	output.Reset()
+	p.linesToRender = p.linesToRender[:2]
+	p.renderView(termenv.NewOutput(&output), "test")
+	assert.Equal(t, "\x1b[?2026h\x1b[3;1H\x1b[0J\x1b[6;6H\x1b[?2026l", output.String(), testSubtitle)
+
+	testSubtitle = "Debug"
+	output.Reset()
+	p.SetDebug(true)
+	p.linesRendered = make([]string, 0)
+	p.renderView(termenv.NewOutput(&output), "test")
+	lines := splitBeforeMoves(output.String())
+	assert.Len(t, lines, 5, testSubtitle)
+	assert.True(t, strings.HasPrefix(lines[3], "\x1b[3;1H\x1b[2K"), testSubtitle)
+	assert.Contains(t, text.StripEscape(lines[3]), "reason=test", testSubtitle)
+	assert.Contains(t, text.StripEscape(lines[3]), "bytes=", testSubtitle)
+	assert.True(t, strings.HasPrefix(lines[4], "\x1b[6;10H"), testSubtitle)
+}
+
+// splitBeforeMoves splits the output before every move of the cursor to an
+// absolute location.
+func splitBeforeMoves(out string) []string {
+	var rsp []string
+	start := 0
+	for _, loc := range reCursorMove.FindAllStringIndex(out, -1) {
+		if loc[0] > start {
+			rsp = append(rsp, out[start:loc[0]])
+		}
+		start = loc[0]
+	}
+	return append(rsp, out[start:])
+}
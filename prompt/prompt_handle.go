@@ -11,12 +11,12 @@ func (p *prompt) handleHistoryExec(output *termenv.Output, cmdNum int) {
 	p.pauseRender()
 	defer p.resumeRender()
 
-	p.updateModel(false)
+	p.updateModel(false, true)
 	p.renderView(output, "hist.exec", true)
 
 	cmd := p.history.Get(cmdNum - 1)
 	if cmd == "" {
-		_, _ = output.WriteString(p.style.Colors.Error.Sprintf("ERROR: invalid command number: %v.\n", cmdNum))
+		_, _ = output.WriteString(convertEscSeqs(p.style.Colors.Error.Sprintf("ERROR: invalid command number: %v.\n", cmdNum), p.activeColorProfile))
 		_, _ = output.WriteString("\n")
 		p.linesRendered = make([]string, 0)
 		p.buffer.Reset()
@@ -31,10 +31,10 @@ func (p *prompt) handleHistoryList(output *termenv.Output, numItems int) {
 	p.pauseRender()
 	defer p.resumeRender()
 
-	p.updateModel(false)
+	p.updateModel(false, true)
 	p.renderView(output, "hist.list", true)
 
-	_, _ = output.WriteString(p.history.Render(numItems, p.getDisplayWidth()))
+	_, _ = output.WriteString(convertEscSeqs(p.history.Render(numItems, p.getDisplayWidth()), p.activeColorProfile))
 	_, _ = output.WriteString("\n")
 	p.linesRendered = make([]string, 0)
 	p.buffer.Reset()
@@ -83,12 +83,31 @@ var insertActionHandlerMap = map[Action]actionHandler{
 		p.forceAutoComplete(true)
 		return nil
 	},
+	Copy: func(p *prompt, output *termenv.Output, key tea.KeyMsg) error {
+		p.copyToClipboard(output, p.buffer.String()) // nothing selected; copy everything
+		return nil
+	},
+	Cut: func(p *prompt, output *termenv.Output, key tea.KeyMsg) error {
+		p.copyToClipboard(output, p.buffer.String()) // nothing selected; cut everything
+		p.buffer.Reset()
+		p.forceAutoComplete(false)
+		p.resetSuggestions()
+		return nil
+	},
+	Dedent: func(p *prompt, output *termenv.Output, key tea.KeyMsg) error {
+		p.buffer.Dedent()
+		return nil
+	},
 	DeleteCharCurrent: func(p *prompt, output *termenv.Output, key tea.KeyMsg) error {
 		p.buffer.DeleteForward(1)
 		return nil
 	},
 	DeleteCharPrevious: func(p *prompt, output *termenv.Output, key tea.KeyMsg) error {
-		p.buffer.DeleteBackward(1)
+		p.deleteCharPrevious()
+		return nil
+	},
+	DeleteLine: func(p *prompt, output *termenv.Output, key tea.KeyMsg) error {
+		p.buffer.DeleteLine()
 		return nil
 	},
 	DeleteWordNext: func(p *prompt, output *termenv.Output, key tea.KeyMsg) error {
@@ -99,6 +118,16 @@ var insertActionHandlerMap = map[Action]actionHandler{
 		p.buffer.DeleteWordBackward()
 		return nil
 	},
+	DuplicateLine: func(p *prompt, output *termenv.Output, key tea.KeyMsg) error {
+		p.buffer.DuplicateLine()
+		return nil
+	},
+	EditInExternalEditor: func(p *prompt, output *termenv.Output, key tea.KeyMsg) error {
+		p.handleExternalEditor(output)
+		p.forceAutoComplete(false)
+		p.resetSuggestions()
+		return nil
+	},
 	EraseEverything: func(p *prompt, output *termenv.Output, key tea.KeyMsg) error {
 		p.buffer.Reset()
 		return nil
@@ -121,6 +150,18 @@ var insertActionHandlerMap = map[Action]actionHandler{
 		p.resetSuggestions()
 		return nil
 	},
+	Indent: func(p *prompt, output *termenv.Output, key tea.KeyMsg) error {
+		p.buffer.Indent()
+		return nil
+	},
+	JoinWithNextLine: func(p *prompt, output *termenv.Output, key tea.KeyMsg) error {
+		p.buffer.JoinWithNextLine()
+		return nil
+	},
+	JumpToMatchingBracket: func(p *prompt, output *termenv.Output, key tea.KeyMsg) error {
+		p.jumpToMatchingBracket()
+		return nil
+	},
 	MakeWordCapitalCase: func(p *prompt, output *termenv.Output, key tea.KeyMsg) error {
 		p.buffer.MakeWordCapitalCase()
 		return nil
@@ -134,19 +175,31 @@ var insertActionHandlerMap = map[Action]actionHandler{
 		return nil
 	},
 	MoveDownOneLine: func(p *prompt, output *termenv.Output, key tea.KeyMsg) error {
-		p.buffer.MoveDown(1)
+		if !p.moveToVisualRow(1) {
+			p.buffer.MoveDown(1)
+		}
 		return nil
 	},
 	MoveLeftOneCharacter: func(p *prompt, output *termenv.Output, key tea.KeyMsg) error {
 		p.buffer.MoveLeft(1)
 		return nil
 	},
+	MoveLineDown: func(p *prompt, output *termenv.Output, key tea.KeyMsg) error {
+		p.buffer.MoveLineDown()
+		return nil
+	},
+	MoveLineUp: func(p *prompt, output *termenv.Output, key tea.KeyMsg) error {
+		p.buffer.MoveLineUp()
+		return nil
+	},
 	MoveRightOneCharacter: func(p *prompt, output *termenv.Output, key tea.KeyMsg) error {
 		p.buffer.MoveRight(1)
 		return nil
 	},
 	MoveUpOneLine: func(p *prompt, output *termenv.Output, key tea.KeyMsg) error {
-		p.buffer.MoveUp(1)
+		if !p.moveToVisualRow(-1) {
+			p.buffer.MoveUp(1)
+		}
 		return nil
 	},
 	MoveToBeginning: func(p *prompt, output *termenv.Output, key tea.KeyMsg) error {
@@ -154,7 +207,9 @@ var insertActionHandlerMap = map[Action]actionHandler{
 		return nil
 	},
 	MoveToBeginningOfLine: func(p *prompt, output *termenv.Output, key tea.KeyMsg) error {
-		p.buffer.MoveToBeginningOfLine()
+		if !p.visualHomeEnd || !p.moveToVisualRowEdge(false) {
+			p.buffer.MoveToBeginningOfLine()
+		}
 		return nil
 	},
 	MoveToEnd: func(p *prompt, output *termenv.Output, key tea.KeyMsg) error {
@@ -162,7 +217,9 @@ var insertActionHandlerMap = map[Action]actionHandler{
 		return nil
 	},
 	MoveToEndOfLine: func(p *prompt, output *termenv.Output, key tea.KeyMsg) error {
-		p.buffer.MoveToEndOfLine()
+		if !p.visualHomeEnd || !p.moveToVisualRowEdge(true) {
+			p.buffer.MoveToEndOfLine()
+		}
 		return nil
 	},
 	MoveToWordNext: func(p *prompt, output *termenv.Output, key tea.KeyMsg) error {
@@ -173,6 +230,73 @@ var insertActionHandlerMap = map[Action]actionHandler{
 		p.buffer.MoveWordLeft()
 		return nil
 	},
+	OpenLineAbove: func(p *prompt, output *termenv.Output, key tea.KeyMsg) error {
+		line := p.buffer.Lines()[p.buffer.Cursor().Line]
+		indent := p.style.Indent.indentation(line[:len(line)-len(strings.TrimLeft(line, " \t"))], p.style.TabString)
+		p.buffer.OpenLineAbove(indent)
+		return nil
+	},
+	OpenLineBelow: func(p *prompt, output *termenv.Output, key tea.KeyMsg) error {
+		line := p.buffer.Lines()[p.buffer.Cursor().Line]
+		p.buffer.OpenLineBelow(p.style.Indent.indentation(line, p.style.TabString))
+		return nil
+	},
+	Paste: func(p *prompt, output *termenv.Output, key tea.KeyMsg) error {
+		p.buffer.InsertString(p.clipboard)
+		p.forceAutoComplete(false)
+		p.resetSuggestions()
+		return nil
+	},
+	SelectDownOneLine: func(p *prompt, output *termenv.Output, key tea.KeyMsg) error {
+		p.buffer.StartSelection()
+		p.buffer.MoveDown(1)
+		return nil
+	},
+	SelectLeftOneCharacter: func(p *prompt, output *termenv.Output, key tea.KeyMsg) error {
+		p.buffer.StartSelection()
+		p.buffer.MoveLeft(1)
+		return nil
+	},
+	SelectRightOneCharacter: func(p *prompt, output *termenv.Output, key tea.KeyMsg) error {
+		p.buffer.StartSelection()
+		p.buffer.MoveRight(1)
+		return nil
+	},
+	SelectToBeginning: func(p *prompt, output *termenv.Output, key tea.KeyMsg) error {
+		p.buffer.StartSelection()
+		p.buffer.MoveToBeginning()
+		return nil
+	},
+	SelectToBeginningOfLine: func(p *prompt, output *termenv.Output, key tea.KeyMsg) error {
+		p.buffer.StartSelection()
+		p.buffer.MoveToBeginningOfLine()
+		return nil
+	},
+	SelectToEnd: func(p *prompt, output *termenv.Output, key tea.KeyMsg) error {
+		p.buffer.StartSelection()
+		p.buffer.MoveToEnd()
+		return nil
+	},
+	SelectToEndOfLine: func(p *prompt, output *termenv.Output, key tea.KeyMsg) error {
+		p.buffer.StartSelection()
+		p.buffer.MoveToEndOfLine()
+		return nil
+	},
+	SelectToWordNext: func(p *prompt, output *termenv.Output, key tea.KeyMsg) error {
+		p.buffer.StartSelection()
+		p.buffer.MoveWordRight()
+		return nil
+	},
+	SelectToWordPrevious: func(p *prompt, output *termenv.Output, key tea.KeyMsg) error {
+		p.buffer.StartSelection()
+		p.buffer.MoveWordLeft()
+		return nil
+	},
+	SelectUpOneLine: func(p *prompt, output *termenv.Output, key tea.KeyMsg) error {
+		p.buffer.StartSelection()
+		p.buffer.MoveUp(1)
+		return nil
+	},
 	Terminate: func(p *prompt, output *termenv.Output, key tea.KeyMsg) error {
 		input := p.buffer.String()
 		if histCmd := p.processHistoryCommand(input); histCmd.Type != historyCommandNone {
@@ -183,18 +307,39 @@ var insertActionHandlerMap = map[Action]actionHandler{
 				p.handleHistoryList(output, histCmd.Value)
 			}
 		} else if p.terminationChecker(input) {
-			p.buffer.MarkAsDone()
+			// refuse to submit input with errors; they are shown below the input
+			// already, but need to be announced once in the accessible mode
+			if diagnostics := p.getDiagnostics(input); !hasErrors(diagnostics) {
+				p.buffer.MarkAsDone()
+			} else if p.isAccessible() {
+				p.linesMutex.Lock()
+				p.accessibleDiagnostics = diagnostics
+				p.linesMutex.Unlock()
+			}
 		} else {
+			// start the new line with the right indentation
+			lines, cursor := p.buffer.Lines(), p.buffer.Cursor()
+			indent := p.style.Indent.indentation(lines[cursor.Line][:cursor.Column], p.style.TabString)
 			p.buffer.Insert('\n')
+			p.buffer.InsertString(indent)
 		}
 		p.forceAutoComplete(false)
 		p.resetSuggestions()
 		return nil
 	},
+	ToggleLineComment: func(p *prompt, output *termenv.Output, key tea.KeyMsg) error {
+		p.buffer.ToggleLineComment(p.style.LineComment)
+		return nil
+	},
 	None: func(p *prompt, output *termenv.Output, key tea.KeyMsg) error {
-		if key.Type == tea.KeyRunes {
+		if key.Type == tea.KeyRunes && key.Paste {
+			// insert pasted text verbatim without acting on the new-lines
+			p.buffer.InsertString(pastedTextNormalizer.Replace(string(key.Runes)))
+			p.forceAutoComplete(false)
+			p.resetSuggestions()
+		} else if key.Type == tea.KeyRunes {
 			for _, r := range key.Runes {
-				p.buffer.Insert(r)
+				p.insertRune(r)
 			}
 			p.forceAutoComplete(false)
 			p.resetSuggestions()
@@ -211,6 +356,97 @@ var insertActionHandlerMap = map[Action]actionHandler{
 	},
 }
 
+// selectionActionHandlerMap contains the handlers for the Actions that work on
+// the selected text instead, when there is some text selected.
+var selectionActionHandlerMap = map[Action]actionHandler{
+	Copy: func(p *prompt, output *termenv.Output, key tea.KeyMsg) error {
+		p.copyToClipboard(output, p.buffer.SelectionText())
+		return nil
+	},
+	Cut: func(p *prompt, output *termenv.Output, key tea.KeyMsg) error {
+		p.copyToClipboard(output, p.buffer.SelectionText())
+		return handleSelectionDelete(p, output, key)
+	},
+	Dedent: func(p *prompt, output *termenv.Output, key tea.KeyMsg) error {
+		p.buffer.Dedent()
+		return nil
+	},
+	DeleteCharCurrent:  handleSelectionDelete,
+	DeleteCharPrevious: handleSelectionDelete,
+	DeleteWordNext:     handleSelectionDelete,
+	DeleteWordPrevious: handleSelectionDelete,
+	Indent: func(p *prompt, output *termenv.Output, key tea.KeyMsg) error {
+		p.buffer.Indent()
+		return nil
+	},
+	MakeWordCapitalCase: func(p *prompt, output *termenv.Output, key tea.KeyMsg) error {
+		p.buffer.TransformSelection(makeCapitalCase)
+		return nil
+	},
+	MakeWordLowerCase: func(p *prompt, output *termenv.Output, key tea.KeyMsg) error {
+		p.buffer.TransformSelection(strings.ToLower)
+		return nil
+	},
+	MakeWordUpperCase: func(p *prompt, output *termenv.Output, key tea.KeyMsg) error {
+		p.buffer.TransformSelection(strings.ToUpper)
+		return nil
+	},
+	Paste: func(p *prompt, output *termenv.Output, key tea.KeyMsg) error {
+		p.buffer.DeleteSelection()
+		return insertActionHandlerMap[Paste](p, output, key)
+	},
+	ToggleLineComment: func(p *prompt, output *termenv.Output, key tea.KeyMsg) error {
+		p.buffer.ToggleLineComment(p.style.LineComment)
+		return nil
+	},
+	None: func(p *prompt, output *termenv.Output, key tea.KeyMsg) error {
+		if key.Type == tea.KeyRunes && len(key.Runes) == 1 && !key.Paste {
+			if closing, ok := surroundPairs[key.Runes[0]]; ok {
+				p.buffer.SurroundSelection(key.Runes[0], closing)
+				return nil
+			}
+		}
+		// type over the selected text
+		if key.Type == tea.KeyRunes || key.Type == tea.KeySpace || key.Type == tea.KeyTab {
+			p.buffer.DeleteSelection()
+		}
+		return insertActionHandlerMap[None](p, output, key)
+	},
+}
+
+// selectionPreservingActions are the Actions that do not clear the selection.
+var selectionPreservingActions = map[Action]bool{
+	Copy:                    true,
+	SelectDownOneLine:       true,
+	SelectLeftOneCharacter:  true,
+	SelectRightOneCharacter: true,
+	SelectToBeginning:       true,
+	SelectToBeginningOfLine: true,
+	SelectToEnd:             true,
+	SelectToEndOfLine:       true,
+	SelectToWordNext:        true,
+	SelectToWordPrevious:    true,
+	SelectUpOneLine:         true,
+}
+
+// surroundPairs maps the runes that, when typed with some text selected,
+// surround the selection instead of replacing it, to their closing runes.
+var surroundPairs = map[rune]rune{
+	'"':  '"',
+	'\'': '\'',
+	'(':  ')',
+	'[':  ']',
+	'`':  '`',
+	'{':  '}',
+}
+
+func handleSelectionDelete(p *prompt, output *termenv.Output, key tea.KeyMsg) error {
+	p.buffer.DeleteSelection()
+	p.forceAutoComplete(false)
+	p.resetSuggestions()
+	return nil
+}
+
 func (p *prompt) handleKey(output *termenv.Output, key tea.KeyMsg) error {
 	if p.isInAutoComplete {
 		return p.handleKeyAutoComplete(output, key)
@@ -238,6 +474,16 @@ func (p *prompt) handleKeyInsert(output *termenv.Output, key tea.KeyMsg) error {
 	}
 
 	action := p.translateKeyToInsertAction(key)
+	if p.buffer.HasSelection() {
+		if handler, ok := selectionActionHandlerMap[action]; ok {
+			p.setDebugData("action", string(action))
+			return handler(p, output, key)
+		}
+	}
+	if !selectionPreservingActions[action] {
+		p.buffer.ClearSelection()
+	}
+
 	handler, ok := insertActionHandlerMap[action]
 	if ok && handler != nil {
 		p.setDebugData("action", string(action))
@@ -218,6 +218,24 @@ func TestPrompt_handleKeyInsert(t *testing.T) {
 		assert.Equal(t, "tet", p.buffer.String())
 	})
 
+	t.Run("DeleteCharPrevious Auto-Pair", func(t *testing.T) {
+		p := generateTestPromptWithBuffer(t, ctx, "foo()", CursorLocation{0, 4})
+		p.keyMapReversed.Insert[Enter] = DeleteCharPrevious
+		p.style.Brackets = StyleBracketsSQL
+
+		output := strings.Builder{}
+		err := p.handleKeyInsert(termenv.NewOutput(&output), tea.KeyMsg{Type: tea.KeyEnter})
+		assert.Nil(t, err)
+		assert.Equal(t, "foo", p.buffer.String())
+
+		// not an empty pair
+		p.buffer.Set("(a)")
+		p.buffer.cursor = CursorLocation{0, 2}
+		err = p.handleKeyInsert(termenv.NewOutput(&output), tea.KeyMsg{Type: tea.KeyEnter})
+		assert.Nil(t, err)
+		assert.Equal(t, "()", p.buffer.String())
+	})
+
 	t.Run("DeleteWordNext", func(t *testing.T) {
 		p := generateTestPromptWithBuffer(t, ctx, testText1, CursorLocation{0, 5})
 		p.keyMapReversed.Insert[Enter] = DeleteWordNext
@@ -292,6 +310,74 @@ func TestPrompt_handleKeyInsert(t *testing.T) {
 		assert.Equal(t, testHistoryCommands[0].Command, p.buffer.String())
 	})
 
+	t.Run("Indent and Dedent", func(t *testing.T) {
+		p := generateTestPromptWithBuffer(t, ctx, testText2, CursorLocation{1, 3})
+		p.keyMap = KeyMapMultiLine
+		p.keyMapReversed, _ = p.keyMap.reverse()
+
+		output := strings.Builder{}
+		err := p.handleKeyInsert(termenv.NewOutput(&output), tea.KeyMsg{Type: tea.KeyTab})
+		assert.Nil(t, err)
+		assert.Equal(t, "test this thing\n    not this thing", p.buffer.String())
+		assert.Equal(t, CursorLocation{1, 7}, p.buffer.Cursor())
+		err = p.handleKeyInsert(termenv.NewOutput(&output), tea.KeyMsg{Type: tea.KeyShiftTab})
+		assert.Nil(t, err)
+		assert.Equal(t, testText2, p.buffer.String())
+		assert.Equal(t, CursorLocation{1, 3}, p.buffer.Cursor())
+	})
+
+	t.Run("Line Actions", func(t *testing.T) {
+		p := generateTestPromptWithBuffer(t, ctx, "select (\n  a,\n  b)", CursorLocation{1, 3})
+		p.keyMap = KeyMapMultiLine
+		p.keyMapReversed, _ = p.keyMap.reverse()
+		p.style.LineComment = "-- "
+
+		output := termenv.NewOutput(&strings.Builder{})
+		for _, tc := range []struct {
+			key      tea.KeyMsg
+			expected string
+			cursor   CursorLocation
+		}{
+			{tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'p'}, Alt: true}, "select (\n  a,\n  a,\n  b)", CursorLocation{2, 3}},
+			{tea.KeyMsg{Type: tea.KeyCtrlUp}, "select (\n  a,\n  a,\n  b)", CursorLocation{1, 3}},
+			{tea.KeyMsg{Type: tea.KeyCtrlUp}, "  a,\nselect (\n  a,\n  b)", CursorLocation{0, 3}},
+			{tea.KeyMsg{Type: tea.KeyCtrlDown}, "select (\n  a,\n  a,\n  b)", CursorLocation{1, 3}},
+			{tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'k'}, Alt: true}, "select (\n  a,\n  b)", CursorLocation{1, 3}},
+			{tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'q'}, Alt: true}, "select (\n  -- a,\n  b)", CursorLocation{1, 6}},
+			{tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'q'}, Alt: true}, "select (\n  a,\n  b)", CursorLocation{1, 3}},
+			{tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'j'}, Alt: true}, "select (\n  a, b)", CursorLocation{1, 5}},
+			{tea.KeyMsg{Type: tea.KeyCtrlO}, "select (\n  a, b)\n  ", CursorLocation{2, 2}},
+			{tea.KeyMsg{Type: tea.KeyUp}, "select (\n  a, b)\n  ", CursorLocation{1, 2}},
+			{tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'o'}, Alt: true}, "select (\n  \n  a, b)\n  ", CursorLocation{1, 2}},
+			{tea.KeyMsg{Type: tea.KeyHome}, "select (\n  \n  a, b)\n  ", CursorLocation{1, 0}},
+			{tea.KeyMsg{Type: tea.KeyUp}, "select (\n  \n  a, b)\n  ", CursorLocation{0, 0}},
+			{tea.KeyMsg{Type: tea.KeyCtrlO}, "select (\n    \n  \n  a, b)\n  ", CursorLocation{1, 4}},
+		} {
+			assert.Nil(t, p.handleKeyInsert(output, tc.key))
+			assert.Equal(t, tc.expected, p.buffer.String(), tc.key.String())
+			assert.Equal(t, tc.cursor, p.buffer.Cursor(), tc.key.String())
+		}
+	})
+
+	t.Run("JumpToMatchingBracket", func(t *testing.T) {
+		p := generateTestPromptWithBuffer(t, ctx, "foo(bar,\n  baz(')'))", CursorLocation{0, 3})
+		p.keyMapReversed.Insert[Enter] = JumpToMatchingBracket
+
+		output := strings.Builder{}
+		err := p.handleKeyInsert(termenv.NewOutput(&output), tea.KeyMsg{Type: tea.KeyEnter})
+		assert.Nil(t, err)
+		assert.Equal(t, CursorLocation{Line: 1, Column: 10}, p.buffer.Cursor())
+		err = p.handleKeyInsert(termenv.NewOutput(&output), tea.KeyMsg{Type: tea.KeyEnter})
+		assert.Nil(t, err)
+		assert.Equal(t, CursorLocation{Line: 0, Column: 3}, p.buffer.Cursor())
+
+		// no bracket at the cursor
+		p.buffer.cursor = CursorLocation{0, 1}
+		err = p.handleKeyInsert(termenv.NewOutput(&output), tea.KeyMsg{Type: tea.KeyEnter})
+		assert.Nil(t, err)
+		assert.Equal(t, CursorLocation{Line: 0, Column: 1}, p.buffer.Cursor())
+	})
+
 	t.Run("MakeWordCapitalCase", func(t *testing.T) {
 		p := generateTestPromptWithBuffer(t, ctx, testText1, CursorLocation{0, 5})
 		p.keyMapReversed.Insert[Enter] = MakeWordCapitalCase
@@ -450,6 +536,29 @@ func TestPrompt_handleKeyInsert(t *testing.T) {
 		assert.Contains(t, p.debugDataAsString(), "reason=hist.list")
 	})
 
+	t.Run("Terminate with Diagnostics", func(t *testing.T) {
+		p := generateTestPrompt(t, ctx)
+		p.keyMapReversed.Insert[Enter] = Terminate
+		severity := DiagnosticSeverityError
+		p.SetValidator(func(input string) []Diagnostic {
+			return []Diagnostic{{Message: "oops", Severity: severity}}
+		})
+		p.buffer.InsertString(testText1)
+
+		output := strings.Builder{}
+		err := p.handleKeyInsert(termenv.NewOutput(&output), tea.KeyMsg{Type: tea.KeyEnter})
+		assert.Nil(t, err)
+		assert.Equal(t, testText1, p.buffer.String())
+		assert.False(t, p.buffer.IsDone())
+
+		severity = DiagnosticSeverityWarning
+		p.buffer.InsertString(" ")
+		err = p.handleKeyInsert(termenv.NewOutput(&output), tea.KeyMsg{Type: tea.KeyEnter})
+		assert.Nil(t, err)
+		assert.Equal(t, testText1+" ", p.buffer.String())
+		assert.True(t, p.buffer.IsDone())
+	})
+
 	t.Run("Terminate Done", func(t *testing.T) {
 		p := generateTestPrompt(t, ctx)
 		p.keyMapReversed.Insert[Enter] = Terminate
@@ -475,6 +584,29 @@ func TestPrompt_handleKeyInsert(t *testing.T) {
 		assert.False(t, p.buffer.IsDone())
 	})
 
+	t.Run("Terminate Insert Newline with Indentation", func(t *testing.T) {
+		p := generateTestPrompt(t, ctx)
+		p.keyMapReversed.Insert[Enter] = Terminate
+		p.buffer.InsertString("select (\n    foo,")
+		p.SetTerminationChecker(TerminationCheckerSQL())
+
+		output := strings.Builder{}
+		err := p.handleKeyInsert(termenv.NewOutput(&output), tea.KeyMsg{Type: tea.KeyEnter})
+		assert.Nil(t, err)
+		assert.Equal(t, "select (\n    foo,\n    ", p.buffer.String())
+
+		p.buffer.Set("select (")
+		err = p.handleKeyInsert(termenv.NewOutput(&output), tea.KeyMsg{Type: tea.KeyEnter})
+		assert.Nil(t, err)
+		assert.Equal(t, "select (\n"+p.style.TabString, p.buffer.String())
+
+		p.style.Indent = StyleIndentNone
+		p.buffer.Set("    select (")
+		err = p.handleKeyInsert(termenv.NewOutput(&output), tea.KeyMsg{Type: tea.KeyEnter})
+		assert.Nil(t, err)
+		assert.Equal(t, "    select (\n", p.buffer.String())
+	})
+
 	t.Run("Runes", func(t *testing.T) {
 		p := generateTestPrompt(t, ctx)
 		p.SetTerminationChecker(TerminationCheckerSQL())
@@ -505,6 +637,42 @@ func TestPrompt_handleKeyInsert(t *testing.T) {
 		assert.Equal(t, p.style.TabString, p.buffer.String())
 	})
 
+	t.Run("Runes Auto-Pair", func(t *testing.T) {
+		p := generateTestPrompt(t, ctx)
+		p.style.Brackets = StyleBracketsSQL
+
+		output := strings.Builder{}
+		for _, tc := range []struct {
+			input    string
+			expected string
+			cursor   int
+		}{
+			{input: "(", expected: "()", cursor: 1},
+			{input: "(x", expected: "(x)", cursor: 2},
+			{input: "(x)", expected: "(x)", cursor: 3},
+			{input: "('", expected: "('')", cursor: 2},
+			{input: "('a'", expected: "('a')", cursor: 4},
+			{input: "it's", expected: "it's", cursor: 4},
+			{input: "(ab", expected: "(ab)", cursor: 3},
+			{input: "{", expected: "{", cursor: 1},
+		} {
+			p.buffer.Reset()
+			for _, r := range tc.input {
+				err := p.handleKeyInsert(termenv.NewOutput(&output), tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{r}})
+				assert.Nil(t, err)
+			}
+			assert.Equal(t, tc.expected, p.buffer.String(), tc.input)
+			assert.Equal(t, CursorLocation{Line: 0, Column: tc.cursor}, p.buffer.Cursor(), tc.input)
+		}
+
+		// no pairing in the middle of a word
+		p.buffer.Set("ab")
+		p.buffer.cursor = CursorLocation{0, 1}
+		err := p.handleKeyInsert(termenv.NewOutput(&output), tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("(")})
+		assert.Nil(t, err)
+		assert.Equal(t, "a(b", p.buffer.String())
+	})
+
 	t.Run("unknown action", func(t *testing.T) {
 		p := generateTestPrompt(t, ctx)
 		p.keyMapReversed.Insert[Enter] = Action("foo")
@@ -514,3 +682,146 @@ func TestPrompt_handleKeyInsert(t *testing.T) {
 		assert.Nil(t, err)
 	})
 }
+
+func TestPrompt_handleKeyInsertSelection(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second*5)
+	defer cancel()
+
+	testText := "test this thing\nnot this thing"
+	selectThis := func(t *testing.T) *prompt {
+		p := generateTestPromptWithBuffer(t, ctx, testText, CursorLocation{0, 5})
+		output := termenv.NewOutput(&strings.Builder{})
+		for idx := 0; idx < 4; idx++ {
+			assert.Nil(t, p.handleKeyInsert(output, tea.KeyMsg{Type: tea.KeyShiftRight}))
+		}
+		assert.Equal(t, "this", p.buffer.SelectionText())
+		return p
+	}
+
+	t.Run("Select*", func(t *testing.T) {
+		p := generateTestPromptWithBuffer(t, ctx, testText, CursorLocation{0, 5})
+		p.keyMap = KeyMapMultiLine
+		p.keyMapReversed, _ = p.keyMap.reverse()
+		output := termenv.NewOutput(&strings.Builder{})
+
+		assert.Nil(t, p.handleKeyInsert(output, tea.KeyMsg{Type: tea.KeyCtrlShiftRight}))
+		assert.Equal(t, "this ", p.buffer.SelectionText())
+		assert.Nil(t, p.handleKeyInsert(output, tea.KeyMsg{Type: tea.KeyShiftLeft}))
+		assert.Equal(t, "this", p.buffer.SelectionText())
+		assert.Nil(t, p.handleKeyInsert(output, tea.KeyMsg{Type: tea.KeyCtrlShiftDown}))
+		assert.Equal(t, "this thing\nnot this ", p.buffer.SelectionText())
+		assert.Nil(t, p.handleKeyInsert(output, tea.KeyMsg{Type: tea.KeyShiftEnd}))
+		assert.Equal(t, "this thing\nnot this thing", p.buffer.SelectionText())
+		assert.Nil(t, p.handleKeyInsert(output, tea.KeyMsg{Type: tea.KeyCtrlShiftUp}))
+		assert.Equal(t, "this thin", p.buffer.SelectionText())
+		assert.Nil(t, p.handleKeyInsert(output, tea.KeyMsg{Type: tea.KeyShiftHome}))
+		assert.Equal(t, "test ", p.buffer.SelectionText())
+		assert.Nil(t, p.handleKeyInsert(output, tea.KeyMsg{Type: tea.KeyCtrlShiftEnd}))
+		assert.Equal(t, "this thing\nnot this thing", p.buffer.SelectionText())
+		assert.Nil(t, p.handleKeyInsert(output, tea.KeyMsg{Type: tea.KeyCtrlShiftHome}))
+		assert.Equal(t, "test ", p.buffer.SelectionText())
+
+		// moving the cursor without extending the selection clears it
+		assert.Nil(t, p.handleKeyInsert(output, tea.KeyMsg{Type: tea.KeyRight}))
+		assert.False(t, p.buffer.HasSelection())
+		assert.Equal(t, CursorLocation{0, 1}, p.buffer.Cursor())
+		assert.Equal(t, testText, p.buffer.String())
+	})
+
+	t.Run("Copy and Paste", func(t *testing.T) {
+		p := selectThis(t)
+		output := termenv.NewOutput(&strings.Builder{})
+
+		assert.Nil(t, p.handleKeyInsert(output, tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'x'}, Alt: true}))
+		assert.Equal(t, "this", p.clipboard)
+		assert.True(t, p.buffer.HasSelection())
+		assert.Nil(t, p.handleKeyInsert(output, tea.KeyMsg{Type: tea.KeyEnd}))
+		assert.Nil(t, p.handleKeyInsert(output, tea.KeyMsg{Type: tea.KeyCtrlV}))
+		assert.Equal(t, "test this thingthis\nnot this thing", p.buffer.String())
+	})
+
+	t.Run("Cut and Paste", func(t *testing.T) {
+		p := selectThis(t)
+		output := termenv.NewOutput(&strings.Builder{})
+
+		assert.Nil(t, p.handleKeyInsert(output, tea.KeyMsg{Type: tea.KeyCtrlX}))
+		assert.Equal(t, "this", p.clipboard)
+		assert.Equal(t, "test  thing\nnot this thing", p.buffer.String())
+		assert.False(t, p.buffer.HasSelection())
+
+		// paste over a selection replaces it
+		assert.Nil(t, p.handleKeyInsert(output, tea.KeyMsg{Type: tea.KeyShiftHome}))
+		assert.Nil(t, p.handleKeyInsert(output, tea.KeyMsg{Type: tea.KeyCtrlY}))
+		assert.Equal(t, "this thing\nnot this thing", p.buffer.String())
+	})
+
+	t.Run("Delete", func(t *testing.T) {
+		for _, keyType := range []tea.KeyType{tea.KeyBackspace, tea.KeyDelete, tea.KeyCtrlW} {
+			p := selectThis(t)
+			output := termenv.NewOutput(&strings.Builder{})
+
+			assert.Nil(t, p.handleKeyInsert(output, tea.KeyMsg{Type: keyType}))
+			assert.Equal(t, "test  thing\nnot this thing", p.buffer.String())
+			assert.Equal(t, CursorLocation{0, 5}, p.buffer.Cursor())
+		}
+	})
+
+	t.Run("MakeWord*Case", func(t *testing.T) {
+		p := selectThis(t)
+		output := termenv.NewOutput(&strings.Builder{})
+
+		assert.Nil(t, p.handleKeyInsert(output, tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'u'}, Alt: true}))
+		assert.Equal(t, "test THIS thing\nnot this thing", p.buffer.String())
+		assert.Nil(t, p.handleKeyInsert(output, tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'l'}, Alt: true}))
+		assert.Equal(t, "test this thing\nnot this thing", p.buffer.String())
+		assert.Nil(t, p.handleKeyInsert(output, tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'c'}, Alt: true}))
+		assert.Equal(t, "test This thing\nnot this thing", p.buffer.String())
+		assert.Equal(t, "This", p.buffer.SelectionText())
+	})
+
+	t.Run("Indent and Dedent", func(t *testing.T) {
+		p := selectThis(t)
+		p.keyMap = KeyMapMultiLine
+		p.keyMapReversed, _ = p.keyMap.reverse()
+		output := termenv.NewOutput(&strings.Builder{})
+
+		assert.Nil(t, p.handleKeyInsert(output, tea.KeyMsg{Type: tea.KeyCtrlShiftDown}))
+		assert.Nil(t, p.handleKeyInsert(output, tea.KeyMsg{Type: tea.KeyTab}))
+		assert.Equal(t, "    test this thing\n    not this thing", p.buffer.String())
+		assert.Equal(t, "this thing\n    not this ", p.buffer.SelectionText())
+		assert.Nil(t, p.handleKeyInsert(output, tea.KeyMsg{Type: tea.KeyShiftTab}))
+		assert.Equal(t, testText, p.buffer.String())
+		assert.Equal(t, "this thing\nnot this ", p.buffer.SelectionText())
+	})
+
+	t.Run("ToggleLineComment", func(t *testing.T) {
+		p := selectThis(t)
+		p.keyMapReversed.Insert[AltQ] = ToggleLineComment
+		output := termenv.NewOutput(&strings.Builder{})
+
+		assert.Nil(t, p.handleKeyInsert(output, tea.KeyMsg{Type: tea.KeyShiftDown}))
+		assert.Nil(t, p.handleKeyInsert(output, tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'q'}, Alt: true}))
+		assert.Equal(t, "# test this thing\n# not this thing", p.buffer.String())
+		assert.True(t, p.buffer.HasSelection())
+	})
+
+	t.Run("Surround", func(t *testing.T) {
+		p := selectThis(t)
+		output := termenv.NewOutput(&strings.Builder{})
+
+		assert.Nil(t, p.handleKeyInsert(output, tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'('}}))
+		assert.Equal(t, "test (this) thing\nnot this thing", p.buffer.String())
+		assert.Nil(t, p.handleKeyInsert(output, tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'"'}}))
+		assert.Equal(t, "test (\"this\") thing\nnot this thing", p.buffer.String())
+		assert.Equal(t, "this", p.buffer.SelectionText())
+	})
+
+	t.Run("Type Over", func(t *testing.T) {
+		p := selectThis(t)
+		output := termenv.NewOutput(&strings.Builder{})
+
+		assert.Nil(t, p.handleKeyInsert(output, tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("that")}))
+		assert.Equal(t, "test that thing\nnot this thing", p.buffer.String())
+		assert.False(t, p.buffer.HasSelection())
+	})
+}
@@ -0,0 +1,124 @@
+package prompt
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/mattn/go-isatty"
+	"github.com/muesli/termenv"
+)
+
+// isInteractive returns true if the prompt should render an interactive
+// editor, which is the case when forced using SetInteractive, or unless the
+// input or the output is a file or a pipe that is not a terminal. Inputs and
+// outputs that are not files (like the ones in tests) cannot be told apart,
+// and are assumed to be terminals.
+func (p *prompt) isInteractive() bool {
+	if p.interactive != nil {
+		return *p.interactive
+	}
+	for _, stream := range []any{p.getInputReader(), p.getOutputWriter()} {
+		if _, ok := stream.(interface{ Fd() uintptr }); ok && !isTerminal(stream) {
+			return false
+		}
+	}
+	return true
+}
+
+// readLine reads the next line from the input, or returns the error from the
+// context if it is done first. The buffered reader (and a read that is still
+// in progress) is retained across calls to Prompt to not lose any input read
+// ahead of time.
+func (p *prompt) readLine(ctx context.Context) (string, error) {
+	p.lineReaderMutex.Lock()
+	if input := p.getInputReader(); p.lineReader == nil || p.lineReaderInput != input {
+		p.lineReader = bufio.NewReader(input)
+		p.lineReaderInput = input
+		p.lineReaderPending = nil
+	}
+	if p.lineReaderPending == nil {
+		reader, chPending := p.lineReader, make(chan lineRead, 1)
+		go func() {
+			line, err := reader.ReadString('\n')
+			chPending <- lineRead{line: line, err: err}
+		}()
+		p.lineReaderPending = chPending
+	}
+	chPending := p.lineReaderPending
+	p.lineReaderMutex.Unlock()
+
+	select {
+	case <-ctx.Done():
+		return "", ctx.Err()
+	case rsp := <-chPending:
+		p.lineReaderMutex.Lock()
+		if p.lineReaderPending == chPending {
+			p.lineReaderPending = nil
+		}
+		p.lineReaderMutex.Unlock()
+		return rsp.line, rsp.err
+	}
+}
+
+type lineRead struct {
+	line string
+	err  error
+}
+
+// promptNonInteractive reads lines from the input until the TerminationChecker
+// considers them a complete input, without rendering anything. History
+// commands are processed as they would be in the interactive mode. A complete
+// input with errors as per the Validator is returned as a DiagnosticsError as
+// there is no way to correct it. An input that is incomplete at the end of the
+// input is returned as is, and io.EOF is returned once there is nothing left
+// to read.
+func (p *prompt) promptNonInteractive(ctx context.Context) (string, error) {
+	output := p.getOutputWriter()
+	var lines []string
+	for {
+		if err := ctx.Err(); err != nil {
+			return "", err
+		}
+
+		line, err := p.readLine(ctx)
+		if err != nil && err != io.EOF {
+			return "", err
+		}
+		if err == io.EOF && line == "" {
+			if len(lines) == 0 {
+				return "", io.EOF
+			}
+			return strings.Join(lines, "\n"), nil
+		}
+		lines = append(lines, strings.TrimRight(line, "\r\n"))
+
+		input := strings.Join(lines, "\n")
+		if histCmd := p.processHistoryCommand(input); histCmd.Type != historyCommandNone {
+			switch histCmd.Type {
+			case historyCommandExec:
+				if cmd := p.history.Get(histCmd.Value - 1); cmd != "" {
+					return cmd, nil
+				}
+				_, _ = fmt.Fprintf(output, "ERROR: invalid command number: %v.\n", histCmd.Value)
+			case historyCommandList:
+				_, _ = fmt.Fprint(output, convertEscSeqs(p.history.Render(histCmd.Value, 0), termenv.Ascii))
+			}
+			lines = nil
+		} else if p.terminationChecker(input) {
+			if diagnostics := p.getDiagnostics(input); hasErrors(diagnostics) {
+				return "", &DiagnosticsError{Input: input, Diagnostics: diagnostics}
+			}
+			return input, nil
+		}
+	}
+}
+
+func isTerminal(v any) bool {
+	if f, ok := v.(interface{ Fd() uintptr }); ok {
+		return isatty.IsTerminal(f.Fd()) || isatty.IsCygwinTerminal(f.Fd())
+	}
+	return false
+}
@@ -0,0 +1,130 @@
+package prompt
+
+import (
+	"context"
+	"io"
+	"os"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPrompt_isInteractive(t *testing.T) {
+	inputReader, inputWriter, err := os.Pipe()
+	assert.Nil(t, err)
+	defer inputReader.Close()
+	defer inputWriter.Close()
+
+	p := prompt{}
+	p.SetInput(inputReader)
+	p.SetOutput(&strings.Builder{})
+	assert.False(t, p.isInteractive())
+	p.SetInput(strings.NewReader("foo"))
+	assert.True(t, p.isInteractive())
+	p.SetOutput(inputWriter)
+	assert.False(t, p.isInteractive())
+
+	p.SetInput(inputReader)
+	p.SetInteractive(true)
+	assert.True(t, p.isInteractive())
+	p.SetInteractive(false)
+	assert.False(t, p.isInteractive())
+}
+
+func TestPrompt_promptNonInteractive(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second*5)
+	defer cancel()
+
+	t.Run("sql", func(t *testing.T) {
+		p := generateTestPrompt(t, ctx)
+		out := strings.Builder{}
+		p.SetInput(strings.NewReader("select *\n  from foo;\r\nselect 1;\n\n!!\n!1\n!5\nselect 2"))
+		p.SetInteractive(false)
+		p.SetOutput(&out)
+		p.SetTerminationChecker(TerminationCheckerSQL())
+
+		userInput, err := p.Prompt(ctx)
+		assert.Nil(t, err)
+		assert.Equal(t, "select *\n  from foo;", userInput)
+		userInput, err = p.Prompt(ctx)
+		assert.Nil(t, err)
+		assert.Equal(t, "select 1;", userInput)
+		userInput, err = p.Prompt(ctx)
+		assert.Nil(t, err)
+		assert.Equal(t, "select *\n  from foo;", userInput)
+		userInput, err = p.Prompt(ctx)
+		assert.Nil(t, err)
+		assert.Equal(t, "select 2", userInput)
+		userInput, err = p.Prompt(ctx)
+		assert.Equal(t, io.EOF, err)
+		assert.Empty(t, userInput)
+
+		assert.Len(t, p.History(), 4)
+		assert.Contains(t, out.String(), "│ select 1;")
+		assert.Contains(t, out.String(), "ERROR: invalid command number: 5.\n")
+		assert.NotContains(t, out.String(), "\x1b")
+	})
+
+	t.Run("validator", func(t *testing.T) {
+		p := generateTestPrompt(t, ctx)
+		p.SetInput(strings.NewReader("selct 1;\nselect 2;\n"))
+		p.SetInteractive(false)
+		p.SetTerminationChecker(TerminationCheckerSQL())
+		p.SetValidator(func(input string) []Diagnostic {
+			if strings.HasPrefix(input, "selct") {
+				return []Diagnostic{{End: CursorLocation{Column: 5}, Message: "unknown keyword", Severity: DiagnosticSeverityError}}
+			}
+			return []Diagnostic{{Message: "no limit", Severity: DiagnosticSeverityWarning}}
+		})
+
+		userInput, err := p.Prompt(ctx)
+		assert.Empty(t, userInput)
+		assert.ErrorIs(t, err, ErrInvalidInput)
+		assert.EqualError(t, err, "invalid input: 1:1: unknown keyword")
+		var diagErr *DiagnosticsError
+		if assert.ErrorAs(t, err, &diagErr) {
+			assert.Equal(t, "selct 1;", diagErr.Input)
+			assert.Len(t, diagErr.Diagnostics, 1)
+		}
+		userInput, err = p.Prompt(ctx)
+		assert.Nil(t, err)
+		assert.Equal(t, "select 2;", userInput)
+	})
+
+	t.Run("context cancelled", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(ctx)
+		cancel()
+
+		p := generateTestPrompt(t, ctx)
+		p.SetInput(strings.NewReader("foo\n"))
+		p.SetInteractive(false)
+
+		userInput, err := p.Prompt(ctx)
+		assert.Empty(t, userInput)
+		assert.ErrorIs(t, err, context.Canceled)
+	})
+
+	t.Run("context cancelled while reading", func(t *testing.T) {
+		inputReader, inputWriter, err := os.Pipe()
+		assert.Nil(t, err)
+		defer inputReader.Close()
+		defer inputWriter.Close()
+
+		p := generateTestPrompt(t, ctx)
+		p.SetInput(inputReader)
+
+		ctxPrompt, cancelPrompt := context.WithTimeout(ctx, time.Second/10)
+		defer cancelPrompt()
+		userInput, err := p.Prompt(ctxPrompt)
+		assert.Empty(t, userInput)
+		assert.ErrorIs(t, err, context.DeadlineExceeded)
+
+		// the line read after the cancellation is not lost
+		_, _ = inputWriter.WriteString("foo\n")
+		userInput, err = p.Prompt(ctx)
+		assert.Nil(t, err)
+		assert.Equal(t, "foo", userInput)
+	})
+}
@@ -8,18 +8,44 @@ import (
 	"github.com/jedib0t/go-pretty/v6/text"
 )
 
-func (p *prompt) updateModel(isBeingEdited bool) {
+// updateModel generates the lines to be rendered. If this is the final render
+// of the prompt (one that is left behind on the terminal), and a transient
+// prefixer is set, the header, footer and line numbers are skipped and the
+// transient prefix is used instead of the usual one. In the full-screen mode,
+// the input is sized to fill up the terminal, and is followed by a status
+// line.
+//
+//gocyclo:ignore
+func (p *prompt) updateModel(isBeingEdited bool, isFinal ...bool) {
 	lines, cursorPos := p.buffer.Display()
+	isTransient := len(isFinal) > 0 && isFinal[0] && p.transientPrefixer != nil
+	isFullScreen := p.isFullScreen()
 
 	timeStart := time.Now()
 	var linesToRender []string
 
 	// header
-	if header := p.getHeader(); header != "" {
+	if header := p.getHeader(); header != "" && !isTransient {
 		for _, line := range strings.Split(header, "\n") {
 			linesToRender = append(linesToRender, line)
 		}
 	}
+	mm := &mouseMap{rowOffset: len(linesToRender)}
+
+	// validate and find the brackets to highlight
+	var brackets []CursorLocation
+	var diagnostics []Diagnostic
+	if isBeingEdited {
+		brackets = p.getBracketsToHighlight(lines, cursorPos)
+		diagnostics = p.getDiagnostics(strings.Join(lines, "\n"))
+	}
+
+	// footer
+	var footerLines []string
+	if footer := p.getFooter(); footer != "" && !isTransient {
+		footerLines = strings.Split(footer, "\n")
+	}
+	heightMin, heightMax := p.getHeightLimits(len(linesToRender) + len(diagnostics) + len(footerLines))
 
 	// syntax highlight
 	timeSyntaxStart := time.Now()
@@ -30,27 +56,49 @@ func (p *prompt) updateModel(isBeingEdited bool) {
 
 	// render the input lines
 	timeBufferStart := time.Now()
-	linesFromBuffer, startIdx := p.generateModelLines(lines, cursorPos, isBeingEdited)
+	linesFromBuffer, cursorRow := p.generateModelLines(lines, cursorPos, isBeingEdited, isTransient, brackets, diagnostics, heightMin, heightMax, mm)
 	timeBuffer := time.Since(timeBufferStart)
 
 	// auto-complete
 	timeAutoCompleteStart := time.Now()
 	if isBeingEdited {
-		linesToRender = append(linesToRender, p.autoComplete(linesFromBuffer, cursorPos, startIdx)...)
-	} else {
-		linesToRender = append(linesToRender, linesFromBuffer...)
+		linesFromBuffer = p.autoComplete(linesFromBuffer, cursorPos, cursorRow, mm)
+	}
+	if isFullScreen && len(linesFromBuffer) > heightMax { // keep everything else pinned in place
+		linesFromBuffer = linesFromBuffer[:heightMax]
 	}
+	linesToRender = append(linesToRender, linesFromBuffer...)
 	timeAutoComplete := time.Since(timeAutoCompleteStart)
 
+	// diagnostics
+	for _, diagnostic := range diagnostics {
+		linesToRender = append(linesToRender, p.generateDiagnosticLine(diagnostic, mm.prefixWidth))
+	}
+
+	// status line
+	if isFullScreen {
+		linesToRender = append(linesToRender, p.generateStatusLine())
+	}
+
 	// footer
-	if footer := p.getFooter(); footer != "" {
-		for _, line := range strings.Split(footer, "\n") {
-			linesToRender = append(linesToRender, line)
-		}
+	linesToRender = append(linesToRender, footerLines...)
+
+	// downsample the colors to the ones supported by the output
+	for idx, line := range linesToRender {
+		linesToRender[idx] = convertEscSeqs(line, p.activeColorProfile)
+	}
+
+	// locate the terminal's cursor
+	var terminalCursor *CursorLocation
+	if p.useTerminalCursor() {
+		linesToRender, terminalCursor = extractCursorMarker(linesToRender)
 	}
 
 	p.linesMutex.Lock()
 	p.linesToRender = linesToRender
+	p.terminalCursor = terminalCursor
+	p.terminalCursorShape = p.getCursorShape()
+	p.mouseMapToRender = mm
 	p.timeGen = time.Since(timeStart).Round(time.Microsecond)
 	p.timeSyntaxGen = timeSyntax.Round(time.Microsecond)
 	p.timeBufferGen = timeBuffer.Round(time.Microsecond)
@@ -58,24 +106,28 @@ func (p *prompt) updateModel(isBeingEdited bool) {
 	p.linesMutex.Unlock()
 }
 
-func (p *prompt) calculateLineStyling(lines []string) (prefix string, prefixWidth int, numColor Color, numLen int, numFmt string, numNone string) {
+func (p *prompt) calculateLineStyling(lines []string, isTransient bool) (prefix string, prefixWidth int, numColor Color, numLen int, numFmt string, numNone string) {
 	// get the line prefix
-	if p.prefixer != nil {
-		prefix = p.prefixer()
+	prefixer := p.prefixer
+	if isTransient {
+		prefixer = p.transientPrefixer
+	}
+	if prefixer != nil {
+		prefix = prefixer()
 		if prefix != "" {
 			prefixWidth += text.RuneWidthWithoutEscSequences(prefix)
 		}
 	}
 
 	// if enabled, get the lines number styling info
-	if p.style.LineNumbers.Enabled {
+	if lineNumbers := p.getLineNumbersStyle(); lineNumbers.Enabled && !isTransient {
 		numDigits := len(fmt.Sprint(len(lines)))
 		zeroPrefix := ""
-		if p.style.LineNumbers.ZeroPrefixed {
+		if lineNumbers.ZeroPrefixed {
 			zeroPrefix = "0"
 		}
 
-		numColor = p.style.LineNumbers.Color
+		numColor = lineNumbers.Color
 		numLen = 1 + numDigits + 1 // with padding
 		numFmt = fmt.Sprintf(" %%%s%dd ", zeroPrefix, numDigits)
 		numNone = numColor.Sprintf(fmt.Sprintf(" %%%ds ", numDigits), " ")
@@ -87,77 +139,265 @@ func (p *prompt) calculateLineStyling(lines []string) (prefix string, prefixWidt
 	return
 }
 
+// modelRow is a row of the input as it would be rendered on the terminal,
+// before being restricted to the viewport.
+type modelRow struct {
+	column      int // column within the line the row begins with
+	content     string
+	isFirst     bool // is the first row of the line?
+	lineIdx     int
+	mouseMapRow mouseMapRow
+}
+
 //gocyclo:ignore
-func (p *prompt) generateModelLines(lines []string, cursorPos CursorLocation, isBeingEdited bool) ([]string, int) {
+func (p *prompt) generateModelLines(lines []string, cursorPos CursorLocation, isBeingEdited bool, isTransient bool, brackets []CursorLocation, diagnostics []Diagnostic, heightMin int, heightMax int, mm *mouseMap) ([]string, int) {
 	// get the line styling
-	linePrefix, prefixWidth, lineNumColor, _, lineNumFmt, lineNumNone := p.calculateLineStyling(lines)
-
-	// restrict number of lines rendered if a max-height was set
-	start, stop := calculateViewportRange(len(lines), cursorPos.Line, int(p.style.Dimensions.HeightMax))
-	//if p.debug {
-	//	p.debugData["lines"] = fmt.Sprintf("%d-%d", start, stop)
-	//}
-	scrollbar, isScrollBarVisible := p.style.Scrollbar.Generate(
-		len(lines), cursorPos.Line, int(p.style.Dimensions.HeightMax),
-	)
+	linePrefix, prefixWidth, lineNumColor, _, lineNumFmt, lineNumNone := p.calculateLineStyling(lines, isTransient)
+	isLineNumbersEnabled := p.getLineNumbersStyle().Enabled
+	mm.heightMax, mm.prefixWidth = heightMax, prefixWidth
 
-	// calculate remaining width for actual content
-	remainingWidth := p.getDisplayWidth() - prefixWidth
-	if isScrollBarVisible {
-		remainingWidth -= 1
-	}
+	// get the selected text range to highlight
+	selStart, selEnd, isSelectionVisible := p.buffer.Selection()
+	isSelectionVisible = isSelectionVisible && isBeingEdited
 
-	// render the lines
-	linesOut := make([]string, 0)
+	// decorate the lines
+	linesDecorated := make([]string, len(lines))
 	for lineIdx, line := range lines {
-		// skip if out of viewport
-		if lineIdx < start || lineIdx > stop {
-			continue
+		// show the placeholder if there is no input
+		if isBeingEdited && p.placeholder != "" && len(lines) == 1 && line == "" {
+			line = p.style.Colors.Placeholder.Sprint(p.placeholder)
 		}
 
-		// insert cursor
-		if isBeingEdited && lineIdx == cursorPos.Line && p.style.Cursor.Enabled {
-			line = insertCursor(line, cursorPos.Column, p.getCursorColor())
+		// underline the problems found by the validator
+		for _, diagnostic := range diagnostics {
+			if lineIdx >= diagnostic.Start.Line && lineIdx <= diagnostic.End.Line {
+				underlineStart, underlineStop := 0, text.RuneWidthWithoutEscSequences(line)
+				if lineIdx == diagnostic.Start.Line {
+					underlineStart = diagnostic.Start.Column
+				}
+				if lineIdx == diagnostic.End.Line {
+					underlineStop = diagnostic.End.Column
+				}
+				if underlineStart == underlineStop { // highlight at least one character
+					underlineStop++
+				}
+				line = underlineRange(line, underlineStart, underlineStop)
+			}
 		}
 
-		// split line into multiple lines if longer than viewport width
-		subLines := []string{line}
-		if text.RuneWidthWithoutEscSequences(line) > remainingWidth {
-			subLines = strings.Split(p.widthEnforcer(line, remainingWidth), "\n")
+		// highlight the matching brackets
+		for _, bracket := range brackets {
+			if bracket.Line == lineIdx {
+				line = highlightRange(line, bracket.Column, bracket.Column+1, p.style.Brackets.Color)
+			}
 		}
-		for subLineIdx, subLine := range subLines {
-			out := strings.Builder{}
-			if linePrefix != "" {
-				_, _ = out.WriteString(linePrefix)
+
+		// highlight selection
+		if isSelectionVisible && lineIdx >= selStart.Line && lineIdx <= selEnd.Line {
+			highlightStart, highlightStop := 0, text.RuneWidthWithoutEscSequences(line)
+			if lineIdx == selStart.Line {
+				highlightStart = selStart.Column
 			}
-			if p.style.LineNumbers.Enabled {
-				if subLineIdx > 0 { // content continues into next physical line
-					_, _ = out.WriteString(lineNumNone)
-				} else {
-					_, _ = out.WriteString(lineNumColor.Sprintf(lineNumFmt, lineIdx+1))
-				}
-				_, _ = out.WriteString(" ") // margin
+			if lineIdx == selEnd.Line {
+				highlightStop = selEnd.Column
 			}
-			if isScrollBarVisible {
-				subLine = text.Pad(subLine, remainingWidth, ' ')
+			line = highlightRange(line, highlightStart, highlightStop, p.style.Colors.Selection)
+		}
+
+		// insert cursor
+		if isBeingEdited && lineIdx == cursorPos.Line && p.useTerminalCursor() {
+			line = insertCursorMarker(line, cursorPos.Column)
+		} else if isBeingEdited && lineIdx == cursorPos.Line && p.style.Cursor.Enabled {
+			line = insertCursor(line, cursorPos.Column, p.getCursorColor(), p.activeColorProfile)
+		}
+
+		linesDecorated[lineIdx] = line
+	}
+
+	// scroll the lines horizontally instead of wrapping them if enabled
+	isScrolling := p.style.HorizontalScroll.isActive(len(lines))
+	scrollOffset := 0
+
+	// splits lines into multiple rows if longer than the given width, or shows
+	// just the visible part if scrolling
+	generateRows := func(width int) ([]modelRow, int) {
+		rows, cursorRow := make([]modelRow, 0, len(lines)), 0
+		for lineIdx, line := range linesDecorated {
+			subLines := []string{line}
+			if isScrolling {
+				subLines[0] = p.style.HorizontalScroll.clip(line, scrollOffset, width)
+			} else if text.RuneWidthWithoutEscSequences(line) > width {
+				subLines = strings.Split(p.widthEnforcer(line, width), "\n")
 			}
-			_, _ = out.WriteString(fmt.Sprintf("%s", subLine))
-			if isScrollBarVisible {
-				_, _ = out.WriteString(scrollbar[lineIdx-start])
+			subLineColumn, indentWidths := 0, wrapIndentWidths(line, subLines)
+			for subLineIdx, subLine := range subLines {
+				// leave out the hanging indent (if any) when mapping to the input
+				indentWidth := indentWidths[subLineIdx]
+				row := modelRow{
+					column:      subLineColumn,
+					content:     subLine,
+					isFirst:     subLineIdx == 0,
+					lineIdx:     lineIdx,
+					mouseMapRow: mouseMapRow{Line: lineIdx, Column: scrollOffset + subLineColumn, Indent: indentWidth},
+				}
+				if lineIdx == cursorPos.Line && row.mouseMapRow.Column <= cursorPos.Column {
+					cursorRow = len(rows)
+				}
+				rows = append(rows, row)
+				subLineColumn += text.RuneWidthWithoutEscSequences(subLine) - indentWidth
 			}
+		}
+		return rows, cursorRow
+	}
+
+	// calculate remaining width for actual content, leaving room for the
+	// scrollbar if the rows do not fit within the max-height
+	remainingWidth := p.getDisplayWidth() - prefixWidth
+	numRows, cursorRow := len(lines), cursorPos.Line
+	if !isScrolling {
+		rows, row := generateRows(remainingWidth)
+		numRows, cursorRow = len(rows), row
+	}
+	scrollbar, isScrollBarVisible := p.style.Scrollbar.Generate(
+		numRows, cursorRow, heightMax,
+	)
+	if isScrollBarVisible {
+		remainingWidth -= 1
+	}
 
-			linesOut = append(linesOut, out.String())
+	// calculate the horizontal scroll offset to keep the cursor visible
+	if isScrolling && isBeingEdited {
+		contentWidth := 0
+		for _, line := range lines {
+			if lineWidth := text.RuneWidthWithoutEscSequences(line); lineWidth > contentWidth {
+				contentWidth = lineWidth
+			}
 		}
+		scrollOffset = calculateHorizontalScrollOffset(p.horizontalScrollOffset, cursorPos.Column,
+			contentWidth, remainingWidth, p.style.HorizontalScroll.Margin)
+	}
+	if isBeingEdited {
+		p.horizontalScrollOffset = scrollOffset
+	}
+	rows, cursorRow := generateRows(remainingWidth)
+	if isScrollBarVisible && len(rows) != numRows {
+		scrollbar, _ = p.style.Scrollbar.Generate(len(rows), cursorRow, heightMax)
+	}
+	for _, row := range rows {
+		mm.layout = append(mm.layout, row.mouseMapRow)
+	}
+
+	// restrict number of rows rendered if a max-height was set
+	start, stop := calculateViewportRange(len(rows), cursorRow, heightMax)
+
+	// get the right prompt (not shown on the transient render)
+	rightPrompt := ""
+	if p.rightPrompter != nil && !isTransient {
+		rightPrompt = p.rightPrompter()
+	}
+
+	// render the rows
+	linesOut := make([]string, 0)
+	for rowIdx := start; rowIdx <= stop; rowIdx++ {
+		row := rows[rowIdx]
+		mm.rows = append(mm.rows, row.mouseMapRow)
+
+		out := strings.Builder{}
+		_, _ = out.WriteString(p.generateLinePrefix(linePrefix, lines, row.lineIdx, row.column, isTransient))
+		if isLineNumbersEnabled && !isTransient {
+			if !row.isFirst { // content continues into next physical line
+				_, _ = out.WriteString(lineNumNone)
+			} else {
+				_, _ = out.WriteString(lineNumColor.Sprintf(lineNumFmt, row.lineIdx+1))
+			}
+			_, _ = out.WriteString(" ") // margin
+		}
+		subLine := row.content
+		if rightPrompt != "" && p.isRightPromptRow(rowIdx == start, rowIdx == stop) {
+			subLine = appendRightPrompt(subLine, rightPrompt, remainingWidth, p.style.RightPrompt.Margin)
+		} else if p.rightGutter != nil && row.isFirst && !isTransient {
+			if gutter := p.rightGutter(row.lineIdx, text.StripEscape(lines[row.lineIdx])); gutter != "" {
+				subLine = appendRightPrompt(subLine, gutter, remainingWidth, p.style.RightPrompt.Margin)
+			}
+		}
+		if isScrollBarVisible {
+			subLine = text.Pad(subLine, remainingWidth, ' ') + scrollbar[rowIdx-start]
+		}
+		_, _ = out.WriteString(subLine)
+		linesOut = append(linesOut, out.String())
 	}
 
 	// add empty lines if number of lines is less than minimum height
-	for p.style.Dimensions.HeightMin > 0 && len(linesOut) < int(p.style.Dimensions.HeightMin) {
-		if p.style.LineNumbers.Enabled {
+	for heightMin > 0 && !isTransient && len(linesOut) < heightMin {
+		mm.rows = append(mm.rows, mouseMapRow{Line: -1})
+		if isLineNumbersEnabled {
 			linesOut = append(linesOut, linePrefix+lineNumNone)
 		} else {
 			linesOut = append(linesOut, "")
 		}
 	}
 
-	return linesOut, start
+	return linesOut, cursorRow - start
+}
+
+// getLineNumbersStyle returns the style of the line numbers to render; the
+// full-screen mode always shows line numbers, with the default style if they
+// are not enabled in the Style.
+func (p *prompt) getLineNumbersStyle() StyleLineNumbers {
+	if p.isFullScreen() && !p.style.LineNumbers.Enabled {
+		return StyleLineNumbersEnabled
+	}
+	return p.style.LineNumbers
+}
+
+// generateDiagnosticLine returns the line describing the problem found by the
+// validator, indented to start where the input text starts.
+func (p *prompt) generateDiagnosticLine(diagnostic Diagnostic, indent int) string {
+	color := p.style.Colors.DiagnosticError
+	if diagnostic.Severity == DiagnosticSeverityWarning {
+		color = p.style.Colors.DiagnosticWarning
+	}
+	return strings.Repeat(" ", indent) + color.Sprintf("%d:%d: %s",
+		diagnostic.Start.Line+1, diagnostic.Start.Column+1, diagnostic.Message)
+}
+
+// generateLinePrefix returns the prefix for the row starting at the given
+// (visible) column of the given line; everything other than the very first row
+// gets the continuation prefix if one is set.
+func (p *prompt) generateLinePrefix(linePrefix string, lines []string, lineIdx int, column int, isTransient bool) string {
+	if p.continuationPrefixer == nil || isTransient || (lineIdx == 0 && column == 0) {
+		return linePrefix
+	}
+
+	// gather the text until the beginning of the row
+	textSoFar := strings.Builder{}
+	for _, line := range lines[:lineIdx] {
+		textSoFar.WriteString(text.StripEscape(line))
+		textSoFar.WriteRune('\n')
+	}
+	line := text.StripEscape(lines[lineIdx])
+	textSoFar.WriteString(line[:byteOffsetOfColumn(line, column)])
+
+	// keep the rest of the row aligned with the first one
+	prefix := p.continuationPrefixer(lineIdx, textSoFar.String())
+	prefixWidth := text.RuneWidthWithoutEscSequences(linePrefix)
+	return text.Pad(trimToWidth(prefix, prefixWidth), prefixWidth, ' ')
+}
+
+func (p *prompt) isRightPromptRow(isFirstRow bool, isLastRow bool) bool {
+	if p.style.RightPrompt.OnLastLine {
+		return isLastRow
+	}
+	return isFirstRow
+}
+
+// appendRightPrompt right-aligns the right prompt on the line if it fits
+// without overlapping the text (and the margin before it).
+func appendRightPrompt(line string, rightPrompt string, width int, margin int) string {
+	lineWidth := text.RuneWidthWithoutEscSequences(line)
+	rightPromptWidth := text.RuneWidthWithoutEscSequences(rightPrompt)
+	if lineWidth+margin+rightPromptWidth > width {
+		return line
+	}
+	return text.Pad(line, width-rightPromptWidth, ' ') + rightPrompt
 }
@@ -3,10 +3,10 @@ package prompt
 import (
 	"context"
 	"fmt"
-	"os"
 	"strings"
 	"testing"
 
+	"github.com/muesli/termenv"
 	"github.com/stretchr/testify/assert"
 )
 
@@ -43,7 +43,7 @@ func generateTestPrompt(t *testing.T, ctx context.Context) *prompt {
 	}
 	p.SetHistoryExecPrefix("!")
 	p.SetHistoryListPrefix("!!")
-	p.SetInput(os.Stdin)
+	p.SetInput(strings.NewReader(""))
 	p.SetOutput(&out)
 	p.SetPrefixer(PrefixText("[" + t.Name() + "] "))
 	p.SetRefreshInterval(DefaultRefreshInterval)
@@ -78,6 +78,30 @@ func TestPrompt_updateModel(t *testing.T) {
 		compareLines(t, expectedLines, p.linesToRender)
 	})
 
+	t.Run("simple one-liner with different color profiles", func(t *testing.T) {
+		p1 := generateTestPrompt(t, ctx)
+		p1.SetHeader(StyleDefault.Colors.Error.Sprint("header"))
+		p1.updateHeaderAndFooter()
+		p1.activeColorProfile = termenv.ANSI
+		p2 := generateTestPrompt(t, ctx)
+		p2.SetHeader(StyleDefault.Colors.Error.Sprint("header"))
+		p2.updateHeaderAndFooter()
+		p2.activeColorProfile = termenv.Ascii
+
+		p1.buffer.InsertString(`select` + ` * from dual`)
+		p2.buffer.InsertString(`select` + ` * from dual`)
+		p1.updateModel(true)
+		p2.updateModel(true)
+		compareLines(t, []string{
+			"\x1b[91mheader\x1b[0m",
+			"[TestPrompt_updateModel/simple_one-liner_with_different_color_profiles] select * from dual\x1b[30;46m \x1b[0m",
+		}, p1.linesToRender)
+		compareLines(t, []string{
+			"header",
+			"[TestPrompt_updateModel/simple_one-liner_with_different_color_profiles] select * from dual\x1b[7m \x1b[27m",
+		}, p2.linesToRender)
+	})
+
 	t.Run("simple one-liner with header and footer", func(t *testing.T) {
 		p := generateTestPrompt(t, ctx)
 		p.SetHeader("header")
@@ -107,6 +131,368 @@ func TestPrompt_updateModel(t *testing.T) {
 		compareLines(t, expectedLines, p.linesToRender)
 	})
 
+	t.Run("continuation prefix", func(t *testing.T) {
+		p := generateTestPrompt(t, ctx)
+		p.SetPrefix("sql> ")
+		p.Style().LineNumbers = StyleLineNumbersEnabled
+		p.Style().Dimensions.WidthMin = 20
+		p.Style().Dimensions.WidthMax = 20
+		p.init(ctx)
+
+		var calls []string
+		p.SetContinuationPrefixer(func(lineNum int, textSoFar string) string {
+			calls = append(calls, fmt.Sprintf("%d:%q", lineNum, textSoFar))
+			return "->"
+		})
+		p.buffer.InsertString("select (1,\n2) from dual")
+		p.updateModel(false)
+		expectedLines := []string{
+			"sql> \x1b[38;5;239;48;5;235m 1 \x1b[0m select (1,",
+			"->   \x1b[38;5;239;48;5;235m 2 \x1b[0m 2) from dua",
+			"->   \x1b[38;5;239;48;5;235m   \x1b[0m l",
+		}
+		compareLines(t, expectedLines, p.linesToRender)
+		assert.Equal(t, []string{`1:"select (1,\n"`, `1:"select (1,\n2) from dua"`}, calls)
+	})
+
+	t.Run("continuation prefix wider than the prefix", func(t *testing.T) {
+		p := generateTestPrompt(t, ctx)
+		p.SetPrefix("> ")
+		p.Style().Dimensions.WidthMin = 10
+		p.Style().Dimensions.WidthMax = 10
+		p.SetWidthEnforcer(WidthEnforcerWordWrap(""))
+		p.init(ctx)
+
+		var calls []string
+		p.SetContinuationPrefixer(func(lineNum int, textSoFar string) string {
+			calls = append(calls, fmt.Sprintf("%d:%q", lineNum, textSoFar))
+			return "\x1b[31m...> \x1b[0m"
+		})
+		p.buffer.InsertString("世界世界世界\nfoo")
+		p.updateModel(false)
+		expectedLines := []string{
+			"> 世界世界",
+			"\x1b[31m..\x1b[0m世界",
+			"\x1b[31m..\x1b[0mfoo",
+		}
+		compareLines(t, expectedLines, p.linesToRender)
+		assert.Equal(t, []string{`0:"世界世界"`, `1:"世界世界世界\n"`}, calls)
+	})
+
+	t.Run("placeholder", func(t *testing.T) {
+		p := generateTestPrompt(t, ctx)
+		p.SetPrefix("> ")
+		p.SetPlaceholder("type a query")
+		p.init(ctx)
+
+		p.updateModel(true)
+		expectedLines := []string{
+			"> \x1b[38;5;232;48;5;6mt\x1b[0m\x1b[38;5;242mype a query\x1b[0m",
+		}
+		compareLines(t, expectedLines, p.linesToRender)
+
+		// not shown once there is some input, or on the final render
+		p.buffer.InsertString("s")
+		p.updateModel(true)
+		expectedLines = []string{
+			"> s\x1b[38;5;232;48;5;6m \x1b[0m",
+		}
+		compareLines(t, expectedLines, p.linesToRender)
+		p.buffer.Reset()
+		p.updateModel(false)
+		expectedLines = []string{
+			"> ",
+		}
+		compareLines(t, expectedLines, p.linesToRender)
+	})
+
+	t.Run("horizontal scroll", func(t *testing.T) {
+		p := generateTestPrompt(t, ctx)
+		p.SetPrefix("> ")
+		p.Style().Dimensions.WidthMin = 12
+		p.Style().Dimensions.WidthMax = 12
+		p.Style().HorizontalScroll = StyleHorizontalScrollEnabled
+		p.Style().HorizontalScroll.Margin = 2
+		p.init(ctx)
+
+		p.buffer.InsertString("abcdefghijklmnopqrstuvwxyz0123")
+		p.updateModel(true)
+		expectedLines := []string{
+			"> \x1b[38;5;244m«\x1b[0mwxyz0123\x1b[38;5;232;48;5;6m \x1b[0m",
+		}
+		compareLines(t, expectedLines, p.linesToRender)
+		assert.Equal(t, 21, p.mouseMapToRender.rows[0].Column)
+
+		// moving left keeps the view until the cursor reaches the margin
+		p.buffer.MoveLeft(6)
+		p.updateModel(true)
+		expectedLines = []string{
+			"> \x1b[38;5;244m«\x1b[0mwx\x1b[38;5;232;48;5;6my\x1b[0mz0123",
+		}
+		compareLines(t, expectedLines, p.linesToRender)
+		p.buffer.MoveLeft(2)
+		p.updateModel(true)
+		expectedLines = []string{
+			"> \x1b[38;5;244m«\x1b[0mv\x1b[38;5;232;48;5;6mw\x1b[0mxyz0123",
+		}
+		compareLines(t, expectedLines, p.linesToRender)
+		p.buffer.MoveLeft(10)
+		p.updateModel(true)
+		expectedLines = []string{
+			"> \x1b[38;5;244m«\x1b[0ml\x1b[38;5;232;48;5;6mm\x1b[0mnopqrs\x1b[38;5;244m»\x1b[0m",
+		}
+		compareLines(t, expectedLines, p.linesToRender)
+
+		// the final render shows the beginning of the line
+		p.updateModel(false)
+		expectedLines = []string{
+			"> abcdefghi\x1b[38;5;244m»\x1b[0m",
+		}
+		compareLines(t, expectedLines, p.linesToRender)
+	})
+
+	t.Run("word wrap", func(t *testing.T) {
+		p := generateTestPrompt(t, ctx)
+		p.SetPrefix("> ")
+		p.SetWidthEnforcer(WidthEnforcerWordWrap("  "))
+		p.Style().Dimensions.WidthMin = 14
+		p.Style().Dimensions.WidthMax = 14
+		p.init(ctx)
+
+		p.buffer.InsertString("select a, bb from foo")
+		p.buffer.MoveLeft(10)
+		p.updateModel(true)
+		expectedLines := []string{
+			"> select a, ",
+			">   b\x1b[38;5;232;48;5;6mb\x1b[0m from ",
+			">   foo",
+		}
+		compareLines(t, expectedLines, p.linesToRender)
+		assert.Equal(t, []mouseMapRow{
+			{Line: 0, Column: 0},
+			{Line: 0, Column: 10, Indent: 2},
+			{Line: 0, Column: 18, Indent: 2},
+		}, p.mouseMapToRender.rows)
+	})
+
+	t.Run("word wrap with max height", func(t *testing.T) {
+		p := generateTestPrompt(t, ctx)
+		p.SetPrefix("> ")
+		p.SetWidthEnforcer(WidthEnforcerWordWrap("  "))
+		p.Style().Dimensions.HeightMax = 2
+		p.Style().Dimensions.WidthMin = 14
+		p.Style().Dimensions.WidthMax = 14
+		p.Style().Scrollbar.Color = Color{}
+		p.init(ctx)
+
+		p.buffer.InsertString("select a, bb from foo")
+		p.updateModel(true)
+		expectedLines := []string{
+			">   bb from  ░",
+			">   foo\x1b[38;5;232;48;5;6m \x1b[0m     █",
+		}
+		compareLines(t, expectedLines, p.linesToRender)
+		assert.Equal(t, []mouseMapRow{
+			{Line: 0, Column: 10, Indent: 2},
+			{Line: 0, Column: 18, Indent: 2},
+		}, p.mouseMapToRender.rows)
+		assert.Equal(t, []mouseMapRow{
+			{Line: 0, Column: 0},
+			{Line: 0, Column: 10, Indent: 2},
+			{Line: 0, Column: 18, Indent: 2},
+		}, p.mouseMapToRender.layout)
+	})
+
+	t.Run("matching brackets", func(t *testing.T) {
+		p := generateTestPrompt(t, ctx)
+		p.SetPrefix("> ")
+		p.init(ctx)
+
+		p.buffer.InsertString("f(x)")
+		p.updateModel(true)
+		expectedLines := []string{
+			"> f\x1b[38;5;231;48;5;240m(\x1b[0mx\x1b[38;5;231;48;5;240m)\x1b[0m\x1b[38;5;232;48;5;6m \x1b[0m",
+		}
+		compareLines(t, expectedLines, p.linesToRender)
+
+		// not highlighted when disabled, or on the final render
+		p.updateModel(false)
+		expectedLines = []string{
+			"> f(x)",
+		}
+		compareLines(t, expectedLines, p.linesToRender)
+		p.style.Brackets.Highlight = false
+		p.updateModel(true)
+		expectedLines = []string{
+			"> f(x)\x1b[38;5;232;48;5;6m \x1b[0m",
+		}
+		compareLines(t, expectedLines, p.linesToRender)
+	})
+
+	t.Run("validator", func(t *testing.T) {
+		p := generateTestPrompt(t, ctx)
+		p.SetPrefix("> ")
+		p.SetValidator(func(input string) []Diagnostic {
+			if strings.Contains(input, "dual") {
+				return nil
+			}
+			return []Diagnostic{
+				{Start: CursorLocation{Line: 0, Column: 0}, End: CursorLocation{Line: 0, Column: 6}, Message: "unknown keyword", Severity: DiagnosticSeverityError},
+				{Start: CursorLocation{Line: 1, Column: 5}, End: CursorLocation{Line: 1, Column: 5}, Message: "missing table", Severity: DiagnosticSeverityWarning},
+			}
+		})
+		p.init(ctx)
+
+		p.buffer.InsertString("selec *\nfrom ")
+		p.updateModel(true)
+		expectedLines := []string{
+			"> \x1b[4mselec \x1b[24m*",
+			"> from \x1b[38;5;232;48;5;6m \x1b[0m",
+			"  \x1b[38;5;9m1:1: unknown keyword\x1b[0m",
+			"  \x1b[38;5;214m2:6: missing table\x1b[0m",
+		}
+		compareLines(t, expectedLines, p.linesToRender)
+
+		p.buffer.InsertString("dual")
+		p.updateModel(true)
+		expectedLines = []string{
+			"> selec *",
+			"> from dual\x1b[38;5;232;48;5;6m \x1b[0m",
+		}
+		compareLines(t, expectedLines, p.linesToRender)
+	})
+
+	t.Run("right gutter", func(t *testing.T) {
+		p := generateTestPrompt(t, ctx)
+		p.SetPrefix("> ")
+		p.SetRightGutter(func(lineNum int, line string) string {
+			if strings.HasSuffix(line, ";") {
+				return fmt.Sprintf("[#%d ok]", lineNum+1)
+			}
+			return ""
+		})
+		p.Style().Dimensions.WidthMin = 20
+		p.Style().Dimensions.WidthMax = 20
+		p.Style().Dimensions.HeightMax = 3
+		p.init(ctx)
+
+		p.buffer.InsertString("foo;\nbar\nbaz;")
+		p.updateModel(false)
+		expectedLines := []string{
+			"> foo;       [#1 ok]",
+			"> bar",
+			"> baz;       [#3 ok]",
+		}
+		compareLines(t, expectedLines, p.linesToRender)
+
+		// hidden when overlapping the text, and left of the scrollbar
+		p.buffer.InsertString(" 1234567890;\nfoo;")
+		p.updateModel(false)
+		expectedLines = []string{
+			"> bar              \x1b[38;5;237;48;5;233m░\x1b[0m",
+			"> baz; 1234567890; \x1b[38;5;237;48;5;233m░\x1b[0m",
+			"> foo;      [#4 ok]\x1b[38;5;237;48;5;233m█\x1b[0m",
+		}
+		compareLines(t, expectedLines, p.linesToRender)
+
+		// the right prompt takes priority on its row
+		p.SetRightPrompt("[db]")
+		p.updateModel(false)
+		expectedLines = []string{
+			"> bar          [db]\x1b[38;5;237;48;5;233m░\x1b[0m",
+			"> baz; 1234567890; \x1b[38;5;237;48;5;233m░\x1b[0m",
+			"> foo;      [#4 ok]\x1b[38;5;237;48;5;233m█\x1b[0m",
+		}
+		compareLines(t, expectedLines, p.linesToRender)
+	})
+
+	t.Run("right prompt", func(t *testing.T) {
+		p := generateTestPrompt(t, ctx)
+		p.SetPrefix("> ")
+		p.SetRightPrompt("[12ms]")
+		p.Style().Dimensions.WidthMin = 20
+		p.Style().Dimensions.WidthMax = 20
+		p.init(ctx)
+
+		p.buffer.InsertString("foo\nbar")
+		p.updateModel(false)
+		expectedLines := []string{
+			"> foo         [12ms]",
+			"> bar",
+		}
+		compareLines(t, expectedLines, p.linesToRender)
+
+		p.Style().RightPrompt.OnLastLine = true
+		p.updateModel(false)
+		expectedLines = []string{
+			"> foo",
+			"> bar         [12ms]",
+		}
+		compareLines(t, expectedLines, p.linesToRender)
+
+		// hidden when overlapping the text
+		p.buffer.InsertString(" baz foo!")
+		p.updateModel(false)
+		expectedLines = []string{
+			"> foo",
+			"> bar baz foo!",
+		}
+		compareLines(t, expectedLines, p.linesToRender)
+
+		// shown on the last wrapped row if it fits
+		p.buffer.InsertString(" 1234567 x")
+		p.updateModel(false)
+		expectedLines = []string{
+			"> foo",
+			"> bar baz foo! 12345",
+			"> 67 x        [12ms]",
+		}
+		compareLines(t, expectedLines, p.linesToRender)
+
+		// not shown on the transient render
+		p.SetTransientPrefixer(PrefixText("❯ "))
+		p.updateModel(false, true)
+		expectedLines = []string{
+			"❯ foo",
+			"❯ bar baz foo! 12345",
+			"❯ 67 x",
+		}
+		compareLines(t, expectedLines, p.linesToRender)
+	})
+
+	t.Run("transient final render", func(t *testing.T) {
+		p := generateTestPrompt(t, ctx)
+		p.SetHeader("header")
+		p.SetFooter("footer")
+		p.SetTransientPrefixer(PrefixText("❯ "))
+		p.Style().LineNumbers = StyleLineNumbersEnabled
+		p.Style().Dimensions.HeightMin = 3
+		p.init(ctx)
+		p.updateHeaderAndFooter()
+
+		p.buffer.InsertString("select *\nfrom dual")
+		p.updateModel(false)
+		expectedLines := []string{
+			"header",
+			"[TestPrompt_updateModel/transient_final_render] \x1b[38;5;239;48;5;235m 1 \x1b[0m select *",
+			"[TestPrompt_updateModel/transient_final_render] \x1b[38;5;239;48;5;235m 2 \x1b[0m from dual",
+			"[TestPrompt_updateModel/transient_final_render] \x1b[38;5;239;48;5;235m   \x1b[0m",
+			"footer",
+		}
+		compareLines(t, expectedLines, p.linesToRender)
+
+		p.updateModel(false, true)
+		expectedLines = []string{
+			"❯ select *",
+			"❯ from dual",
+		}
+		compareLines(t, expectedLines, p.linesToRender)
+
+		p.SetTransientPrefixer(nil)
+		p.updateModel(false, true)
+		assert.Len(t, p.linesToRender, 5)
+	})
+
 	t.Run("simple one-liner with line-numbers and short-display-width", func(t *testing.T) {
 		p := generateTestPrompt(t, ctx)
 		p.Style().LineNumbers = StyleLineNumbersEnabled
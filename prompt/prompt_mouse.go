@@ -0,0 +1,188 @@
+package prompt
+
+import (
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/jedib0t/go-pretty/v6/text"
+	"github.com/jedib0t/go-prompter/input"
+	"github.com/muesli/termenv"
+)
+
+// mouseMapRow maps a rendered row to the buffer line (and the column within
+// that line) it begins with.
+type mouseMapRow struct {
+	Line   int // -1 if the row does not contain any text from the buffer
+	Column int
+	Indent int // width of the hanging indent before the text
+}
+
+// mouseMap contains everything needed to translate a mouse event on the
+// rendered prompt back into a location in the buffer or the drop-down.
+type mouseMap struct {
+	dropDownColumnStart int
+	dropDownColumnStop  int
+	dropDownRows        map[int]int   // row => suggestion index
+	layout              []mouseMapRow // all the rows, even those outside the viewport
+	heightMax           int           // max number of rows of the input; 0 if not limited
+	prefixWidth         int
+	rowOffset           int // number of header rows before the buffer
+	rows                []mouseMapRow
+}
+
+// dropDownIndexAt returns the index of the suggestion rendered at the given
+// row and column, if any.
+func (mm *mouseMap) dropDownIndexAt(row int, column int) (int, bool) {
+	if column < mm.dropDownColumnStart || column >= mm.dropDownColumnStop {
+		return 0, false
+	}
+	idx, ok := mm.dropDownRows[row-mm.rowOffset]
+	return idx, ok
+}
+
+// locationAt returns the buffer location rendered at the given row and column,
+// if any.
+func (mm *mouseMap) locationAt(row int, column int) (CursorLocation, bool) {
+	row -= mm.rowOffset
+	if row < 0 || row >= len(mm.rows) || mm.rows[row].Line < 0 {
+		return CursorLocation{}, false
+	}
+
+	column -= mm.prefixWidth + mm.rows[row].Indent
+	if column < 0 {
+		column = 0
+	}
+	return CursorLocation{
+		Line:   mm.rows[row].Line,
+		Column: mm.rows[row].Column + column,
+	}, true
+}
+
+// setDropDown records where the auto-complete drop-down got rendered, mimicking
+// the placement logic of overwriteContents.
+func (mm *mouseMap) setDropDown(dropDown []string, numSuggestions int, suggestionsIdx int, numItems int, firstRow int, insertIdx int, displayWidth int) {
+	if len(dropDown) == 0 {
+		return
+	}
+
+	// calculate the columns
+	width := text.RuneWidthWithoutEscSequences(dropDown[0])
+	if width >= displayWidth {
+		insertIdx, width = 0, displayWidth
+	}
+	for insertIdx > 0 && insertIdx+width > displayWidth {
+		insertIdx--
+	}
+	if insertIdx < 0 {
+		insertIdx = 0
+	}
+	mm.dropDownColumnStart, mm.dropDownColumnStop = insertIdx, insertIdx+width
+
+	// calculate the rows
+	if suggestionsIdx >= numSuggestions {
+		suggestionsIdx = 0
+	}
+	start, _ := calculateViewportRange(numSuggestions, suggestionsIdx, numItems)
+	mm.dropDownRows = make(map[int]int, len(dropDown))
+	for idx := range dropDown {
+		mm.dropDownRows[firstRow+idx] = start + idx
+	}
+}
+
+// handleMouse queues up the mouse event and asks the terminal for the cursor
+// position to be able to tell which row of the prompt got clicked. In the
+// full-screen mode, the prompt begins on the first row, and so the event is
+// handled right away.
+func (p *prompt) handleMouse(output *termenv.Output, mouse tea.MouseMsg) error {
+	if p.isRenderPaused() {
+		return nil
+	}
+	if p.isFullScreen() {
+		p.linesMutex.Lock()
+		mm := p.mouseMapRendered
+		p.linesMutex.Unlock()
+
+		if mm == nil {
+			return nil
+		}
+		return p.handleMouseEvent(output, mouse, mm, mouse.Y)
+	}
+
+	p.linesMutex.Lock()
+	defer p.linesMutex.Unlock()
+
+	if len(p.mouseEventsPending) == 0 {
+		p.mouseQueryRows = len(p.linesRendered) - p.terminalCursorRowsUp
+		if p.debug {
+			p.mouseQueryRows++
+		}
+		_, _ = output.WriteString(input.QueryCursorPosition)
+	}
+	p.mouseEventsPending = append(p.mouseEventsPending, mouse)
+	return nil
+}
+
+// handleMouseCursorPosition processes all the queued up mouse events now that
+// the location of the prompt on the terminal is known.
+func (p *prompt) handleMouseCursorPosition(output *termenv.Output, cursorPosition input.CursorPositionMsg) error {
+	p.linesMutex.Lock()
+	events, mm := p.mouseEventsPending, p.mouseMapRendered
+	topRow := cursorPosition.Row - p.mouseQueryRows
+	p.mouseEventsPending = nil
+	p.linesMutex.Unlock()
+
+	if mm == nil {
+		return nil
+	}
+	for _, mouse := range events {
+		if err := p.handleMouseEvent(output, mouse, mm, mouse.Y-topRow); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// handleMouseEvent handles a single mouse event on the given row of the
+// rendered prompt (0 being the first row).
+func (p *prompt) handleMouseEvent(output *termenv.Output, mouse tea.MouseMsg, mm *mouseMap, row int) error {
+	switch {
+	case mouse.Button == tea.MouseButtonWheelUp || mouse.Button == tea.MouseButtonWheelDown:
+		isUp := mouse.Button == tea.MouseButtonWheelUp
+		if _, ok := mm.dropDownIndexAt(row, mouse.X); ok && p.isInAutoComplete {
+			if isUp {
+				return autoCompleteActionHandlerMap[AutoCompleteChoosePrevious](p, output, tea.KeyMsg{})
+			}
+			return autoCompleteActionHandlerMap[AutoCompleteChooseNext](p, output, tea.KeyMsg{})
+		}
+		// scroll the viewport (by moving the cursor) only if it is limited
+		heightMax := int(p.style.Dimensions.HeightMax)
+		if p.isFullScreen() {
+			heightMax = mm.heightMax
+		}
+		if heightMax > 0 && len(mm.layout) > heightMax {
+			if isUp && !p.moveToVisualRow(-1) {
+				p.buffer.MoveUp(1)
+			} else if !isUp && !p.moveToVisualRow(1) {
+				p.buffer.MoveDown(1)
+			}
+		}
+	case mouse.Button == tea.MouseButtonLeft && mouse.Action == tea.MouseActionPress:
+		if idx, ok := mm.dropDownIndexAt(row, mouse.X); ok && p.isInAutoComplete {
+			p.setSuggestionsIdx(idx)
+			return autoCompleteActionHandlerMap[AutoCompleteSelect](p, output, tea.KeyMsg{})
+		}
+		if location, ok := mm.locationAt(row, mouse.X); ok {
+			p.buffer.ClearSelection()
+			p.buffer.MoveTo(location)
+			p.buffer.StartSelection()
+		}
+	case mouse.Button == tea.MouseButtonLeft && mouse.Action == tea.MouseActionMotion:
+		if location, ok := mm.locationAt(row, mouse.X); ok {
+			p.buffer.StartSelection()
+			p.buffer.MoveTo(location)
+		}
+	case mouse.Action == tea.MouseActionRelease:
+		if !p.buffer.HasSelection() {
+			p.buffer.ClearSelection()
+		}
+	}
+	return nil
+}
@@ -0,0 +1,211 @@
+package prompt
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/jedib0t/go-prompter/input"
+	"github.com/muesli/termenv"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMouseMap_dropDownIndexAt(t *testing.T) {
+	mm := &mouseMap{rowOffset: 1}
+	mm.setDropDown([]string{"  foo  ", "  bar  "}, 5, 4, 2, 1, 10, 80)
+	assert.Equal(t, 10, mm.dropDownColumnStart)
+	assert.Equal(t, 17, mm.dropDownColumnStop)
+
+	idx, ok := mm.dropDownIndexAt(2, 10)
+	assert.True(t, ok)
+	assert.Equal(t, 3, idx)
+	idx, ok = mm.dropDownIndexAt(3, 16)
+	assert.True(t, ok)
+	assert.Equal(t, 4, idx)
+	_, ok = mm.dropDownIndexAt(3, 17)
+	assert.False(t, ok)
+	_, ok = mm.dropDownIndexAt(1, 12)
+	assert.False(t, ok)
+
+	// moved left to fit within the display width
+	mm.setDropDown([]string{"  foo  "}, 1, 0, 2, 1, 10, 15)
+	assert.Equal(t, 8, mm.dropDownColumnStart)
+	assert.Equal(t, 15, mm.dropDownColumnStop)
+}
+
+func TestMouseMap_locationAt(t *testing.T) {
+	mm := &mouseMap{
+		prefixWidth: 2,
+		rowOffset:   1,
+		rows: []mouseMapRow{
+			{Line: 0, Column: 0},
+			{Line: 1, Column: 0},
+			{Line: 1, Column: 10},
+			{Line: -1},
+			{Line: 2, Column: 8, Indent: 2},
+		},
+	}
+
+	for _, row := range []int{0, 4, 6} {
+		_, ok := mm.locationAt(row, 5)
+		assert.False(t, ok, row)
+	}
+
+	location, ok := mm.locationAt(1, 5)
+	assert.True(t, ok)
+	assert.Equal(t, CursorLocation{Line: 0, Column: 3}, location)
+	location, ok = mm.locationAt(2, 0)
+	assert.True(t, ok)
+	assert.Equal(t, CursorLocation{Line: 1, Column: 0}, location)
+	location, ok = mm.locationAt(3, 5)
+	assert.True(t, ok)
+	assert.Equal(t, CursorLocation{Line: 1, Column: 13}, location)
+	location, ok = mm.locationAt(5, 3)
+	assert.True(t, ok)
+	assert.Equal(t, CursorLocation{Line: 2, Column: 8}, location)
+	location, ok = mm.locationAt(5, 6)
+	assert.True(t, ok)
+	assert.Equal(t, CursorLocation{Line: 2, Column: 10}, location)
+}
+
+func TestPrompt_handleMouse(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second*5)
+	defer cancel()
+
+	testText := "foo\nbar baz\nqux"
+	topRow := 10
+	generateTestPromptWithMouse := func(t *testing.T, text string) (*prompt, *strings.Builder, *termenv.Output) {
+		p := generateTestPromptWithBuffer(t, ctx, text, CursorLocation{0, 0})
+		p.SetPrefixer(PrefixText("> "))
+		p.SetHeader("header")
+		p.updateHeaderAndFooter()
+
+		out := &strings.Builder{}
+		output := termenv.NewOutput(out)
+		p.updateModel(true)
+		p.renderView(output, "test")
+		out.Reset()
+		return p, out, output
+	}
+	sendMouse := func(p *prompt, output *termenv.Output, x, y int, button tea.MouseButton, action tea.MouseAction) error {
+		p.handleMouse(output, tea.MouseMsg{X: x, Y: topRow + y, Button: button, Action: action})
+		return p.handleMouseCursorPosition(output, input.CursorPositionMsg{Row: topRow + len(p.linesRendered)})
+	}
+
+	t.Run("queries cursor position once", func(t *testing.T) {
+		p, out, output := generateTestPromptWithMouse(t, testText)
+
+		p.handleMouse(output, tea.MouseMsg{X: 5, Y: 2, Button: tea.MouseButtonLeft, Action: tea.MouseActionPress})
+		p.handleMouse(output, tea.MouseMsg{X: 5, Y: 2, Button: tea.MouseButtonLeft, Action: tea.MouseActionRelease})
+		assert.Equal(t, input.QueryCursorPosition, out.String())
+		assert.Len(t, p.mouseEventsPending, 2)
+
+		err := p.handleMouseCursorPosition(output, input.CursorPositionMsg{Row: 1 + len(p.linesRendered)})
+		assert.Nil(t, err)
+		assert.Len(t, p.mouseEventsPending, 0)
+		assert.Equal(t, CursorLocation{Line: 0, Column: 3}, p.buffer.Cursor())
+	})
+
+	t.Run("click to place cursor", func(t *testing.T) {
+		p, _, output := generateTestPromptWithMouse(t, testText)
+
+		assert.Nil(t, sendMouse(p, output, 6, 2, tea.MouseButtonLeft, tea.MouseActionPress))
+		assert.Nil(t, sendMouse(p, output, 6, 2, tea.MouseButtonLeft, tea.MouseActionRelease))
+		assert.Equal(t, CursorLocation{Line: 1, Column: 4}, p.buffer.Cursor())
+		assert.False(t, p.buffer.HasSelection())
+
+		// beyond the end of the line
+		assert.Nil(t, sendMouse(p, output, 50, 3, tea.MouseButtonLeft, tea.MouseActionPress))
+		assert.Equal(t, CursorLocation{Line: 2, Column: 3}, p.buffer.Cursor())
+
+		// on the header
+		assert.Nil(t, sendMouse(p, output, 3, 0, tea.MouseButtonLeft, tea.MouseActionPress))
+		assert.Equal(t, CursorLocation{Line: 2, Column: 3}, p.buffer.Cursor())
+	})
+
+	t.Run("click on wrapped line", func(t *testing.T) {
+		p, _, output := generateTestPromptWithMouse(t, strings.Repeat("a", 150)+"\nb")
+
+		assert.Nil(t, sendMouse(p, output, 7, 2, tea.MouseButtonLeft, tea.MouseActionPress))
+		assert.Equal(t, 0, p.buffer.Cursor().Line)
+		assert.Equal(t, p.mouseMapRendered.rows[1].Column+5, p.buffer.Cursor().Column)
+		assert.Nil(t, sendMouse(p, output, 2, 3, tea.MouseButtonLeft, tea.MouseActionPress))
+		assert.Equal(t, CursorLocation{Line: 1, Column: 0}, p.buffer.Cursor())
+	})
+
+	t.Run("drag to select", func(t *testing.T) {
+		p, _, output := generateTestPromptWithMouse(t, testText)
+
+		assert.Nil(t, sendMouse(p, output, 3, 1, tea.MouseButtonLeft, tea.MouseActionPress))
+		assert.Nil(t, sendMouse(p, output, 5, 2, tea.MouseButtonLeft, tea.MouseActionMotion))
+		assert.Nil(t, sendMouse(p, output, 5, 2, tea.MouseButtonNone, tea.MouseActionRelease))
+		assert.True(t, p.buffer.HasSelection())
+		assert.Equal(t, "oo\nbar", p.buffer.SelectionText())
+	})
+
+	t.Run("wheel scrolls lines", func(t *testing.T) {
+		p, _, output := generateTestPromptWithMouse(t, testText)
+
+		assert.Nil(t, sendMouse(p, output, 3, 1, tea.MouseButtonWheelDown, tea.MouseActionPress))
+		assert.Equal(t, 0, p.buffer.Cursor().Line)
+
+		p.style.Dimensions.HeightMax = 2
+		assert.Nil(t, sendMouse(p, output, 3, 1, tea.MouseButtonWheelDown, tea.MouseActionPress))
+		assert.Equal(t, 1, p.buffer.Cursor().Line)
+		assert.Nil(t, sendMouse(p, output, 3, 1, tea.MouseButtonWheelUp, tea.MouseActionPress))
+		assert.Equal(t, 0, p.buffer.Cursor().Line)
+	})
+
+	t.Run("drop-down", func(t *testing.T) {
+		p, _, output := generateTestPromptWithMouse(t, "auto")
+		p.buffer.MoveToEnd()
+		p.suggestions = append(p.suggestions, testSuggestions...)
+		p.suggestionsIdx = 0
+		p.updateModel(true)
+		p.renderView(output, "test")
+		assert.True(t, p.isInAutoComplete)
+		column := p.mouseMapRendered.dropDownColumnStart
+
+		// wheel over the drop-down
+		assert.Nil(t, sendMouse(p, output, column, 2, tea.MouseButtonWheelDown, tea.MouseActionPress))
+		assert.Equal(t, 1, p.suggestionsIdx)
+		assert.Nil(t, sendMouse(p, output, column, 2, tea.MouseButtonWheelUp, tea.MouseActionPress))
+		assert.Equal(t, 0, p.suggestionsIdx)
+
+		// click on the second suggestion
+		assert.Nil(t, sendMouse(p, output, column, 3, tea.MouseButtonLeft, tea.MouseActionPress))
+		assert.Equal(t, "auto-complete-2 ", p.buffer.String())
+	})
+
+	t.Run("full screen", func(t *testing.T) {
+		p, out, output := generateTestPromptWithMouse(t, testText+"\n4\n5\n6\n7\n8")
+		p.setFullScreen(true)
+		p.updateDisplayHeight(5) // header, 3 rows of input, status line
+		p.updateModel(true)
+		p.renderView(output, "test")
+		out.Reset()
+
+		// the prompt is at the top of the screen; no need to look for it
+		assert.Nil(t, p.handleMouse(output, tea.MouseMsg{X: 9, Y: 2, Button: tea.MouseButtonLeft, Action: tea.MouseActionPress}))
+		assert.Empty(t, out.String())
+		assert.Equal(t, CursorLocation{Line: 1, Column: 3}, p.buffer.Cursor())
+
+		// the wheel scrolls the input as it does not fit on the screen
+		assert.Nil(t, p.handleMouse(output, tea.MouseMsg{X: 9, Y: 2, Button: tea.MouseButtonWheelDown, Action: tea.MouseActionPress}))
+		assert.Equal(t, 2, p.buffer.Cursor().Line)
+	})
+}
+
+func TestPrompt_SetMouseSupport(t *testing.T) {
+	p := &prompt{}
+	assert.False(t, p.mouseSupport)
+
+	p.SetMouseSupport(true)
+	assert.True(t, p.mouseSupport)
+	assert.NotNil(t, p.reader)
+
+	p.SetMouseSupport(false)
+	assert.False(t, p.mouseSupport)
+}
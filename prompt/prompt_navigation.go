@@ -0,0 +1,86 @@
+package prompt
+
+// getVisualRows returns the rows of the input as they were last laid out for
+// rendering (with long lines wrapped), and the index of the row the cursor is
+// on; the index is -1 if the layout is not known.
+func (p *prompt) getVisualRows() ([]mouseMapRow, CursorLocation, int) {
+	// lay out the input again if it changed since the last render
+	if p.buffer.HasChanges() {
+		p.updateModel(true)
+	}
+
+	p.linesMutex.Lock()
+	var layout []mouseMapRow
+	if p.mouseMapToRender != nil {
+		layout = p.mouseMapToRender.layout
+	}
+	p.linesMutex.Unlock()
+
+	cursor, rowIdx := p.buffer.Cursor(), -1
+	for idx, row := range layout {
+		if row.Line == cursor.Line && row.Column <= cursor.Column {
+			rowIdx = idx
+		}
+	}
+	return layout, cursor, rowIdx
+}
+
+// moveToVisualRow moves the cursor to the row that many rows above (negative)
+// or below it as rendered on the terminal, keeping the cursor on the same
+// column on the screen as far as possible. Returns false if the cursor could
+// not be moved, like when the layout is not known or there is no such row.
+func (p *prompt) moveToVisualRow(numRows int) bool {
+	layout, cursor, rowIdx := p.getVisualRows()
+	targetIdx := rowIdx + numRows
+	if rowIdx < 0 || targetIdx < 0 || targetIdx >= len(layout) {
+		return false
+	}
+
+	from, to := layout[rowIdx], layout[targetIdx]
+	column := to.Column + (cursor.Column - from.Column + from.Indent) - to.Indent
+	if column < to.Column {
+		column = to.Column
+	}
+	if end := visualRowEnd(layout, targetIdx, p.buffer.Lines()); column > end {
+		column = end
+	}
+	p.buffer.MoveTo(CursorLocation{Line: to.Line, Column: column})
+	return true
+}
+
+// moveToVisualRowEdge moves the cursor to the beginning (or the end) of the
+// wrapped row it is on. Returns false if the line is not wrapped or if the
+// cursor is already there, so that the caller can move to the beginning (or
+// the end) of the line instead.
+func (p *prompt) moveToVisualRowEdge(toEnd bool) bool {
+	layout, cursor, rowIdx := p.getVisualRows()
+	if rowIdx < 0 {
+		return false
+	}
+
+	row := layout[rowIdx]
+	isWrapped := (rowIdx > 0 && layout[rowIdx-1].Line == row.Line) ||
+		(rowIdx < len(layout)-1 && layout[rowIdx+1].Line == row.Line)
+	column := row.Column
+	if toEnd {
+		column = visualRowEnd(layout, rowIdx, p.buffer.Lines())
+	}
+	if !isWrapped || cursor.Column == column {
+		return false
+	}
+	p.buffer.MoveTo(CursorLocation{Line: row.Line, Column: column})
+	return true
+}
+
+// visualRowEnd returns the last column the cursor can be on without moving to
+// the next row: the last character of a wrapped row, or the end of the line.
+func visualRowEnd(layout []mouseMapRow, rowIdx int, lines []string) int {
+	row := layout[rowIdx]
+	if rowIdx < len(layout)-1 && layout[rowIdx+1].Line == row.Line {
+		return layout[rowIdx+1].Column - 1
+	}
+	if row.Line < len(lines) {
+		return len(lines[row.Line])
+	}
+	return row.Column
+}
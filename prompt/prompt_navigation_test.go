@@ -0,0 +1,87 @@
+package prompt
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPrompt_moveToVisualRow(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second*5)
+	defer cancel()
+
+	p := generateTestPrompt(t, ctx)
+	p.SetPrefix("> ")
+	p.SetWidthEnforcer(WidthEnforcerWordWrap("  "))
+	p.Style().Dimensions.WidthMin = 14
+	p.Style().Dimensions.WidthMax = 14
+	p.init(ctx)
+
+	// rows: "select a, " / "  bb from " / "  foo"
+	p.buffer.InsertString("select a, bb from foo\nxy")
+	p.buffer.MoveTo(CursorLocation{Line: 0, Column: 3})
+	assert.True(t, p.moveToVisualRow(1))
+	assert.Equal(t, CursorLocation{Line: 0, Column: 11}, p.buffer.Cursor())
+	assert.True(t, p.moveToVisualRow(1))
+	assert.Equal(t, CursorLocation{Line: 0, Column: 19}, p.buffer.Cursor())
+	assert.True(t, p.moveToVisualRow(1))
+	assert.Equal(t, CursorLocation{Line: 1, Column: 2}, p.buffer.Cursor())
+	assert.False(t, p.moveToVisualRow(1))
+
+	// columns within the hanging indent, or beyond the row, get clamped
+	p.buffer.MoveTo(CursorLocation{Line: 0, Column: 0})
+	assert.True(t, p.moveToVisualRow(1))
+	assert.Equal(t, CursorLocation{Line: 0, Column: 10}, p.buffer.Cursor())
+	p.buffer.MoveTo(CursorLocation{Line: 0, Column: 9})
+	assert.True(t, p.moveToVisualRow(2))
+	assert.Equal(t, CursorLocation{Line: 0, Column: 21}, p.buffer.Cursor())
+	assert.True(t, p.moveToVisualRow(-2))
+	assert.Equal(t, CursorLocation{Line: 0, Column: 5}, p.buffer.Cursor())
+	assert.False(t, p.moveToVisualRow(-1))
+
+	// via the actions
+	p.buffer.MoveTo(CursorLocation{Line: 0, Column: 14})
+	assert.Nil(t, insertActionHandlerMap[MoveUpOneLine](p, nil, tea.KeyMsg{}))
+	assert.Equal(t, CursorLocation{Line: 0, Column: 6}, p.buffer.Cursor())
+	assert.Nil(t, insertActionHandlerMap[MoveDownOneLine](p, nil, tea.KeyMsg{}))
+	assert.Equal(t, CursorLocation{Line: 0, Column: 14}, p.buffer.Cursor())
+}
+
+func TestPrompt_moveToVisualRowEdge(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second*5)
+	defer cancel()
+
+	p := generateTestPrompt(t, ctx)
+	p.SetPrefix("> ")
+	p.SetWidthEnforcer(WidthEnforcerWordWrap("  "))
+	p.Style().Dimensions.WidthMin = 14
+	p.Style().Dimensions.WidthMax = 14
+	p.init(ctx)
+
+	p.buffer.InsertString("select a, bb from foo\nxy")
+	p.buffer.MoveTo(CursorLocation{Line: 0, Column: 14})
+
+	// disabled by default
+	assert.Nil(t, insertActionHandlerMap[MoveToEndOfLine](p, nil, tea.KeyMsg{}))
+	assert.Equal(t, CursorLocation{Line: 0, Column: 21}, p.buffer.Cursor())
+
+	p.SetVisualHomeEnd(true)
+	p.buffer.MoveTo(CursorLocation{Line: 0, Column: 14})
+	assert.Nil(t, insertActionHandlerMap[MoveToEndOfLine](p, nil, tea.KeyMsg{}))
+	assert.Equal(t, CursorLocation{Line: 0, Column: 17}, p.buffer.Cursor())
+	assert.Nil(t, insertActionHandlerMap[MoveToEndOfLine](p, nil, tea.KeyMsg{}))
+	assert.Equal(t, CursorLocation{Line: 0, Column: 21}, p.buffer.Cursor())
+	assert.Nil(t, insertActionHandlerMap[MoveToBeginningOfLine](p, nil, tea.KeyMsg{}))
+	assert.Equal(t, CursorLocation{Line: 0, Column: 18}, p.buffer.Cursor())
+	assert.Nil(t, insertActionHandlerMap[MoveToBeginningOfLine](p, nil, tea.KeyMsg{}))
+	assert.Equal(t, CursorLocation{Line: 0, Column: 0}, p.buffer.Cursor())
+
+	// lines that are not wrapped behave as usual
+	p.buffer.MoveTo(CursorLocation{Line: 1, Column: 1})
+	assert.False(t, p.moveToVisualRowEdge(false))
+	assert.False(t, p.moveToVisualRowEdge(true))
+}
@@ -0,0 +1,29 @@
+package prompt
+
+// PromptOption customizes the behavior of a single call to Prompt.
+type PromptOption func(o *promptOptions)
+
+type promptOptions struct {
+	fullScreen bool
+}
+
+func newPromptOptions(opts ...PromptOption) promptOptions {
+	o := promptOptions{}
+	for _, opt := range opts {
+		opt(&o)
+	}
+	return o
+}
+
+// WithFullScreen switches to the alternate screen buffer and uses the whole
+// terminal as an editor: the header and the footer are pinned to the top and
+// the bottom, and the input fills up the rest with line numbers, a scrollbar
+// and a status line showing the location of the cursor and whether the input
+// was modified. The normal screen is restored with the final input echoed on
+// it once the user is done (or aborts). This has no effect in the accessible
+// mode, or when not on a terminal.
+func WithFullScreen() PromptOption {
+	return func(o *promptOptions) {
+		o.fullScreen = true
+	}
+}
@@ -0,0 +1,12 @@
+package prompt
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestWithFullScreen(t *testing.T) {
+	assert.False(t, newPromptOptions().fullScreen)
+	assert.True(t, newPromptOptions(WithFullScreen()).fullScreen)
+}
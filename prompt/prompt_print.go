@@ -0,0 +1,61 @@
+package prompt
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/muesli/termenv"
+)
+
+// printWriter is an io.Writer that prints everything above the active prompt.
+type printWriter struct {
+	p *prompt
+}
+
+// Write prints the given bytes above the active prompt.
+func (pw *printWriter) Write(b []byte) (int, error) {
+	pw.p.printAbove(string(b))
+	return len(b), nil
+}
+
+// printAbove erases the rendered prompt (if any), prints the text in its place
+// and lets the next render cycle draw the prompt again below the text.
+func (p *prompt) printAbove(str string) {
+	if str == "" {
+		return
+	}
+	if !strings.HasSuffix(str, "\n") {
+		str += "\n"
+	}
+
+	p.linesMutex.Lock()
+	defer p.linesMutex.Unlock()
+
+	if p.IsActive() && p.isFullScreen() {
+		// print it on the normal screen once the prompt is done with the
+		// alternate screen
+		p.fullScreenPrinted = append(p.fullScreenPrinted, str)
+		return
+	}
+
+	output := p.getOutput(p.IsActive() && !p.isRenderPaused())
+	if p.IsActive() && !p.isRenderPaused() && p.isAccessible() {
+		// leave the echoed input as is, and start afresh on a new line
+		if p.accessiblePrompted {
+			_, _ = output.WriteString("\n")
+		}
+		p.resetAccessibleState()
+	} else if p.IsActive() && !p.isRenderPaused() {
+		p.moveTerminalCursorToBottom(output)
+		numLinesRendered := len(p.linesRendered)
+		if p.debug && numLinesRendered > 0 { // for the final debug footer
+			numLinesRendered++
+		}
+		if numLinesRendered > 0 {
+			output.CursorUp(numLinesRendered)
+			_, _ = output.WriteString(fmt.Sprintf(termenv.CSI+termenv.EraseDisplaySeq, 0))
+		}
+		p.linesRendered = make([]string, 0)
+	}
+	_, _ = output.WriteString(str)
+}
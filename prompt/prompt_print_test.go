@@ -0,0 +1,104 @@
+package prompt
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPrompt_Print(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second*5)
+	defer cancel()
+
+	t.Run("inactive", func(t *testing.T) {
+		p := generateTestPrompt(t, ctx)
+		out := strings.Builder{}
+		p.SetOutput(&out)
+
+		p.Print("foo", 1)
+		p.Printf("bar %d\n", 2)
+		p.Print("")
+		assert.Equal(t, "foo1\nbar 2\n", out.String())
+	})
+
+	t.Run("active", func(t *testing.T) {
+		p := generateTestPrompt(t, ctx)
+		out := strings.Builder{}
+		p.SetOutput(&out)
+		p.markActive()
+		defer p.markInactive()
+		p.linesRendered = []string{"> foo", "> bar"}
+
+		p.Print("notification")
+		assert.Equal(t, "\x1b[2A\x1b[0Jnotification\r\n", out.String())
+		assert.Empty(t, p.linesRendered)
+
+		// nothing rendered yet
+		out.Reset()
+		p.Print("another notification")
+		assert.Equal(t, "another notification\r\n", out.String())
+	})
+
+	t.Run("active with debug", func(t *testing.T) {
+		p := generateTestPrompt(t, ctx)
+		out := strings.Builder{}
+		p.SetOutput(&out)
+		p.SetDebug(true)
+		p.markActive()
+		defer p.markInactive()
+		p.linesRendered = []string{"> foo"}
+
+		p.Print("notification")
+		assert.Equal(t, "\x1b[2A\x1b[0Jnotification\r\n", out.String())
+	})
+
+	t.Run("active but paused", func(t *testing.T) {
+		p := generateTestPrompt(t, ctx)
+		out := strings.Builder{}
+		p.SetOutput(&out)
+		p.markActive()
+		defer p.markInactive()
+		p.pauseRender()
+		p.linesRendered = []string{"> foo"}
+
+		p.Print("notification")
+		assert.Equal(t, "notification\n", out.String())
+		assert.Len(t, p.linesRendered, 1)
+	})
+}
+
+func TestPrompt_Writer(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second*5)
+	defer cancel()
+
+	p := generateTestPrompt(t, ctx)
+	out := strings.Builder{}
+	p.SetOutput(&out)
+	p.markActive()
+	defer p.markInactive()
+	p.linesRendered = []string{"> foo"}
+
+	w := p.Writer()
+	n, err := fmt.Fprintln(w, "foo")
+	assert.Nil(t, err)
+	assert.Equal(t, 4, n)
+	assert.Equal(t, "\x1b[1A\x1b[0Jfoo\r\n", out.String())
+
+	// concurrent writers do not interleave
+	out.Reset()
+	wg := sync.WaitGroup{}
+	for idx := 0; idx < 10; idx++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			_, _ = w.Write([]byte("bar\n"))
+		}()
+	}
+	wg.Wait()
+	assert.Equal(t, strings.Repeat("bar\r\n", 10), out.String())
+}
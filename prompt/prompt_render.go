@@ -1,14 +1,33 @@
 package prompt
 
 import (
+	"bytes"
 	"context"
 	"fmt"
+	"io"
 	"time"
 
 	"github.com/jedib0t/go-pretty/v6/text"
 	"github.com/muesli/termenv"
 )
 
+// rawModeWriter writes line feeds as carriage return + line feed, which is
+// what the terminal does on its own unless it is in raw mode.
+type rawModeWriter struct {
+	writer io.Writer
+}
+
+// Write writes the given bytes with the line feeds translated.
+func (rw *rawModeWriter) Write(b []byte) (int, error) {
+	if bytes.IndexByte(b, '\n') < 0 {
+		return rw.writer.Write(b)
+	}
+	if _, err := rw.writer.Write(bytes.ReplaceAll(b, []byte("\n"), []byte("\r\n"))); err != nil {
+		return 0, err
+	}
+	return len(b), nil
+}
+
 //gocyclo:ignore
 func (p *prompt) render(ctx context.Context, output *termenv.Output) (rsp string, err error) {
 	p.init(ctx)
@@ -16,8 +35,16 @@ func (p *prompt) render(ctx context.Context, output *termenv.Output) (rsp string
 	// set up cleanup
 	defer func() {
 		p.pauseRender()
-		p.updateModel(false)
+		if p.isFullScreen() {
+			p.exitFullScreen(output)
+		}
+		// the input reader has been ended by now, and what it did to the
+		// terminal on its way out is not known; paint the final render afresh
+		p.forceRepaint()
+		p.updateModel(false, true)
 		p.renderView(output, "done", true)
+		p.resetTerminalCursorShape(output)
+		output.ShowCursor()
 		p.buffer.Reset()
 	}()
 
@@ -30,11 +57,21 @@ func (p *prompt) render(ctx context.Context, output *termenv.Output) (rsp string
 	}()
 
 	// first time render
+	p.setTerminalCursorVisibility(output)
+	if p.isFullScreen() {
+		p.enterFullScreen(output)
+	}
 	p.updateModel(true)
+	if p.recorder != nil {
+		p.recorder.RecordPromptStart()
+	}
 
 	// start handling input events and rendering to screen
 	tick := time.Tick(p.refreshInterval)
-	tickCursor := time.Tick(p.style.Cursor.BlinkInterval)
+	var tickCursor <-chan time.Time
+	if !p.isAccessible() && !p.useTerminalCursor() {
+		tickCursor = time.Tick(p.style.Cursor.BlinkInterval)
+	}
 	for {
 		select {
 		case <-ctx.Done():
@@ -49,14 +86,36 @@ func (p *prompt) render(ctx context.Context, output *termenv.Output) (rsp string
 		case err = <-p.reader.Errors():
 			return "", err
 		case key := <-p.reader.KeyEvents():
+			p.recordKeyPress(key)
 			if err = p.handleKey(output, key); err != nil {
 				return "", err
 			}
 			if p.buffer.IsDone() {
 				return p.buffer.String(), nil
 			}
+		case mouse := <-p.reader.MouseEvents():
+			if err = p.handleMouse(output, mouse); err != nil {
+				return "", err
+			}
+		case cursorPosition := <-p.reader.CursorPositionEvents():
+			if err = p.handleMouseCursorPosition(output, cursorPosition); err != nil {
+				return "", err
+			}
 		case resize := <-p.reader.WindowSizeEvents():
+			if p.recorder != nil {
+				p.recorder.RecordResize(resize.Width, resize.Height)
+			}
 			p.updateDisplayWidth(resize.Width)
+			p.updateDisplayHeight(resize.Height)
+			p.updateHeaderAndFooter()
+			if p.isFullScreen() {
+				p.repaintFullScreen(output)
+				p.updateModel(true)
+			} else {
+				// the terminal may have re-flowed the rows on its own
+				p.forceRepaint()
+				p.updateModel(true)
+			}
 		}
 	}
 }
@@ -70,56 +129,129 @@ func (p *prompt) renderView(output *termenv.Output, reason string, forced ...boo
 		return
 	}
 	p.setDebugData("reason", reason)
+	if p.isAccessible() {
+		p.renderViewAccessible(output, len(forced) > 0 && forced[0])
+		return
+	}
 
 	timeStart := time.Now()
 	defer func() {
 		p.linesRendered = p.linesToRender
+		p.mouseMapRendered = p.mouseMapToRender
 	}()
 
-	// calculate movement
-	numLinesToRender, numLinesRendered := len(p.linesToRender), len(p.linesRendered)
+	// start from where the last render left the terminal's cursor
+	numRowsRendered := len(p.linesRendered)
+	if numRowsRendered > 0 && len(p.screenRows) > numRowsRendered { // the debug footer
+		numRowsRendered++
+	}
+	fw := &frameWriter{absolute: p.isFullScreen(), column: p.screenCursorColumn, row: -1, rows: numRowsRendered + 1}
+	if fw.column >= 0 {
+		fw.row = numRowsRendered - p.terminalCursorRowsUp
+	}
 
-	// move cursor up and clear printed lines
-	if numLinesRendered > 0 {
-		if numLinesToRender < numLinesRendered {
-			for idx := numLinesRendered; idx > numLinesToRender; idx-- {
-				output.CursorUp(1)
-				output.ClearLine()
-			}
-			output.CursorUp(numLinesToRender)
-		} else {
-			output.CursorUp(numLinesRendered)
-		}
-		if p.debug { // for the final debug footer
-			output.CursorUp(1)
+	// write the cells that changed, and clear the rows left behind
+	rows := p.renderRows(fw, timeStart)
+	if len(rows) < numRowsRendered {
+		fw.moveTo(len(rows), 0)
+		fw.eraseBelow()
+	}
+	fw.setStyle("")
+	p.screenRows = rows
+
+	// move to the beginning of the line after the prompt, making sure it is
+	// there for the next render to start from, and then the terminal's cursor
+	// to where the input cursor is
+	if !fw.absolute && (p.terminalCursor == nil || len(rows) >= fw.rows) {
+		fw.moveTo(len(rows), 0)
+	}
+	if p.terminalCursor != nil {
+		column := p.terminalCursor.Column
+		if p.debug { // for the debug margin
+			column += debugMarginWidth
 		}
+		fw.moveTo(p.terminalCursor.Line, column)
+		p.setTerminalCursorShape(fw)
+	}
+	p.terminalCursorRowsUp, p.screenCursorColumn = len(rows)-fw.row, fw.column
+	if fw.row < 0 { // nothing written to a screen that got messed with
+		p.terminalCursorRowsUp, p.screenCursorColumn = 0, -1
 	}
 
-	// print all the changed lines
+	p.writeFrame(output, fw)
+}
+
+// forceRepaint forces a full repaint of the rows on the next render as what is
+// on the terminal is no longer known.
+func (p *prompt) forceRepaint() {
+	p.linesMutex.Lock()
+	defer p.linesMutex.Unlock()
+
+	p.linesRendered = make([]string, len(p.linesRendered))
+}
+
+// renderRows writes the cells that changed since the last render into the
+// frame, and returns the rows as rendered.
+func (p *prompt) renderRows(fw *frameWriter, timeStart time.Time) []screenRow {
+	rows := make([]screenRow, 0, len(p.linesToRender)+1)
 	for idx, line := range p.linesToRender {
-		if idx < len(p.linesRendered) && p.linesRendered[idx] == line { // nothing changed
-			output.CursorDown(1)
+		cellsRendered, known := p.screenRowRendered(idx)
+		if known && p.linesRendered[idx] == line { // nothing changed
+			rows = append(rows, p.screenRows[idx])
 			continue
 		}
 
-		// something is different, clear and reprint
-		output.ClearLine()
+		row := screenRow{line: line}
 		if p.debug { // render the "second" this line was rendered to screen
-			_, _ = output.WriteString(p.style.Colors.Debug.Sprintf(" %02d ", time.Now().Second()))
+			row.cells = parseCells(convertEscSeqs(p.style.Colors.Debug.Sprintf(" %02d ", time.Now().Second()), p.activeColorProfile) + line)
+		} else {
+			row.cells = parseCells(line)
 		}
-		_, _ = output.WriteString(fmt.Sprintf("%s\n", line))
+		fw.writeRow(idx, cellsRendered, row.cells, known)
+		rows = append(rows, row)
 	}
 
 	if p.debug {
-		stats := fmt.Sprintf("%s; time=%v [gen=sh:%v/bf:%v/ac:%v/%v]",
-			p.debugDataAsString(), time.Since(timeStart).Round(time.Microsecond),
-			p.timeSyntaxGen, p.timeBufferGen, p.timeAutoComplete, p.timeGen,
-		)
+		row := screenRow{cells: parseCells(p.generateDebugStats(timeStart))}
+		fw.writeRow(len(rows), nil, row.cells, false)
+		rows = append(rows, row)
+	}
+	return rows
+}
 
-		output.ClearLine()
-		_, _ = output.WriteString(p.style.Colors.Debug.Sprintf(" %02d ", time.Now().Second()))
-		_, _ = output.WriteString(p.style.Colors.Debug.Sprintf(
-			text.AlignCenter.Apply(stats, p.getDisplayWidth())) + "\n",
-		)
+// screenRowRendered returns the cells on the terminal in the given row, and
+// whether they are known to still be there; the rows are not known once the
+// rendered lines are forgotten or blanked out to force a repaint.
+func (p *prompt) screenRowRendered(idx int) ([]cell, bool) {
+	if idx < len(p.linesRendered) && idx < len(p.screenRows) && p.screenRows[idx].line == p.linesRendered[idx] {
+		return p.screenRows[idx].cells, true
 	}
+	return nil, false
+}
+
+// writeFrame writes the frame to the terminal, if there is anything in it, in
+// a synchronized update where supported.
+func (p *prompt) writeFrame(output *termenv.Output, fw *frameWriter) {
+	frame := fw.String()
+	if frame != "" {
+		if p.useSyncUpdates() {
+			frame = escSeqSyncUpdateStart + frame + escSeqSyncUpdateEnd
+		}
+		_, _ = output.WriteString(frame)
+	}
+	p.bytesRendered = len(frame)
+}
+
+// generateDebugStats returns the debug footer with the debug data, the time
+// taken to generate and render the lines, and the bytes written for the last
+// frame.
+func (p *prompt) generateDebugStats(timeStart time.Time) string {
+	stats := fmt.Sprintf("%s; time=%v; bytes=%d [gen=sh:%v/bf:%v/ac:%v/%v]",
+		p.debugDataAsString(), time.Since(timeStart).Round(time.Microsecond), p.bytesRendered,
+		p.timeSyntaxGen, p.timeBufferGen, p.timeAutoComplete, p.timeGen,
+	)
+	return convertEscSeqs(p.style.Colors.Debug.Sprintf(" %02d ", time.Now().Second())+
+		p.style.Colors.Debug.Sprintf(text.AlignCenter.Apply(text.Trim(stats, p.getDisplayWidth()), p.getDisplayWidth())),
+		p.activeColorProfile,
+	)
 }
@@ -17,6 +17,7 @@ var (
 func TestPrompt_renderView(t *testing.T) {
 	ctx, cancel := context.WithTimeout(context.Background(), time.Second*5)
 	defer cancel()
+	t.Setenv("TERM", "xterm-256color")
 
 	p := generateTestPrompt(t, ctx)
 	p.SetFooterGenerator(LineRuler(StyleLineNumbersEnabled.Color))
@@ -32,10 +33,10 @@ func TestPrompt_renderView(t *testing.T) {
 	p.updateModel(true)
 	p.renderView(termenv.NewOutput(&output), "test")
 	expectedLines := []string{
-		"\x1b[2K\x1b[38;5;239;48;5;235m----+----1----+----2----+----3----+----4----+----5----+----6----+----7----+----8----+----9----+----0----+----1----+----2\x1b[0m",
+		"\x1b[?2026h\x1b[2K\x1b[38;5;239;48;5;235m----+----1----+----2----+----3----+----4----+----5----+----6----+----7----+----8----+----9----+----0----+----1----+----2\x1b[0m",
 		"\x1b[2K\x1b[38;5;239;48;5;235m 1 \x1b[0m This is a test\x1b[38;5;232;48;5;6m \x1b[0m",
 		"\x1b[2K\x1b[38;5;239;48;5;235m----+----1----+----2----+----3----+----4----+----5----+----6----+----7----+----8----+----9----+----0----+----1----+----2\x1b[0m",
-		"",
+		"\x1b[?2026l",
 	}
 	compareLines(t, expectedLines, strings.Split(output.String(), "\n"), testSubtitle)
 
@@ -45,10 +46,8 @@ func TestPrompt_renderView(t *testing.T) {
 	p.updateModel(true)
 	p.renderView(termenv.NewOutput(&output), "test")
 	expectedLines = []string{
-		"\x1b[3A\x1b[1B\x1b[2K\x1b[38;5;239;48;5;235m 1 \x1b[0m This is a test",
-		"\x1b[2K\x1b[38;5;239;48;5;235m 2 \x1b[0m and this is not a test\x1b[38;5;232;48;5;6m \x1b[0m",
-		"\x1b[2K\x1b[38;5;239;48;5;235m----+----1----+----2----+----3----+----4----+----5----+----6----+----7----+----8----+----9----+----0----+----1----+----2\x1b[0m",
-		"",
+		"\x1b[?2026h\x1b[2A\x1b[19G\x1b[0K\x1b[1B\r\x1b[38;5;239;48;5;235m 2 \x1b[0m and this is not a test\x1b[38;5;232;48;5;6m \x1b[0m\x1b[0K\x1b[1B\r\x1b[2K\x1b[38;5;239;48;5;235m----+----1----+----2----+----3----+----4----+----5----+----6----+----7----+----8----+----9----+----0----+----1----+----2\x1b[0m",
+		"\x1b[?2026l",
 	}
 	compareLines(t, expectedLines, strings.Split(output.String(), "\n"), testSubtitle)
 
@@ -58,10 +57,8 @@ func TestPrompt_renderView(t *testing.T) {
 	p.updateModel(true)
 	p.renderView(termenv.NewOutput(&output), "test", true)
 	expectedLines = []string{
-		"\x1b[4A\x1b[1B\x1b[1B\x1b[2K\x1b[38;5;239;48;5;235m 2 \x1b[0m and this is not a test",
-		"\x1b[2K\x1b[38;5;239;48;5;235m 3 \x1b[0m and no idea what this is about.\x1b[38;5;232;48;5;6m \x1b[0m",
-		"\x1b[2K\x1b[38;5;239;48;5;235m----+----1----+----2----+----3----+----4----+----5----+----6----+----7----+----8----+----9----+----0----+----1----+----2\x1b[0m",
-		"",
+		"\x1b[?2026h\x1b[2A\x1b[27G\x1b[0K\x1b[1B\r\x1b[38;5;239;48;5;235m 3 \x1b[0m and no idea what this is about.\x1b[38;5;232;48;5;6m \x1b[0m\x1b[0K\x1b[1B\r\x1b[2K\x1b[38;5;239;48;5;235m----+----1----+----2----+----3----+----4----+----5----+----6----+----7----+----8----+----9----+----0----+----1----+----2\x1b[0m",
+		"\x1b[?2026l",
 	}
 	compareLines(t, expectedLines, strings.Split(output.String(), "\n"), testSubtitle)
 
@@ -71,12 +68,12 @@ func TestPrompt_renderView(t *testing.T) {
 	p.updateModel(true)
 	p.renderView(termenv.NewOutput(&output), "test")
 	expectedLines = []string{
-		"\x1b[2K\x1b[38;5;239;48;5;235m----+----1----+----2----+----3----+----4----+----5----+----6----+----7----+----8----+----9----+----0----+----1----+----2\x1b[0m",
+		"\x1b[?2026h\x1b[2K\x1b[38;5;239;48;5;235m----+----1----+----2----+----3----+----4----+----5----+----6----+----7----+----8----+----9----+----0----+----1----+----2\x1b[0m",
 		"\x1b[2K\x1b[38;5;239;48;5;235m 1 \x1b[0m This is a test",
 		"\x1b[2K\x1b[38;5;239;48;5;235m 2 \x1b[0m and this is not a test",
 		"\x1b[2K\x1b[38;5;239;48;5;235m 3 \x1b[0m and no idea what this is about.\x1b[38;5;232;48;5;6m \x1b[0m",
 		"\x1b[2K\x1b[38;5;239;48;5;235m----+----1----+----2----+----3----+----4----+----5----+----6----+----7----+----8----+----9----+----0----+----1----+----2\x1b[0m",
-		"",
+		"\x1b[?2026l",
 	}
 	compareLines(t, expectedLines, strings.Split(output.String(), "\n"), testSubtitle)
 
@@ -86,9 +83,7 @@ func TestPrompt_renderView(t *testing.T) {
 	p.updateModel(true)
 	p.renderView(termenv.NewOutput(&output), "test")
 	expectedLines = []string{
-		"\x1b[5A\x1b[1B\x1b[2K\x1b[38;5;239;48;5;235m 1 \x1b[0m \x1b[38;5;232;48;5;6mT\x1b[0mhis is a test",
-		"\x1b[1B\x1b[2K\x1b[38;5;239;48;5;235m 3 \x1b[0m and no idea what this is about.",
-		"\x1b[1B",
+		"\x1b[?2026h\x1b[4A\x1b[5G\x1b[38;5;232;48;5;6mT\x1b[2B\x1b[36G\x1b[0m\x1b[0K\x1b[2B\r\x1b[?2026l",
 	}
 	compareLines(t, expectedLines, strings.Split(output.String(), "\n"), testSubtitle)
 
@@ -97,8 +92,7 @@ func TestPrompt_renderView(t *testing.T) {
 	p.updateModel(false)
 	p.renderView(termenv.NewOutput(&output), "test")
 	expectedLines = []string{
-		"\x1b[5A\x1b[1B\x1b[2K\x1b[38;5;239;48;5;235m 1 \x1b[0m This is a test",
-		"\x1b[1B\x1b[1B\x1b[1B",
+		"\x1b[?2026h\x1b[4A\x1b[5GT\x1b[4B\r\x1b[?2026l",
 	}
 	compareLines(t, expectedLines, strings.Split(output.String(), "\n"), testSubtitle)
 
@@ -111,13 +105,35 @@ func TestPrompt_renderView(t *testing.T) {
 	p.updateModel(false)
 	p.renderView(termenv.NewOutput(&output), "test")
 	expectedLines = []string{
-		"\x1b[1A\x1b[2K\x1b[1A\x1b[2K\x1b[3A\x1b[2K\x1b[38;5;239;48;5;235m 1 \x1b[0m This is a test",
-		"\x1b[2K\x1b[38;5;239;48;5;235m 2 \x1b[0m and this is not a test",
-		"\x1b[2K\x1b[38;5;239;48;5;235m 3 \x1b[0m and no idea what this is about.",
-		"",
+		"\x1b[?2026h\x1b[5A\x1b[38;5;239;48;5;235m 1 \x1b[0m This is a test\x1b[0K\x1b[1B\x1b[2G\x1b[38;5;239;48;5;235m2 \x1b[0m and this is not a test\x1b[1B\x1b[2G\x1b[38;5;239;48;5;235m3\x1b[9G\x1b[0mno idea what this is about.\x1b[1B\r\x1b[0J\x1b[?2026l",
 	}
 	compareLines(t, expectedLines, strings.Split(output.String(), "\n"), testSubtitle)
 
+	testSubtitle = "Forced repaint"
+	output.Reset()
+	p.forceRepaint()
+	p.renderView(termenv.NewOutput(&output), "test")
+	assert.True(t, strings.HasPrefix(output.String(), "\x1b[?2026h\x1b[3A\x1b[2K"), testSubtitle)
+	assert.Equal(t, 3, strings.Count(output.String(), "\x1b[2K"), testSubtitle)
+	assert.True(t, strings.HasSuffix(output.String(), "\x1b[1B\r\x1b[?2026l"), testSubtitle)
+
+	testSubtitle = "Nothing changed"
+	output.Reset()
+	p.updateModel(false)
+	p.renderView(termenv.NewOutput(&output), "test")
+	assert.Equal(t, "", output.String(), testSubtitle)
+	assert.Equal(t, 0, p.bytesRendered, testSubtitle)
+
+	testSubtitle = "No synchronized updates on unsupported terminals"
+	output.Reset()
+	t.Setenv("TERM", "dumb")
+	p.buffer.MoveToEnd()
+	p.updateModel(true)
+	p.renderView(termenv.NewOutput(&output), "test")
+	assert.Equal(t, "\x1b[1A\x1b[36G\x1b[38;5;232;48;5;6m \x1b[0m\x1b[1B\r", output.String(), testSubtitle)
+	assert.Equal(t, len(output.String()), p.bytesRendered, testSubtitle)
+	t.Setenv("TERM", "xterm-256color")
+
 	testSubtitle = "Render the whole thing again with debug mode"
 	output.Reset()
 	p.SetDebug(true)
@@ -135,10 +151,11 @@ func TestPrompt_renderView(t *testing.T) {
 	p.setDebugData("bar", "baz")
 	p.updateModel(true)
 	p.renderView(termenv.NewOutput(&output), testReasonDebug)
-	actualLines = strings.Split(output.String(), "\n")
-	assert.Len(t, actualLines, 2, testSubtitle)
-	assert.Contains(t, actualLines[0], p.debugDataAsString(), testSubtitle)
-	assert.Contains(t, actualLines[0], "time=", testSubtitle)
+	assert.True(t, strings.HasPrefix(output.String(), "\x1b[?2026h\x1b[1A\x1b[2K"), testSubtitle)
+	assert.NotContains(t, output.String(), "\n", testSubtitle)
+	assert.Contains(t, output.String(), p.debugDataAsString(), testSubtitle)
+	assert.Contains(t, output.String(), "time=", testSubtitle)
+	assert.Contains(t, output.String(), "bytes=", testSubtitle)
 
 	testSubtitle = "Paused"
 	output.Reset()
@@ -153,8 +170,22 @@ func TestPrompt_renderView(t *testing.T) {
 	p.resumeRender()
 	p.updateModel(true)
 	p.renderView(termenv.NewOutput(&output), testReasonDebug)
-	actualLines = strings.Split(output.String(), "\n")
-	assert.Len(t, actualLines, 2, testSubtitle)
-	assert.Contains(t, actualLines[0], p.debugDataAsString(), testSubtitle)
-	assert.Contains(t, actualLines[0], "time=", testSubtitle)
+	assert.True(t, strings.HasPrefix(output.String(), "\x1b[?2026h\x1b[1A\x1b[2K"), testSubtitle)
+	assert.NotContains(t, output.String(), "\n", testSubtitle)
+	assert.Contains(t, output.String(), p.debugDataAsString(), testSubtitle)
+	assert.Contains(t, output.String(), "time=", testSubtitle)
+	assert.Contains(t, output.String(), "bytes=", testSubtitle)
+}
+
+func Test_rawModeWriter(t *testing.T) {
+	out := strings.Builder{}
+	rw := &rawModeWriter{writer: &out}
+
+	n, err := rw.Write([]byte("foo"))
+	assert.Nil(t, err)
+	assert.Equal(t, 3, n)
+	n, err = rw.Write([]byte("\x1b[2Kbar\nbaz\n"))
+	assert.Nil(t, err)
+	assert.Equal(t, 12, n)
+	assert.Equal(t, "foo\x1b[2Kbar\r\nbaz\r\n", out.String())
 }
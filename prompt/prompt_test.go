@@ -11,7 +11,9 @@ import (
 	"time"
 
 	tea "github.com/charmbracelet/bubbletea"
+	"github.com/jedib0t/go-prompter/input"
 	mock_input "github.com/jedib0t/go-prompter/mocks/input"
+	"github.com/muesli/termenv"
 	"github.com/stretchr/testify/assert"
 	"go.uber.org/mock/gomock"
 )
@@ -75,7 +77,7 @@ func TestPrompt_KeyMap(t *testing.T) {
 	assert.NotNil(t, p.keyMapReversed)
 	if p.keyMapReversed != nil {
 		assert.Len(t, p.keyMapReversed.AutoComplete, 3)
-		assert.Len(t, p.keyMapReversed.Insert, 28)
+		assert.Len(t, p.keyMapReversed.Insert, 44)
 	}
 }
 
@@ -96,8 +98,10 @@ func TestPrompt_Prompt(t *testing.T) {
 		mockReader := mock_input.NewMockReader(mc)
 		mockReader.EXPECT().Begin(gomock.Any())
 		mockReader.EXPECT().Reset()
+		mockReader.EXPECT().CursorPositionEvents().AnyTimes().Return(make(chan input.CursorPositionMsg))
 		mockReader.EXPECT().Errors().AnyTimes().Return(chErrors)
 		mockReader.EXPECT().KeyEvents().AnyTimes().Return(chKeyEvents)
+		mockReader.EXPECT().MouseEvents().AnyTimes().Return(make(chan tea.MouseMsg))
 		mockReader.EXPECT().WindowSizeEvents().AnyTimes().Return(chWindowSizeEvents)
 		mockReader.EXPECT().End()
 
@@ -175,6 +179,77 @@ func TestPrompt_Prompt(t *testing.T) {
 		assert.True(t, errors.Is(err, context.Canceled))
 	})
 
+	t.Run("with recorder", func(t *testing.T) {
+		ctx, cancel := context.WithTimeout(context.Background(), time.Second*5)
+		defer cancel()
+
+		chKeyEvents := make(chan tea.KeyMsg, 1)
+		chWindowSizeEvents := make(chan tea.WindowSizeMsg, 1)
+		mc := gomock.NewController(t)
+		defer mc.Finish()
+		p := generateTestPromptWithMockReader(t, ctx, mc, nil, chKeyEvents, chWindowSizeEvents)
+		tr := &testRecorder{}
+		p.SetRecorder(tr)
+		chWindowSizeEvents <- tea.WindowSizeMsg{Width: 3, Height: 2}
+		go func() {
+			<-time.After(time.Second / 10) // some time for all goroutines to start
+			// wait for the resize to be processed before the keys
+			for tr.numSizes() == 0 {
+				<-time.After(time.Millisecond)
+			}
+			chKeyEvents <- tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("abc")}
+			chKeyEvents <- tea.KeyMsg{Type: tea.KeyEnter}
+		}()
+		userInput, err := p.Prompt(ctx)
+		assert.Equal(t, "abc", userInput)
+		assert.Nil(t, err)
+
+		tr.mutex.Lock()
+		defer tr.mutex.Unlock()
+		assert.Equal(t, 1, tr.numPrompts)
+		assert.Equal(t, []KeyPress{{Text: "abc"}, {KeySequence: Enter}}, tr.keyPresses)
+		assert.Equal(t, []string{"3x2"}, tr.sizes)
+		assert.Contains(t, tr.output.String(), "abc")
+		assert.Contains(t, tr.output.String(), "\r\n")
+		assert.Equal(t, tr.output.String(), p.output.(*strings.Builder).String())
+	})
+
+	t.Run("full screen", func(t *testing.T) {
+		ctx, cancel := context.WithTimeout(context.Background(), time.Second*5)
+		defer cancel()
+
+		chKeyEvents := make(chan tea.KeyMsg, 1)
+		chWindowSizeEvents := make(chan tea.WindowSizeMsg, 1)
+		mc := gomock.NewController(t)
+		defer mc.Finish()
+		p := generateTestPromptWithMockReader(t, ctx, mc, nil, chKeyEvents, chWindowSizeEvents)
+		p.SetFooter("footer")
+		go func() {
+			<-time.After(time.Second / 10) // some time for all goroutines to start
+			chWindowSizeEvents <- tea.WindowSizeMsg{Width: 120, Height: 10}
+			chKeyEvents <- tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("abc")}
+			p.Print("printed while editing")
+			<-time.After(time.Second / 10) // some time for a render
+			chKeyEvents <- tea.KeyMsg{Type: tea.KeyEnter}
+		}()
+		userInput, err := p.Prompt(ctx, WithFullScreen())
+		assert.Equal(t, "abc", userInput)
+		assert.Nil(t, err)
+		assert.False(t, p.isFullScreen())
+
+		out := p.output.(*strings.Builder).String()
+		idxEnter, idxExit := strings.Index(out, "\x1b[?1049h"), strings.Index(out, "\x1b[?1049l")
+		assert.True(t, idxEnter >= 0 && idxEnter < idxExit)
+		fullScreen, normalScreen := out[idxEnter:idxExit], out[idxExit:]
+		assert.Contains(t, fullScreen, "\x1b[10;1H") // the footer on the last row
+		assert.Contains(t, fullScreen, "Ln 1/1, Col 4")
+		assert.Contains(t, fullScreen, "[Modified]")
+		assert.NotContains(t, fullScreen, "printed while editing")
+		assert.Contains(t, normalScreen, "printed while editing")
+		assert.Contains(t, normalScreen, "abc")
+		assert.NotContains(t, normalScreen, " Ln 1/1")
+	})
+
 	t.Run("no error", func(t *testing.T) {
 		ctx, cancel := context.WithTimeout(context.Background(), time.Second*5)
 		defer cancel()
@@ -226,6 +301,20 @@ func TestPrompt_SendInput(t *testing.T) {
 		assert.Equal(t, errFoo, err)
 	})
 
+	t.Run("send KeyPress", func(t *testing.T) {
+		mc := gomock.NewController(t)
+		defer mc.Finish()
+		mockReader := mock_input.NewMockReader(mc)
+		mockReader.EXPECT().Send(keySequenceKeyMsgMap[F1])
+		mockReader.EXPECT().Send(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("a\nb"), Paste: true}).
+			Return(errFoo)
+
+		p := generateTestPrompt(t, ctx)
+		p.reader = mockReader
+		err := p.SendInput([]any{KeyPress{KeySequence: F1}, KeyPress{}, KeyPress{Paste: true, Text: "a\nb"}})
+		assert.Equal(t, errFoo, err)
+	})
+
 	t.Run("send KeySequence", func(t *testing.T) {
 		mc := gomock.NewController(t)
 		defer mc.Finish()
@@ -334,6 +423,31 @@ func TestPrompt_SetAutoCompleterContextual(t *testing.T) {
 	assert.NotNil(t, p.autoCompleterContextual)
 }
 
+func TestPrompt_SetColorProfile(t *testing.T) {
+	t.Setenv("CLICOLOR", "")
+	t.Setenv("CLICOLOR_FORCE", "")
+	t.Setenv("CI", "")
+	t.Setenv("COLORTERM", "")
+	t.Setenv("NO_COLOR", "")
+	t.Setenv("TERM", "xterm-256color")
+	output := termenv.NewOutput(&strings.Builder{}, termenv.WithTTY(true))
+
+	p := prompt{}
+	assert.Nil(t, p.colorProfile)
+	assert.Equal(t, termenv.ANSI256, p.getColorProfile(output))
+
+	t.Setenv("NO_COLOR", "1")
+	assert.Equal(t, termenv.Ascii, p.getColorProfile(output))
+	t.Setenv("NO_COLOR", "")
+	t.Setenv("TERM", "dumb")
+	assert.Equal(t, termenv.Ascii, p.getColorProfile(output))
+	assert.Equal(t, termenv.Ascii, p.getColorProfile(termenv.NewOutput(&strings.Builder{})))
+
+	p.SetColorProfile(termenv.TrueColor)
+	assert.NotNil(t, p.colorProfile)
+	assert.Equal(t, termenv.TrueColor, p.getColorProfile(output))
+}
+
 func TestPrompt_SetCommandShortcuts(t *testing.T) {
 	p := prompt{}
 	assert.Nil(t, p.shortcuts)
@@ -347,6 +461,15 @@ func TestPrompt_SetCommandShortcuts(t *testing.T) {
 	assert.Contains(t, p.shortcuts, F1)
 }
 
+func TestPrompt_SetContinuationPrefixer(t *testing.T) {
+	p := prompt{}
+	assert.Nil(t, p.continuationPrefixer)
+
+	p.SetContinuationPrefixer(ContinuationPrefixText("-> "))
+	assert.NotNil(t, p.continuationPrefixer)
+	assert.Equal(t, "-> ", p.continuationPrefixer(1, "foo\n"))
+}
+
 func TestPrompt_SetDebug(t *testing.T) {
 	p := prompt{}
 	assert.False(t, p.debug)
@@ -471,6 +594,14 @@ func TestPrompt_SetOutput(t *testing.T) {
 	assert.Equal(t, os.Stdout, p.getOutputWriter())
 }
 
+func TestPrompt_SetPlaceholder(t *testing.T) {
+	p := prompt{}
+	assert.Equal(t, "", p.placeholder)
+
+	p.SetPlaceholder("type a query or /help")
+	assert.Equal(t, "type a query or /help", p.placeholder)
+}
+
 func TestPrompt_SetPrefix(t *testing.T) {
 	p := prompt{}
 	assert.Nil(t, p.prefixer)
@@ -489,6 +620,18 @@ func TestPrompt_SetPrefixer(t *testing.T) {
 	assert.Equal(t, "> ", p.prefixer())
 }
 
+func TestPrompt_SetRecorder(t *testing.T) {
+	p := prompt{}
+	assert.Nil(t, p.recorder)
+
+	tr := &testRecorder{}
+	p.SetRecorder(tr)
+	assert.Equal(t, tr, p.recorder)
+
+	p.SetRecorder(nil)
+	assert.Nil(t, p.recorder)
+}
+
 func TestPrompt_SetRefreshInterval(t *testing.T) {
 	p := prompt{}
 	assert.Equal(t, time.Duration(0), p.refreshInterval)
@@ -500,6 +643,35 @@ func TestPrompt_SetRefreshInterval(t *testing.T) {
 	assert.Equal(t, DefaultRefreshInterval, p.refreshInterval)
 }
 
+func TestPrompt_SetRightGutter(t *testing.T) {
+	p := prompt{}
+	assert.Nil(t, p.rightGutter)
+
+	p.SetRightGutter(func(lineNum int, line string) string {
+		return fmt.Sprintf("%d:%d", lineNum, len(line))
+	})
+	assert.NotNil(t, p.rightGutter)
+	assert.Equal(t, "1:3", p.rightGutter(1, "foo"))
+}
+
+func TestPrompt_SetRightPrompt(t *testing.T) {
+	p := prompt{}
+	assert.Nil(t, p.rightPrompter)
+
+	p.SetRightPrompt("[12ms]")
+	assert.NotNil(t, p.rightPrompter)
+	assert.Equal(t, "[12ms]", p.rightPrompter())
+}
+
+func TestPrompt_SetRightPrompter(t *testing.T) {
+	p := prompt{}
+	assert.Nil(t, p.rightPrompter)
+
+	p.SetRightPrompter(PrefixText("[db]"))
+	assert.NotNil(t, p.rightPrompter)
+	assert.Equal(t, "[db]", p.rightPrompter())
+}
+
 func TestPrompt_SetStyle(t *testing.T) {
 	p := prompt{}
 	assert.Nil(t, p.style)
@@ -511,6 +683,20 @@ func TestPrompt_SetStyle(t *testing.T) {
 	}
 }
 
+func TestPrompt_SetSynchronizedUpdates(t *testing.T) {
+	p := prompt{}
+	t.Setenv("TERM", "xterm-256color")
+	assert.True(t, p.useSyncUpdates())
+	t.Setenv("TERM", "linux")
+	assert.False(t, p.useSyncUpdates())
+
+	p.SetSynchronizedUpdates(true)
+	assert.True(t, p.useSyncUpdates())
+	t.Setenv("TERM", "xterm-256color")
+	p.SetSynchronizedUpdates(false)
+	assert.False(t, p.useSyncUpdates())
+}
+
 func TestPrompt_SetSyntaxHighlighter(t *testing.T) {
 	p := prompt{}
 	assert.Nil(t, p.syntaxHighlighter)
@@ -542,6 +728,36 @@ func TestPrompt_SetTerminationChecker(t *testing.T) {
 	assert.True(t, p.terminationChecker("foo;"))
 }
 
+func TestPrompt_SetTransientPrefixer(t *testing.T) {
+	p := prompt{}
+	assert.Nil(t, p.transientPrefixer)
+
+	p.SetTransientPrefixer(PrefixText("❯ "))
+	assert.NotNil(t, p.transientPrefixer)
+	assert.Equal(t, "❯ ", p.transientPrefixer())
+
+	p.SetTransientPrefixer(nil)
+	assert.Nil(t, p.transientPrefixer)
+}
+
+func TestPrompt_SetValidator(t *testing.T) {
+	p := prompt{}
+	assert.Nil(t, p.validator)
+	assert.Nil(t, p.getDiagnostics("foo"))
+
+	numCalls := 0
+	p.SetValidator(func(input string) []Diagnostic {
+		numCalls++
+		return []Diagnostic{{Message: input}}
+	})
+	assert.NotNil(t, p.validator)
+	assert.Equal(t, []Diagnostic{{Message: "foo"}}, p.getDiagnostics("foo"))
+	assert.Equal(t, []Diagnostic{{Message: "foo"}}, p.getDiagnostics("foo"))
+	assert.Equal(t, 1, numCalls)
+	assert.Equal(t, []Diagnostic{{Message: "bar"}}, p.getDiagnostics("bar"))
+	assert.Equal(t, 2, numCalls)
+}
+
 func TestPrompt_SetWidthEnforcer(t *testing.T) {
 	p := prompt{}
 	assert.Nil(t, p.widthEnforcer)
@@ -604,6 +820,48 @@ func TestPrompt_clearDebugData(t *testing.T) {
 	assert.Len(t, p.debugData, 0)
 }
 
+type testTerminalSizer struct {
+	strings.Builder
+}
+
+func (t *testTerminalSizer) TerminalSize() (int, int) {
+	return 42, 10
+}
+
+func TestPrompt_getHeightLimits(t *testing.T) {
+	p := prompt{}
+	p.SetStyle(StyleDefault)
+	p.Style().Dimensions.HeightMin = 2
+	p.Style().Dimensions.HeightMax = 5
+	p.updateDisplayHeight(20)
+
+	heightMin, heightMax := p.getHeightLimits(3)
+	assert.Equal(t, 2, heightMin)
+	assert.Equal(t, 5, heightMax)
+
+	p.setFullScreen(true)
+	heightMin, heightMax = p.getHeightLimits(3)
+	assert.Equal(t, 16, heightMin)
+	assert.Equal(t, 16, heightMax)
+
+	p.SetDebug(true)
+	heightMin, heightMax = p.getHeightLimits(3)
+	assert.Equal(t, 15, heightMin)
+	assert.Equal(t, 15, heightMax)
+
+	heightMin, heightMax = p.getHeightLimits(30)
+	assert.Equal(t, 1, heightMin)
+	assert.Equal(t, 1, heightMax)
+}
+
+func TestPrompt_getTerminalSize(t *testing.T) {
+	p := prompt{}
+	p.SetOutput(&testTerminalSizer{})
+	width, height := p.getTerminalSize()
+	assert.Equal(t, 42, width)
+	assert.Equal(t, 10, height)
+}
+
 func TestPrompt_updateCursorColors(t *testing.T) {
 	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
 	defer cancel()
@@ -622,6 +880,16 @@ func TestPrompt_updateCursorColors(t *testing.T) {
 	assert.Equal(t, p.Style().Cursor.Color, p.getCursorColor())
 }
 
+func TestPrompt_updateDisplayHeight(t *testing.T) {
+	p := prompt{}
+	assert.Equal(t, 0, p.getDisplayHeight())
+
+	p.updateDisplayHeight(30)
+	assert.Equal(t, 30, p.getDisplayHeight())
+	p.updateDisplayHeight(0)
+	assert.Equal(t, defaultDisplayHeight, p.getDisplayHeight())
+}
+
 func TestPrompt_updateDisplayWidth(t *testing.T) {
 	p := prompt{}
 	assert.Equal(t, 0, p.displayWidth)
@@ -5,6 +5,8 @@ import (
 	"io"
 	"os"
 	"time"
+
+	"github.com/muesli/termenv"
 )
 
 // Prompter in the interface to create and manage a shell-like interactive
@@ -29,14 +31,33 @@ type Prompter interface {
 	//prompt.
 	NumLines() int
 
+	// Print prints the given items (formatted like fmt.Sprint) above the
+	// active prompt without corrupting it, and can be called safely from any
+	// goroutine. If there is no active prompt, the text is printed as is.
+	Print(a ...any)
+
+	// Printf prints the formatted text (like fmt.Sprintf) above the active
+	// prompt without corrupting it, and can be called safely from any
+	// goroutine. If there is no active prompt, the text is printed as is.
+	Printf(format string, a ...any)
+
 	// Prompt prompts. It also watches for cancel KeyEvents on the Context to
-	// abort the prompt and return control to client.
-	Prompt(ctx context.Context) (string, error)
+	// abort the prompt and return control to client. The PromptOptions
+	// customize just this one prompt.
+	Prompt(ctx context.Context, opts ...PromptOption) (string, error)
 
-	// SendInput lets you send strings/runes/KeySequence to the currently active
-	// prompt.
+	// SendInput lets you send strings/runes/KeySequence/KeyPress to the
+	// currently active prompt.
 	SendInput(a []any, delayBetweenRunes ...time.Duration) error
 
+	// SetAccessibleMode enables or disables the accessible mode meant for use
+	// with screen readers. In this mode, the prompt never rewrites anything it
+	// printed before: the cursor does not blink, the header, footer and
+	// auto-complete drop-down are not rendered, edits are echoed as they are
+	// made, and the suggestions are announced as plain lines of text. The mode
+	// can also be enabled by setting the environment variable ACCESSIBLE=1.
+	SetAccessibleMode(enabled bool)
+
 	// SetAutoCompleter sets up the AutoCompleter that will be used to provide
 	// suggestions. Consider this as an auto completer which will be useful for
 	// suggesting global stuff like language keywords, or global variables.
@@ -51,6 +72,13 @@ type Prompter interface {
 	// previous command.
 	SetAutoCompleterContextual(autoCompleter AutoCompleter)
 
+	// SetColorProfile forces the color profile to render the prompt with,
+	// instead of detecting it from the output and the environment (NO_COLOR,
+	// CLICOLOR, CLICOLOR_FORCE and TERM). Colors not supported by the profile
+	// are downsampled, and termenv.Ascii disables them entirely while keeping
+	// the cursor visible using reverse video.
+	SetColorProfile(profile termenv.Profile)
+
 	// SetCommandShortcuts sets up command shortcuts. For example, if you want
 	// to get the prompt input as "/help" when the user presses F1, you'd call
 	// this function with the argument:
@@ -63,9 +91,22 @@ type Prompter interface {
 	// overwrite the contents of the prompt and return control to the caller.
 	SetCommandShortcuts(shortcuts map[KeySequence]string)
 
+	// SetContinuationPrefixer sets up the prefixer to be called to generate
+	// the prefix for the lines after the first one (including wrapped lines)
+	// in a multi-line prompt, instead of repeating the prefix. Prefixes are
+	// padded or truncated to the width of the prefix of the first line to keep
+	// the text aligned.
+	SetContinuationPrefixer(prefixer ContinuationPrefixer)
+
 	// SetDebug enables/disables debug logs/messages in the prompt.
 	SetDebug(debug bool)
 
+	// SetExternalEditorFileExtension sets up the extension (like ".sql") for the
+	// temporary file used to edit the prompt text in an external editor with
+	// the EditInExternalEditor Action. This helps the editor pick the right
+	// syntax highlighting.
+	SetExternalEditorFileExtension(extension string)
+
 	// SetFooter sets up the footer line below the prompt line for each render
 	// cycle.
 	SetFooter(header string)
@@ -102,13 +143,33 @@ type Prompter interface {
 	// os.Stdin.
 	SetInput(input io.Reader)
 
+	// SetInteractive forces the prompt to render (or not render) an
+	// interactive editor, instead of detecting if the input or the output is a
+	// file or a pipe that is not a terminal. When not interactive (like when
+	// input is piped in from a file), Prompt reads the input one line at a
+	// time until the TerminationChecker deems it complete, processes history
+	// commands, emits no escape sequences, returns a DiagnosticsError if the
+	// Validator finds errors, and returns io.EOF at the end of the input.
+	SetInteractive(interactive bool)
+
 	// SetKeyMap sets up the KeyMap used for interacting with the user's input.
 	SetKeyMap(keyMap KeyMap) error
 
+	// SetMouseSupport enables or disables handling Mouse events. When enabled,
+	// clicking within the prompt moves the cursor, dragging selects text,
+	// clicking on a suggestion selects it, and the wheel scrolls the
+	// suggestions or the lines. Note that this disables the Terminal's native
+	// text selection for as long as the prompt is active.
+	SetMouseSupport(enabled bool)
+
 	// SetOutput sets up the output to go to the given io.Writer instead of
 	// os.Stdout.
 	SetOutput(output io.Writer)
 
+	// SetPlaceholder sets up the text to be shown (dimmed) when there is no
+	// user input yet.
+	SetPlaceholder(text string)
+
 	// SetPrefix sets up the prefix to use before the prompt.
 	//
 	// SetPrefix and SetPrefixer override the same property and the last
@@ -122,14 +183,49 @@ type Prompter interface {
 	// function called takes priority.
 	SetPrefixer(prefixer Prefixer)
 
+	// SetRecorder sets up the Recorder to record the key-presses and the
+	// output of the prompt with, like to replay them later. Use nil to stop
+	// recording.
+	SetRecorder(recorder Recorder)
+
 	// SetRefreshInterval sets up the minimum interval between consecutive
 	// renders. Note that this can be overridden in case of a mandatory override
 	// event like a cursor blink.
 	SetRefreshInterval(interval time.Duration)
 
+	// SetRightGutter sets up the generator to be called to generate the text
+	// to be rendered right-aligned on the first row of every line of input,
+	// like the status of each statement. The gutter of a line gets hidden
+	// whenever its text (or the right prompt on the same row) would overlap
+	// with it.
+	SetRightGutter(gutter RightGutter)
+
+	// SetRightPrompt sets up the text to be rendered right-aligned on the
+	// first (or last) line of the prompt.
+	//
+	// SetRightPrompt and SetRightPrompter override the same property and the
+	// last function called takes priority.
+	SetRightPrompt(text string)
+
+	// SetRightPrompter sets up the prefixer to be called to generate the text
+	// to be rendered right-aligned on the first (or last) line of the prompt
+	// for each render cycle. The right prompt gets hidden whenever the text on
+	// that line would overlap with it.
+	//
+	// SetRightPrompt and SetRightPrompter override the same property and the
+	// last function called takes priority.
+	SetRightPrompter(prompter Prefixer)
+
 	// SetStyle sets up the Style sheet to be followed for the render.
 	SetStyle(s Style)
 
+	// SetSynchronizedUpdates enables or disables wrapping every frame
+	// rendered in synchronized update sequences (DEC mode 2026). This is on by
+	// default (except for TERM=dumb and TERM=linux) on a best-effort basis
+	// without querying the terminal; turn it off for terminals that misbehave
+	// with them.
+	SetSynchronizedUpdates(enabled bool)
+
 	// SetSyntaxHighlighter sets up the function that will colorize and
 	// highlight keywords in the user-input.
 	SetSyntaxHighlighter(highlighter SyntaxHighlighter)
@@ -138,6 +234,26 @@ type Prompter interface {
 	// user input is done and can be returned to caller on "Terminate" action.
 	SetTerminationChecker(checker TerminationChecker)
 
+	// SetTransientPrefixer enables the transient mode where the final render
+	// of the prompt, which is left behind on the terminal once the user is
+	// done, skips the header, footer and line numbers, and uses the prefix
+	// generated by the given prefixer instead of the usual one. This keeps the
+	// scrollback free of clutter while the active prompt stays rich. Use nil
+	// to disable it.
+	SetTransientPrefixer(prefixer Prefixer)
+
+	// SetValidator sets up the function to validate the user input every time
+	// it changes. The problems found are shown as underlined text with the
+	// messages below the input, and the input is not submitted as long as
+	// there are errors.
+	SetValidator(validator Validator)
+
+	// SetVisualHomeEnd enables or disables moving to the beginning/end of the
+	// wrapped row the cursor is on (before moving to the beginning/end of the
+	// line on the next key press) with the MoveToBeginningOfLine and
+	// MoveToEndOfLine Actions.
+	SetVisualHomeEnd(enabled bool)
+
 	// SetWidthEnforcer sets up the function to wrap lines longer than the
 	// prompt width.
 	SetWidthEnforcer(enforcer WidthEnforcer)
@@ -145,6 +261,10 @@ type Prompter interface {
 	// Style returns the current Style in use, so it can be modified on the fly
 	// in between two prompts.
 	Style() *Style
+
+	// Writer returns an io.Writer that prints everything written to it above
+	// the active prompt (see Print). Useful for hooking up loggers.
+	Writer() io.Writer
 }
 
 // New returns a Prompter than can be used over and over to run a CLI.
@@ -0,0 +1,77 @@
+package prompt
+
+import (
+	"io"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// KeyPress is a key-press handled by the prompt: either a special key (like
+// Enter), or the text that was typed (or pasted) as is.
+type KeyPress struct {
+	KeySequence KeySequence // the special key pressed, if any
+	Paste       bool        // whether the Text was pasted
+	Text        string      // the text typed or pasted
+}
+
+// newKeyPress returns the KeyPress for the key event; the KeyPress is empty for
+// keys that are not supported.
+func newKeyPress(key tea.KeyMsg) KeyPress {
+	if key.Type == tea.KeyRunes && !key.Alt {
+		return KeyPress{Paste: key.Paste, Text: string(key.Runes)}
+	}
+	if ks := translateKeyToKeySequence(key); ks != "" {
+		return KeyPress{KeySequence: ks}
+	}
+	// Alt + a rune without a KeySequence is handled like the rune by itself
+	return KeyPress{Text: string(key.Runes)}
+}
+
+func (kp KeyPress) keyMsg() (tea.KeyMsg, bool) {
+	if kp.KeySequence != "" {
+		km, ok := keySequenceKeyMsgMap[kp.KeySequence]
+		return km, ok
+	}
+	if kp.Text == "" {
+		return tea.KeyMsg{}, false
+	}
+	return tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune(kp.Text), Paste: kp.Paste}, true
+}
+
+// Recorder records what goes on in the prompt so that it can be played back
+// later. The asciicast package has a Recorder that writes to asciinema files.
+type Recorder interface {
+	// RecordKeyPress is called with every key-press before it is handled.
+	RecordKeyPress(kp KeyPress)
+	// RecordOutput is called with everything written to the output.
+	RecordOutput(b []byte)
+	// RecordPromptStart is called every time the prompt is ready for input.
+	RecordPromptStart()
+	// RecordResize is called when the size of the terminal is known or when
+	// it changes.
+	RecordResize(width int, height int)
+}
+
+// recordingWriter passes on everything written to the Recorder.
+type recordingWriter struct {
+	recorder Recorder
+	writer   io.Writer
+}
+
+// Write writes the given bytes and records them.
+func (rw *recordingWriter) Write(b []byte) (int, error) {
+	n, err := rw.writer.Write(b)
+	if n > 0 {
+		rw.recorder.RecordOutput(b[:n])
+	}
+	return n, err
+}
+
+func (p *prompt) recordKeyPress(key tea.KeyMsg) {
+	if p.recorder == nil {
+		return
+	}
+	if kp := newKeyPress(key); kp.KeySequence != "" || kp.Text != "" {
+		p.recorder.RecordKeyPress(kp)
+	}
+}
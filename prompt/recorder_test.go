@@ -0,0 +1,103 @@
+package prompt
+
+import (
+	"fmt"
+	"strings"
+	"sync"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/stretchr/testify/assert"
+)
+
+type testRecorder struct {
+	keyPresses []KeyPress
+	mutex      sync.Mutex
+	numPrompts int
+	output     strings.Builder
+	sizes      []string
+}
+
+func (tr *testRecorder) RecordKeyPress(kp KeyPress) {
+	tr.mutex.Lock()
+	defer tr.mutex.Unlock()
+	tr.keyPresses = append(tr.keyPresses, kp)
+}
+
+func (tr *testRecorder) RecordOutput(b []byte) {
+	tr.mutex.Lock()
+	defer tr.mutex.Unlock()
+	tr.output.Write(b)
+}
+
+func (tr *testRecorder) RecordPromptStart() {
+	tr.mutex.Lock()
+	defer tr.mutex.Unlock()
+	tr.numPrompts++
+}
+
+func (tr *testRecorder) RecordResize(width int, height int) {
+	tr.mutex.Lock()
+	defer tr.mutex.Unlock()
+	tr.sizes = append(tr.sizes, fmt.Sprintf("%dx%d", width, height))
+}
+
+func (tr *testRecorder) numSizes() int {
+	tr.mutex.Lock()
+	defer tr.mutex.Unlock()
+	return len(tr.sizes)
+}
+
+func TestKeyPress_keyMsg(t *testing.T) {
+	km, ok := KeyPress{}.keyMsg()
+	assert.False(t, ok)
+	assert.Equal(t, tea.KeyMsg{}, km)
+
+	km, ok = KeyPress{KeySequence: "foo"}.keyMsg()
+	assert.False(t, ok)
+
+	km, ok = KeyPress{KeySequence: AltX}.keyMsg()
+	assert.True(t, ok)
+	assert.Equal(t, AltX, translateKeyToKeySequence(km))
+
+	km, ok = KeyPress{Text: "foo"}.keyMsg()
+	assert.True(t, ok)
+	assert.Equal(t, tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("foo")}, km)
+
+	km, ok = KeyPress{Paste: true, Text: "foo\nbar"}.keyMsg()
+	assert.True(t, ok)
+	assert.Equal(t, tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("foo\nbar"), Paste: true}, km)
+}
+
+func TestPrompt_recordKeyPress(t *testing.T) {
+	p := prompt{}
+	p.recordKeyPress(tea.KeyMsg{Type: tea.KeyEnter}) // no recorder, no-op
+
+	tr := &testRecorder{}
+	p.SetRecorder(tr)
+	p.recordKeyPress(tea.KeyMsg{Type: tea.KeyEnter})
+	p.recordKeyPress(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("abc")})
+	p.recordKeyPress(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("a\nb"), Paste: true})
+	p.recordKeyPress(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("x"), Alt: true})
+	p.recordKeyPress(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("1"), Alt: true})
+	p.recordKeyPress(tea.KeyMsg{Type: tea.KeyCtrlBackslash}) // not supported
+	assert.Equal(t, []KeyPress{
+		{KeySequence: Enter},
+		{Text: "abc"},
+		{Paste: true, Text: "a\nb"},
+		{KeySequence: AltX},
+		{Text: "1"},
+	}, tr.keyPresses)
+}
+
+func Test_recordingWriter(t *testing.T) {
+	tr := &testRecorder{}
+	out := strings.Builder{}
+	rw := &recordingWriter{recorder: tr, writer: &out}
+
+	n, err := rw.Write([]byte("foo\n"))
+	assert.Nil(t, err)
+	assert.Equal(t, 4, n)
+	assert.Equal(t, "foo\n", out.String())
+	assert.Equal(t, "foo\n", tr.output.String())
+}
@@ -0,0 +1,294 @@
+package prompt
+
+import (
+	"fmt"
+	"os"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/jedib0t/go-pretty/v6/text"
+	"github.com/muesli/termenv"
+)
+
+const (
+	// escSeqSyncUpdateStart and escSeqSyncUpdateEnd wrap a frame for the
+	// terminal to show it all at once instead of as it arrives (DEC mode 2026).
+	escSeqSyncUpdateStart = termenv.CSI + "?2026h"
+	escSeqSyncUpdateEnd   = termenv.CSI + "?2026l"
+
+	// screenSpanMaxGap is the number of unchanged cells between two changed
+	// ones up to which it is cheaper to rewrite them than to move over them.
+	screenSpanMaxGap = 4
+)
+
+// syncUpdateUnsupportedTerms contains the values of TERM for which the frames
+// are not to be wrapped in synchronized update sequences.
+var syncUpdateUnsupportedTerms = map[string]bool{
+	"dumb":  true,
+	"linux": true,
+}
+
+// cell is what is in a single column of a row on the terminal: a character
+// (along with any zero-width characters and escape sequences that go with it)
+// and the SGR escape sequences it is styled with. The second column of a wide
+// character is a cell without any content.
+type cell struct {
+	content string
+	style   string
+}
+
+// blankCell is what is in a column that nothing was written to.
+var blankCell = cell{content: " "}
+
+// screenRow is a row rendered on the terminal, along with the line it was
+// rendered from.
+type screenRow struct {
+	cells []cell
+	line  string
+}
+
+// parseCells splits the rendered line into the cells it takes up on the
+// terminal.
+func parseCells(line string) []cell {
+	var cells []cell
+	style, prefix := "", ""
+	appendToLastCell := func(str string) {
+		idx := len(cells) - 1
+		if cells[idx].content == "" && idx > 0 { // second column of a wide character
+			idx--
+		}
+		cells[idx].content += str
+	}
+
+	for idx := 0; idx < len(line); {
+		if line[idx] == escSeqStart {
+			seq := line[idx : idx+escSeqLength(line[idx:])]
+			idx += len(seq)
+			if strings.HasPrefix(seq, termenv.CSI) && seq[len(seq)-1] == escSeqStop {
+				style = appendSGR(style, seq)
+			} else {
+				prefix += seq
+			}
+			continue
+		}
+
+		r, size := utf8.DecodeRuneInString(line[idx:])
+		idx += size
+		width := text.RuneWidth(r)
+		if width == 0 { // combining characters and the like
+			if len(cells) > 0 && prefix == "" {
+				appendToLastCell(string(r))
+			} else {
+				prefix += string(r)
+			}
+			continue
+		}
+		cells = append(cells, cell{content: prefix + string(r), style: style})
+		prefix = ""
+		for ; width > 1; width-- {
+			cells = append(cells, cell{style: style})
+		}
+	}
+	if prefix != "" && len(cells) > 0 {
+		appendToLastCell(prefix)
+	}
+	return cells
+}
+
+// appendSGR returns the style in effect after applying the given SGR escape
+// sequence on top of the given style.
+func appendSGR(style string, seq string) string {
+	params := seq[len(termenv.CSI) : len(seq)-1]
+	if params == "" || params == "0" {
+		return ""
+	}
+	if strings.HasPrefix(params, "0;") {
+		return seq
+	}
+	return style + seq
+}
+
+// escSeqLength returns the length of the escape sequence at the beginning of
+// the given string.
+func escSeqLength(str string) int {
+	if len(str) < 2 {
+		return len(str)
+	}
+	switch str[1] {
+	case '[': // CSI: parameters and intermediates till a final byte
+		for idx := 2; idx < len(str); idx++ {
+			if str[idx] >= 0x40 && str[idx] <= 0x7e {
+				return idx + 1
+			}
+		}
+	case ']': // OSC: till BEL or ST
+		for idx := 2; idx < len(str); idx++ {
+			if str[idx] == '\a' {
+				return idx + 1
+			}
+			if str[idx] == escSeqStart && idx+1 < len(str) && str[idx+1] == '\\' {
+				return idx + 2
+			}
+		}
+	default:
+		return 2
+	}
+	return len(str)
+}
+
+// cellAt returns the cell at the given column of the row.
+func cellAt(cells []cell, column int) cell {
+	if column < len(cells) {
+		return cells[column]
+	}
+	return blankCell
+}
+
+// frameWriter builds up a frame to be written to the terminal in one go,
+// keeping track of where the cursor is and of the style in effect to avoid
+// needless escape sequences.
+type frameWriter struct {
+	strings.Builder
+
+	absolute bool   // use absolute cursor positions (full-screen mode)
+	column   int    // column of the cursor; -1 if not known
+	row      int    // row of the cursor from the top; -1 if not known
+	rows     int    // number of rows on the terminal from the top (not absolute)
+	style    string // SGR escape sequences in effect
+}
+
+// eraseBelow erases everything from the cursor to the end of the screen.
+func (fw *frameWriter) eraseBelow() {
+	fw.setStyle("")
+	_, _ = fw.WriteString(fmt.Sprintf(termenv.CSI+termenv.EraseDisplaySeq, 0))
+}
+
+// moveTo moves the cursor to the given row and column. Rows beyond the ones on
+// the terminal are made by scrolling it with line feeds, unless it is working
+// with absolute positions.
+func (fw *frameWriter) moveTo(row int, column int) {
+	if fw.absolute {
+		if row != fw.row {
+			_, _ = fw.WriteString(fmt.Sprintf(termenv.CSI+termenv.CursorPositionSeq, row+1, column+1))
+			fw.row, fw.column = row, column
+		}
+		fw.moveToColumn(column)
+		return
+	}
+
+	if row >= fw.rows {
+		fw.moveToRow(fw.rows - 1)
+		fw.setStyle("") // for the new rows to not get the background color
+		_, _ = fw.WriteString(strings.Repeat("\n", row-fw.row))
+		fw.row, fw.column, fw.rows = row, 0, row+1
+	}
+	fw.moveToRow(row)
+	fw.moveToColumn(column)
+}
+
+// moveToColumn moves the cursor to the given column on the same row.
+func (fw *frameWriter) moveToColumn(column int) {
+	if column == fw.column {
+		return
+	}
+	if column == 0 {
+		_, _ = fw.WriteString("\r")
+	} else {
+		_, _ = fw.WriteString(fmt.Sprintf(termenv.CSI+termenv.CursorHorizontalSeq, column+1))
+	}
+	fw.column = column
+}
+
+// moveToRow moves the cursor up or down to the given row in the same column.
+func (fw *frameWriter) moveToRow(row int) {
+	if row > fw.row {
+		_, _ = fw.WriteString(fmt.Sprintf(termenv.CSI+termenv.CursorDownSeq, row-fw.row))
+	} else if row < fw.row {
+		_, _ = fw.WriteString(fmt.Sprintf(termenv.CSI+termenv.CursorUpSeq, fw.row-row))
+	}
+	fw.row = row
+}
+
+// setStyle switches to the given style, by appending to the style in effect if
+// possible, or by resetting it first.
+func (fw *frameWriter) setStyle(style string) {
+	if style == fw.style {
+		return
+	}
+	if strings.HasPrefix(style, fw.style) {
+		_, _ = fw.WriteString(style[len(fw.style):])
+	} else {
+		_, _ = fw.WriteString(escSeqReset + style)
+	}
+	fw.style = style
+}
+
+// writeCells writes the cells from where the cursor is.
+func (fw *frameWriter) writeCells(cells []cell) {
+	for _, c := range cells {
+		if c.content != "" {
+			fw.setStyle(c.style)
+			_, _ = fw.WriteString(c.content)
+		}
+		fw.column++
+	}
+}
+
+// writeRow writes the cells of the row that differ from what is on the
+// terminal (all of them if that is not known), in spans, and erases whatever is
+// left of the old row beyond the new one.
+func (fw *frameWriter) writeRow(row int, oldCells []cell, newCells []cell, known bool) {
+	if !known {
+		fw.moveTo(row, 0)
+		fw.setStyle("")
+		_, _ = fw.WriteString(termenv.CSI + termenv.EraseEntireLineSeq)
+		oldCells = nil
+	}
+
+	for start := 0; start < len(newCells); {
+		if cellAt(oldCells, start) == newCells[start] {
+			start++
+			continue
+		}
+
+		end, gap := start+1, 0
+		for idx := end; idx < len(newCells) && gap <= screenSpanMaxGap; idx++ {
+			if cellAt(oldCells, idx) != newCells[idx] {
+				end, gap = idx+1, 0
+			} else {
+				gap++
+			}
+		}
+		for start > 0 && newCells[start].content == "" { // don't split wide characters
+			start--
+		}
+		for end < len(newCells) && newCells[end].content == "" {
+			end++
+		}
+
+		fw.moveTo(row, start)
+		fw.writeCells(newCells[start:end])
+		start = end
+	}
+
+	for idx := len(newCells); idx < len(oldCells); idx++ {
+		if oldCells[idx] != blankCell {
+			fw.moveTo(row, len(newCells))
+			fw.setStyle("")
+			_, _ = fw.WriteString(termenv.CSI + termenv.EraseLineRightSeq)
+			break
+		}
+	}
+}
+
+// useSyncUpdates returns true if the frames are to be wrapped in synchronized
+// update sequences, which is the case when enabled using
+// SetSynchronizedUpdates, or by default as a best-effort as terminals that do
+// not support them ignore them, except for the ones known to not handle them
+// well.
+func (p *prompt) useSyncUpdates() bool {
+	if p.syncUpdates != nil {
+		return *p.syncUpdates
+	}
+	return !syncUpdateUnsupportedTerms[os.Getenv("TERM")]
+}
@@ -0,0 +1,92 @@
+package prompt
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFrameWriter_moveTo(t *testing.T) {
+	t.Run("relative", func(t *testing.T) {
+		fw := &frameWriter{column: 0, row: 2, rows: 3}
+		fw.moveTo(0, 4)
+		fw.moveTo(0, 4)
+		fw.moveTo(1, 0)
+		assert.Equal(t, "\x1b[2A\x1b[5G\x1b[1B\r", fw.String())
+
+		// rows beyond the ones on the terminal are made with line feeds
+		fw.Reset()
+		fw.style = escSeqReverse
+		fw.moveTo(4, 2)
+		assert.Equal(t, "\x1b[1B\x1b[0m\n\n\x1b[3G", fw.String())
+		assert.Equal(t, 5, fw.rows)
+	})
+
+	t.Run("absolute", func(t *testing.T) {
+		fw := &frameWriter{absolute: true, column: -1, row: -1}
+		fw.moveTo(2, 4)
+		fw.moveTo(2, 0)
+		fw.moveTo(2, 0)
+		fw.moveTo(5, 1)
+		assert.Equal(t, "\x1b[3;5H\r\x1b[6;2H", fw.String())
+	})
+}
+
+func TestFrameWriter_setStyle(t *testing.T) {
+	fw := &frameWriter{}
+	fw.setStyle("\x1b[31m")
+	fw.setStyle("\x1b[31m")
+	fw.setStyle("\x1b[31m" + escSeqReverse)
+	fw.setStyle("\x1b[32m")
+	fw.setStyle("")
+	assert.Equal(t, "\x1b[31m\x1b[7m\x1b[0m\x1b[32m\x1b[0m", fw.String())
+}
+
+func TestFrameWriter_writeRow(t *testing.T) {
+	render := func(row int, oldLine string, newLine string, known bool) string {
+		fw := &frameWriter{column: 0, row: 0, rows: 2}
+		fw.writeRow(row, parseCells(oldLine), parseCells(newLine), known)
+		fw.setStyle("")
+		return fw.String()
+	}
+
+	assert.Equal(t, "", render(0, "foo bar", "foo bar", true))
+	assert.Equal(t, "\x1b[7Gz", render(0, "foo bar", "foo baz", true))
+	assert.Equal(t, "\x1b[1B\x1b[7Gz", render(1, "foo bar", "foo baz", true))
+	assert.Equal(t, "\x1b[2GOO BAR", render(0, "foo bar", "fOO BAR", true), "small gaps get rewritten")
+	assert.Equal(t, "\x1b[2GO\x1b[11GZ", render(0, "foo bar baz", "fOo bar baZ", true), "large gaps get skipped")
+	assert.Equal(t, "\x1b[4G\x1b[0K", render(0, "foo bar", "foo", true), "the rest of the old row gets erased")
+	assert.Equal(t, "", render(0, "foo   ", "foo", true), "blanks need no erasing")
+	assert.Equal(t, "\x1b[5G\x1b[31mbar\x1b[0m", render(0, "foo bar", "foo \x1b[31mbar\x1b[0m", true))
+	assert.Equal(t, "\x1b[2Kfoo baz", render(0, "foo bar", "foo baz", false), "unknown rows get rewritten")
+	assert.Equal(t, "\x1b[3G世", render(0, "ab12", "ab世", true), "wide characters")
+	assert.Equal(t, "\x1b[3G世", render(0, "ab界", "ab世", true), "wide characters are not split")
+	assert.Equal(t, "\x1b[3Gcd", render(0, "ab世", "abcd", true), "wide characters get replaced")
+}
+
+func Test_escSeqLength(t *testing.T) {
+	assert.Equal(t, 1, escSeqLength("\x1b"))
+	assert.Equal(t, 2, escSeqLength("\x1b7foo"))
+	assert.Equal(t, 5, escSeqLength("\x1b[31mfoo"))
+	assert.Equal(t, 6, escSeqLength("\x1b[?25hfoo"))
+	assert.Equal(t, 4, escSeqLength("\x1b[31"))
+	assert.Equal(t, 10, escSeqLength("\x1b]8;;url\x1b\\foo"))
+	assert.Equal(t, 9, escSeqLength("\x1b]8;;url\afoo"))
+}
+
+func Test_parseCells(t *testing.T) {
+	assert.Nil(t, parseCells(""))
+	assert.Equal(t, []cell{{content: "a"}, {content: "b"}}, parseCells("ab"))
+	assert.Equal(t, []cell{
+		{content: "a"},
+		{content: "b", style: "\x1b[31m"},
+		{content: "c", style: "\x1b[31m\x1b[7m"},
+		{content: "d", style: "\x1b[0;32m"},
+		{content: "e"},
+	}, parseCells("a\x1b[31mb\x1b[7mc\x1b[0;32md\x1b[0me"))
+	assert.Equal(t, []cell{{content: "世"}, {}, {content: "é"}}, parseCells("世é"))
+	assert.Equal(t, []cell{
+		{content: "\x1b]8;;url\x1b\\a"},
+		{content: "b\x1b]8;;\x1b\\"},
+	}, parseCells("\x1b]8;;url\x1b\\ab\x1b]8;;\x1b\\"))
+}
@@ -2,20 +2,28 @@ package prompt
 
 import (
 	"fmt"
+	"strings"
 	"time"
+	"unicode"
 
+	"github.com/jedib0t/go-pretty/v6/text"
 	"github.com/muesli/termenv"
 )
 
 // Style is used to customize the look and feel of everything about the prompt.
 type Style struct {
-	AutoComplete StyleAutoComplete `json:"auto_complete"`
-	Colors       StyleColors       `json:"colors"`
-	Cursor       StyleCursor       `json:"cursor"`
-	Dimensions   StyleDimensions   `json:"dimensions"`
-	LineNumbers  StyleLineNumbers  `json:"line_numbers"`
-	Scrollbar    StyleScrollbar    `json:"scrollbar"`
-	TabString    string            `json:"tab_string"`
+	AutoComplete     StyleAutoComplete     `json:"auto_complete"`
+	Brackets         StyleBrackets         `json:"brackets"`
+	Colors           StyleColors           `json:"colors"`
+	Cursor           StyleCursor           `json:"cursor"`
+	Dimensions       StyleDimensions       `json:"dimensions"`
+	HorizontalScroll StyleHorizontalScroll `json:"horizontal_scroll"`
+	Indent           StyleIndent           `json:"indent"`
+	LineComment      string                `json:"line_comment"`
+	LineNumbers      StyleLineNumbers      `json:"line_numbers"`
+	RightPrompt      StyleRightPrompt      `json:"right_prompt"`
+	Scrollbar        StyleScrollbar        `json:"scrollbar"`
+	TabString        string                `json:"tab_string"`
 }
 
 // Validate ensures that the Style can be used without issues.
@@ -28,13 +36,18 @@ func (s Style) Validate() error {
 
 // StyleDefault - default Style when none provided.
 var StyleDefault = Style{
-	AutoComplete: StyleAutoCompleteDefault,
-	Colors:       StyleColorsDefault,
-	Cursor:       StyleCursorDefault,
-	Dimensions:   StyleDimensionsDefault,
-	LineNumbers:  StyleLineNumbersNone,
-	Scrollbar:    StyleScrollbarDefault,
-	TabString:    "    ",
+	AutoComplete:     StyleAutoCompleteDefault,
+	Brackets:         StyleBracketsDefault,
+	Colors:           StyleColorsDefault,
+	Cursor:           StyleCursorDefault,
+	Dimensions:       StyleDimensionsDefault,
+	HorizontalScroll: StyleHorizontalScrollDefault,
+	Indent:           StyleIndentDefault,
+	LineComment:      "# ",
+	LineNumbers:      StyleLineNumbersNone,
+	RightPrompt:      StyleRightPromptDefault,
+	Scrollbar:        StyleScrollbarDefault,
+	TabString:        "    ",
 }
 
 // StyleAutoComplete is used to customize the look and feel of the auto-complete
@@ -94,10 +107,68 @@ var StyleAutoCompleteDefault = StyleAutoComplete{
 	},
 }
 
+// StyleBrackets is used to customize the highlighting of matching brackets,
+// and the auto-pairing of brackets and quotes. String literals and comments
+// are skipped while looking for the matching bracket, and so these depend on
+// the language of the input.
+type StyleBrackets struct {
+	AutoPair          bool     `json:"auto_pair"`           // insert the closing bracket/quote along with the opening one
+	Color             Color    `json:"color"`               // color for the matching brackets
+	CommentBlockEnd   string   `json:"comment_block_end"`   // marker that ends a block comment
+	CommentBlockStart string   `json:"comment_block_start"` // marker that starts a block comment
+	CommentLine       []string `json:"comment_line"`        // markers that start a comment until the end of the line
+	Highlight         bool     `json:"highlight"`           // highlight the bracket matching the one at/before the cursor
+	Pairs             string   `json:"pairs"`               // opening and closing brackets one after the other; ex.: "()[]{}"
+	Quotes            string   `json:"quotes"`              // runes that start and end a string literal
+}
+
+var (
+	// StyleBracketsDefault - default Style when none provided.
+	StyleBracketsDefault = StyleBrackets{
+		AutoPair: false,
+		Color: Color{
+			Foreground: termenv.ANSI256Color(231),
+			Background: termenv.ANSI256Color(240),
+		},
+		Highlight: true,
+		Pairs:     "()[]{}",
+		Quotes:    "\"'",
+	}
+
+	// StyleBracketsGo - Style for Go code with auto-pairing.
+	StyleBracketsGo = StyleBrackets{
+		AutoPair:          true,
+		Color:             StyleBracketsDefault.Color,
+		CommentBlockEnd:   "*/",
+		CommentBlockStart: "/*",
+		CommentLine:       []string{"//"},
+		Highlight:         true,
+		Pairs:             "()[]{}",
+		Quotes:            "\"'`",
+	}
+
+	// StyleBracketsSQL - Style for SQL with auto-pairing.
+	StyleBracketsSQL = StyleBrackets{
+		AutoPair:          true,
+		Color:             StyleBracketsDefault.Color,
+		CommentBlockEnd:   "*/",
+		CommentBlockStart: "/*",
+		CommentLine:       []string{"--"},
+		Highlight:         true,
+		Pairs:             "()[]",
+		Quotes:            "\"'`",
+	}
+)
+
 // StyleColors is used to customize the colors used on the prompt.
 type StyleColors struct {
-	Debug Color `json:"debug"`
-	Error Color `json:"error"`
+	Debug             Color `json:"debug"`
+	DiagnosticError   Color `json:"diagnostic_error"`
+	DiagnosticWarning Color `json:"diagnostic_warning"`
+	Error             Color `json:"error"`
+	Placeholder       Color `json:"placeholder"`
+	Selection         Color `json:"selection"`
+	StatusLine        Color `json:"status_line"`
 }
 
 // StyleColorsDefault - default style when none provided.
@@ -106,19 +177,44 @@ var StyleColorsDefault = StyleColors{
 		Foreground: termenv.ANSI256Color(22),
 		Background: termenv.ANSI256Color(232),
 	},
+	DiagnosticError: Color{
+		Foreground: termenv.ANSI256Color(9),
+	},
+	DiagnosticWarning: Color{
+		Foreground: termenv.ANSI256Color(214),
+	},
 	Error: Color{
 		Foreground: termenv.ANSI256Color(9),
 		Background: termenv.BackgroundColor(),
 	},
+	Placeholder: Color{
+		Foreground: termenv.ANSI256Color(242),
+	},
+	Selection: Color{
+		Foreground: termenv.ANSI256Color(231),
+		Background: termenv.ANSI256Color(24),
+	},
+	StatusLine: Color{
+		Foreground: termenv.ANSI256Color(250),
+		Background: termenv.ANSI256Color(237),
+	},
 }
 
-// StyleCursor is used to customize the look and feel of the cursor.
+// StyleCursor is used to customize the look and feel of the cursor. The cursor
+// is painted on to the text using the colors by default. With Terminal set, the
+// terminal's own cursor is placed at the cursor location instead, using the
+// shape for the current mode; the painted cursor is used as a fallback on
+// terminals that do not support it.
 type StyleCursor struct {
-	Blink         bool          `json:"blink"`
-	BlinkInterval time.Duration `json:"blink_interval"`
-	Color         Color         `json:"color"`
-	ColorAlt      Color         `json:"color_alt"`
-	Enabled       bool          `json:"enabled"`
+	Blink             bool          `json:"blink"`
+	BlinkInterval     time.Duration `json:"blink_interval"`
+	Color             Color         `json:"color"`
+	ColorAlt          Color         `json:"color_alt"`
+	Enabled           bool          `json:"enabled"`
+	Shape             CursorShape   `json:"shape"`               // terminal cursor shape while editing
+	ShapeAutoComplete CursorShape   `json:"shape_auto_complete"` // terminal cursor shape with suggestions shown
+	ShapeSelection    CursorShape   `json:"shape_selection"`     // terminal cursor shape with text selected
+	Terminal          bool          `json:"terminal"`            // use the terminal's cursor instead of painting one
 }
 
 // StyleCursorDefault - default style when none provided.
@@ -136,6 +232,21 @@ var StyleCursorDefault = StyleCursor{
 	Enabled: true,
 }
 
+// StyleCursorTerminal - style to use the terminal's cursor with a blinking bar
+// while editing, a blinking underline while choosing suggestions, and a steady
+// block while selecting text.
+var StyleCursorTerminal = StyleCursor{
+	Blink:             StyleCursorDefault.Blink,
+	BlinkInterval:     StyleCursorDefault.BlinkInterval,
+	Color:             StyleCursorDefault.Color,
+	ColorAlt:          StyleCursorDefault.ColorAlt,
+	Enabled:           true,
+	Shape:             CursorShapeBlinkingBar,
+	ShapeAutoComplete: CursorShapeBlinkingUnderline,
+	ShapeSelection:    CursorShapeSteadyBlock,
+	Terminal:          true,
+}
+
 // StyleDimensions is used to customize the sizing of the prompt
 type StyleDimensions struct {
 	HeightMin uint `json:"height_min"`
@@ -164,6 +275,137 @@ func (sd StyleDimensions) validate() error {
 	return nil
 }
 
+// StyleHorizontalScroll is used to customize the horizontal scrolling of lines
+// that are longer than the prompt width. When enabled, each line is kept on a
+// single row (instead of being wrapped by the WidthEnforcer), and the view
+// scrolls to keep the cursor visible.
+type StyleHorizontalScroll struct {
+	Color          Color `json:"color"`           // color for the indicators
+	Enabled        bool  `json:"enabled"`         // scroll single-line inputs instead of wrapping them
+	IndicatorLeft  rune  `json:"indicator_left"`  // shown when the content is clipped on the left
+	IndicatorRight rune  `json:"indicator_right"` // shown when the content is clipped on the right
+	Margin         int   `json:"margin"`          // columns to keep visible around the cursor (at least 1)
+	MultiLine      bool  `json:"multi_line"`      // scroll multi-line inputs too instead of wrapping them
+}
+
+var (
+	// StyleHorizontalScrollDefault - default Style when none provided; lines
+	// are wrapped.
+	StyleHorizontalScrollDefault = StyleHorizontalScroll{
+		Color: Color{
+			Foreground: termenv.ANSI256Color(244),
+		},
+		Enabled:        false,
+		IndicatorLeft:  '«',
+		IndicatorRight: '»',
+		Margin:         4,
+		MultiLine:      false,
+	}
+
+	// StyleHorizontalScrollEnabled - Style to scroll all lines horizontally.
+	StyleHorizontalScrollEnabled = StyleHorizontalScroll{
+		Color:          StyleHorizontalScrollDefault.Color,
+		Enabled:        true,
+		IndicatorLeft:  '«',
+		IndicatorRight: '»',
+		Margin:         4,
+		MultiLine:      true,
+	}
+)
+
+// isActive returns true if the lines should be scrolled instead of wrapped.
+func (s StyleHorizontalScroll) isActive(numLines int) bool {
+	return s.Enabled && (numLines <= 1 || s.MultiLine)
+}
+
+// clip returns the part of the line that is visible from the given offset
+// within the given width, with the indicators in place of the first/last
+// visible columns if content is clipped on that side.
+func (s StyleHorizontalScroll) clip(line string, offset int, width int) string {
+	lineWidth := text.RuneWidthWithoutEscSequences(line)
+	isClippedLeft := offset > 0 && lineWidth > 0
+	isClippedRight := lineWidth > offset+width
+
+	start, stop := offset, offset+width-1
+	if isClippedLeft {
+		start++
+	}
+	if isClippedRight {
+		stop--
+	}
+
+	out := strings.Builder{}
+	if isClippedLeft {
+		out.WriteString(s.Color.Sprint(string(s.IndicatorLeft)))
+	}
+	out.WriteString(stringSubset(line, start, stop))
+	if isClippedRight {
+		out.WriteString(s.Color.Sprint(string(s.IndicatorRight)))
+	}
+	return out.String()
+}
+
+// StyleIndent is used to customize the indentation of new lines in multi-line
+// prompts. If auto-indent is enabled, a new line gets the indentation of the
+// line before it, and one more level (Style.TabString) if the line before it
+// ends with one of the given openers (matched case-insensitively).
+type StyleIndent struct {
+	Auto          bool     `json:"auto"`           // copy the indentation of the previous line
+	IncreaseAfter []string `json:"increase_after"` // openers after which the indentation increases
+}
+
+var (
+	// StyleIndentDefault - default Style when none provided.
+	StyleIndentDefault = StyleIndent{
+		Auto:          true,
+		IncreaseAfter: []string{"(", "[", "{"},
+	}
+
+	// StyleIndentNone - Style to disable auto-indentation.
+	StyleIndentNone = StyleIndent{
+		Auto: false,
+	}
+
+	// StyleIndentPython - Style for Python code.
+	StyleIndentPython = StyleIndent{
+		Auto:          true,
+		IncreaseAfter: []string{"(", "[", "{", ":"},
+	}
+
+	// StyleIndentSQL - Style for SQL.
+	StyleIndentSQL = StyleIndent{
+		Auto:          true,
+		IncreaseAfter: []string{"(", "BEGIN"},
+	}
+)
+
+// indentation returns the indentation for a new line inserted after the given
+// text.
+func (s StyleIndent) indentation(textBefore string, tab string) string {
+	if !s.Auto {
+		return ""
+	}
+
+	indent := textBefore[:len(textBefore)-len(strings.TrimLeft(textBefore, " \t"))]
+	textBefore = strings.ToLower(strings.TrimRight(textBefore, " \t"))
+	for _, opener := range s.IncreaseAfter {
+		opener = strings.ToLower(opener)
+		if opener == "" || !strings.HasSuffix(textBefore, opener) {
+			continue
+		}
+		// openers that are words need to be whole words
+		isWordByte := func(b byte) bool {
+			return b == '_' || unicode.IsLetter(rune(b)) || unicode.IsDigit(rune(b))
+		}
+		idxBefore := len(textBefore) - len(opener) - 1
+		if isWordByte(opener[0]) && idxBefore >= 0 && isWordByte(textBefore[idxBefore]) {
+			continue
+		}
+		return indent + tab
+	}
+	return indent
+}
+
 // StyleLineNumbers is used to customize the look and feel of the line numbers
 // in the prompt.
 type StyleLineNumbers struct {
@@ -189,11 +431,24 @@ var (
 	}
 )
 
+// StyleRightPrompt is used to customize the placement of the right-aligned
+// prompt.
+type StyleRightPrompt struct {
+	Margin     int  `json:"margin"`       // minimum gap between the text and the right prompt
+	OnLastLine bool `json:"on_last_line"` // render on the last line instead of the first
+}
+
+// StyleRightPromptDefault - default Style when none provided.
+var StyleRightPromptDefault = StyleRightPrompt{
+	Margin:     1,
+	OnLastLine: false,
+}
+
 // StyleScrollbar is used to customize the look and feel of the scrollbar.
 type StyleScrollbar struct {
-	Color          Color
-	Indicator      rune
-	IndicatorEmpty rune
+	Color          Color `json:"color"`
+	Indicator      rune  `json:"indicator"`
+	IndicatorEmpty rune  `json:"indicator_empty"`
 }
 
 var (
@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"testing"
 
+	"github.com/muesli/termenv"
 	"github.com/stretchr/testify/assert"
 )
 
@@ -30,6 +31,53 @@ func TestStyle_Validate(t *testing.T) {
 	assert.Contains(t, err.Error(), "width-min [50] cannot be greater than width-max [40]")
 }
 
+func TestStyleHorizontalScroll_clip(t *testing.T) {
+	s := StyleHorizontalScrollEnabled
+	s.Color = Color{}
+	line := "0123456789"
+
+	assert.Equal(t, line, s.clip(line, 0, 10))
+	assert.Equal(t, "0123»", s.clip(line, 0, 5))
+	assert.Equal(t, "«345»", s.clip(line, 2, 5))
+	assert.Equal(t, "«6789", s.clip(line, 5, 5))
+	assert.Equal(t, "«", s.clip("01", 5, 5))
+	assert.Equal(t, "", s.clip("", 5, 5))
+
+	// colors are retained
+	color := Color{Foreground: termenv.ANSI256Color(9)}
+	assert.Equal(t, "«"+color.Sprint("345")+"»", s.clip(color.Sprint(line), 2, 5))
+}
+
+func TestStyleHorizontalScroll_isActive(t *testing.T) {
+	assert.False(t, StyleHorizontalScrollDefault.isActive(1))
+
+	s := StyleHorizontalScrollEnabled
+	assert.True(t, s.isActive(1))
+	assert.True(t, s.isActive(2))
+	s.MultiLine = false
+	assert.True(t, s.isActive(1))
+	assert.False(t, s.isActive(2))
+}
+
+func TestStyleIndent_indentation(t *testing.T) {
+	tab := "  "
+	assert.Equal(t, "", StyleIndentNone.indentation("  foo(", tab))
+
+	s := StyleIndentDefault
+	assert.Equal(t, "", s.indentation("", tab))
+	assert.Equal(t, "", s.indentation("foo", tab))
+	assert.Equal(t, "    ", s.indentation("    foo", tab))
+	assert.Equal(t, "    ", s.indentation("    ", tab))
+	assert.Equal(t, "  ", s.indentation("foo( ", tab))
+	assert.Equal(t, "    ", s.indentation("  {", tab))
+	assert.Equal(t, "", s.indentation("foo:", tab))
+
+	assert.Equal(t, "  ", StyleIndentPython.indentation("if x:", tab))
+	assert.Equal(t, "   ", StyleIndentSQL.indentation(" begin", tab))
+	assert.Equal(t, "  ", StyleIndentSQL.indentation("IF x THEN BEGIN", tab))
+	assert.Equal(t, "", StyleIndentSQL.indentation("xbegin", tab))
+}
+
 func TestStyleScrollbar_Generate(t *testing.T) {
 	s := StyleScrollbarDefault
 
@@ -5,6 +5,7 @@ import (
 	"strings"
 
 	"github.com/jedib0t/go-pretty/v6/text"
+	"github.com/muesli/termenv"
 )
 
 // calculateViewportRange returns the 0-indexed start and stop values given the
@@ -36,6 +37,43 @@ func calculateViewportRange(numLines int, cursorLine int, maxHeight int) (int, i
 	return start, stop
 }
 
+// calculateHorizontalScrollOffset returns the 0-indexed column from which the
+// content should be shown to keep the cursor visible, with at least the given
+// margin around it, given the previous offset and the width of the content and
+// the viewport. The offset changes only when the cursor gets too close to the
+// edges to keep the view stable. The margin is at least 1 (if the width allows
+// it) as the scroll indicators take the place of the first/last columns when
+// the content is clipped on that side.
+func calculateHorizontalScrollOffset(offset int, cursorColumn int, contentWidth int, width int, margin int) int {
+	// if everything fits (including the cursor after the end), don't scroll
+	if width <= 0 || contentWidth < width {
+		return 0
+	}
+
+	maxMargin := (width - 1) / 2
+	if margin > maxMargin {
+		margin = maxMargin
+	}
+	if margin < 1 && maxMargin >= 1 {
+		// the offset is clamped below if the side is not clipped after all
+		margin = 1
+	}
+	if cursorColumn < offset+margin {
+		offset = cursorColumn - margin
+	}
+	if cursorColumn > offset+width-1-margin {
+		offset = cursorColumn - (width - 1 - margin)
+	}
+	// don't scroll past the end of the content
+	if maxOffset := contentWidth + 1 - width; offset > maxOffset {
+		offset = maxOffset
+	}
+	if offset < 0 {
+		offset = 0
+	}
+	return offset
+}
+
 // clampValue returns the value that fits inside the min/max range.
 func clampValue(val, min, max int) int {
 	if min > 0 && val < min {
@@ -56,7 +94,78 @@ func clampValueAllowZero(val, min, max int) int {
 	return clampValue(val, min, max)
 }
 
-func insertCursor(input string, insertIdx int, color Color) string {
+func highlightRange(input string, startIdx int, stopIdx int, color Color) string {
+	if startIdx >= stopIdx {
+		return input
+	}
+
+	out, highlighted := strings.Builder{}, strings.Builder{}
+	visibleCharIdx, escSeq, inEscSeq := 0, make([]rune, 0), false
+	flushHighlighted := func() {
+		if highlighted.Len() > 0 {
+			if len(escSeq) > 0 {
+				out.WriteString(escSeqReset)
+			}
+			out.WriteString(color.Sprint(highlighted.String()))
+			out.WriteString(string(escSeq))
+			highlighted.Reset()
+		}
+	}
+	for _, r := range input {
+		inRange := visibleCharIdx >= startIdx && visibleCharIdx < stopIdx
+		if !inRange {
+			flushHighlighted()
+		}
+
+		// keep track of the color coding escape sequences, but drop the ones
+		// within the range to not override the highlight color
+		if r == escSeqStart || inEscSeq {
+			inEscSeq = r != escSeqStop
+			escSeq = append(escSeq, r)
+			if !inEscSeq && strings.HasSuffix(string(escSeq), escSeqReset) {
+				escSeq = make([]rune, 0)
+			}
+			if !inRange {
+				out.WriteRune(r)
+			}
+			continue
+		}
+
+		if inRange {
+			highlighted.WriteRune(r)
+		} else {
+			out.WriteRune(r)
+		}
+		visibleCharIdx++
+	}
+	flushHighlighted()
+
+	return out.String()
+}
+
+// byteOffsetOfColumn returns the offset of the character at the given visible
+// column in the given string (without any escape sequences).
+func byteOffsetOfColumn(str string, column int) int {
+	width := 0
+	for idx, r := range str {
+		if width >= column {
+			return idx
+		}
+		width += text.RuneWidth(r)
+	}
+	return len(str)
+}
+
+// insertCursor renders the character at the given index as the cursor using
+// the given color, or in reverse video if the color profile supports no colors.
+func insertCursor(input string, insertIdx int, color Color, profile termenv.Profile) string {
+	sprintCursor := color.Sprint
+	if profile == termenv.Ascii {
+		sprintCursor = func(a ...any) string {
+			return escSeqReverse + fmt.Sprint(a...) + escSeqReverseOff
+		}
+	}
+
 	inputRunes := []rune(input)
 	visibleCharIdx, escSeq, inEscSeq := 0, make([]rune, 0), false
 	for idx, r := range inputRunes {
@@ -84,7 +193,7 @@ func insertCursor(input string, insertIdx int, color Color) string {
 					output = append(output, []rune(escSeqReset)...)
 				}
 			}
-			output = append(output, []rune(color.Sprintf("%c", inputRunes[idx]))...)
+			output = append(output, []rune(sprintCursor(string(inputRunes[idx])))...)
 			if len(escSeq) > 0 {
 				output = append(output, escSeq...)
 			}
@@ -94,7 +203,44 @@ func insertCursor(input string, insertIdx int, color Color) string {
 		visibleCharIdx++
 	}
 
-	return fmt.Sprintf("%s%s", input, color.Sprint(" "))
+	return fmt.Sprintf("%s%s", input, sprintCursor(" "))
+}
+
+// underlineRange underlines the visible characters in the given range, while
+// retaining the colors of the text (like the ones from syntax highlighting).
+func underlineRange(input string, startIdx int, stopIdx int) string {
+	if startIdx >= stopIdx {
+		return input
+	}
+
+	out := strings.Builder{}
+	visibleCharIdx, inEscSeq, inRange := 0, false, false
+	for _, r := range input {
+		if r == escSeqStart || inEscSeq {
+			inEscSeq = r != escSeqStop
+			out.WriteRune(r)
+			// re-apply the underline in case the escape sequence reset it
+			if !inEscSeq && inRange {
+				out.WriteString(escSeqUnderline)
+			}
+			continue
+		}
+
+		if visibleCharIdx == startIdx {
+			out.WriteString(escSeqUnderline)
+			inRange = true
+		} else if visibleCharIdx == stopIdx && inRange {
+			out.WriteString(escSeqUnderlineOff)
+			inRange = false
+		}
+		out.WriteRune(r)
+		visibleCharIdx++
+	}
+	if inRange {
+		out.WriteString(escSeqUnderlineOff)
+	}
+
+	return out.String()
 }
 
 func overwriteContents(input string, newContent string, insertIdx int, maxWidth int) string {
@@ -175,3 +321,28 @@ func stringSubset(input string, start, stop int) string {
 
 	return string(output)
 }
+
+// trimToWidth trims the given string to fit within the given width, while
+// retaining all the escape sequences in it.
+func trimToWidth(str string, width int) string {
+	if text.RuneWidthWithoutEscSequences(str) <= width {
+		return str
+	}
+
+	out := strings.Builder{}
+	outWidth, inEscSeq := 0, false
+	for _, r := range str {
+		if r == escSeqStart || inEscSeq {
+			inEscSeq = r != escSeqStop
+			out.WriteRune(r)
+			continue
+		}
+		if rWidth := text.RuneWidth(r); outWidth+rWidth <= width {
+			out.WriteRune(r)
+			outWidth += rWidth
+		} else {
+			outWidth = width // nothing more fits after a wide character either
+		}
+	}
+	return out.String()
+}
@@ -8,6 +8,47 @@ import (
 	"github.com/stretchr/testify/assert"
 )
 
+func Test_byteOffsetOfColumn(t *testing.T) {
+	assert.Equal(t, 0, byteOffsetOfColumn("abc", 0))
+	assert.Equal(t, 2, byteOffsetOfColumn("abc", 2))
+	assert.Equal(t, 3, byteOffsetOfColumn("abc", 5))
+	assert.Equal(t, 3, byteOffsetOfColumn("世界x", 2))
+	assert.Equal(t, 7, byteOffsetOfColumn("世界x", 5))
+}
+
+func Test_calculateHorizontalScrollOffset(t *testing.T) {
+	// everything fits
+	assert.Equal(t, 0, calculateHorizontalScrollOffset(5, 9, 9, 10, 2))
+	assert.Equal(t, 0, calculateHorizontalScrollOffset(5, 9, 9, 0, 2))
+
+	// cursor moving right
+	assert.Equal(t, 0, calculateHorizontalScrollOffset(0, 7, 30, 10, 2))
+	assert.Equal(t, 1, calculateHorizontalScrollOffset(0, 8, 30, 10, 2))
+	assert.Equal(t, 21, calculateHorizontalScrollOffset(0, 28, 30, 10, 2))
+	assert.Equal(t, 21, calculateHorizontalScrollOffset(0, 30, 30, 10, 2))
+
+	// cursor moving left
+	assert.Equal(t, 10, calculateHorizontalScrollOffset(10, 12, 30, 10, 2))
+	assert.Equal(t, 9, calculateHorizontalScrollOffset(10, 11, 30, 10, 2))
+	assert.Equal(t, 0, calculateHorizontalScrollOffset(10, 1, 30, 10, 2))
+
+	// margin too big for the width
+	assert.Equal(t, 6, calculateHorizontalScrollOffset(0, 8, 30, 5, 10))
+	assert.Equal(t, 7, calculateHorizontalScrollOffset(0, 8, 30, 3, 10))
+
+	// no margin: the cursor still stays clear of the scroll indicators on the
+	// clipped sides, but can reach the edges that are not clipped
+	assert.Equal(t, 0, calculateHorizontalScrollOffset(0, 8, 30, 10, 0))
+	assert.Equal(t, 1, calculateHorizontalScrollOffset(0, 9, 30, 10, 0))
+	assert.Equal(t, 21, calculateHorizontalScrollOffset(0, 30, 30, 10, 0))
+	assert.Equal(t, 9, calculateHorizontalScrollOffset(10, 10, 30, 10, 0))
+	assert.Equal(t, 0, calculateHorizontalScrollOffset(10, 0, 30, 10, 0))
+	assert.Equal(t, 9, calculateHorizontalScrollOffset(10, 9, 30, 2, 0)) // no room
+
+	// content got shorter
+	assert.Equal(t, 11, calculateHorizontalScrollOffset(15, 18, 20, 10, 2))
+}
+
 func Test_calculateViewportRange(t *testing.T) {
 	start, stop := calculateViewportRange(5, -1, -1)
 	assert.Equal(t, 0, start)
@@ -91,14 +132,56 @@ func Benchmark_insertCursor(b *testing.B) {
 	input := colorContent1.Sprint("select")
 	expectedOutput := colorCursor.Sprint("s") +
 		colorContent1.Sprint("elect")
-	output := insertCursor(input, 0, colorCursor)
+	output := insertCursor(input, 0, colorCursor, termenv.TrueColor)
 	assert.Equal(b, expectedOutput, output)
 
 	for idx := 0; idx < b.N; idx++ {
-		insertCursor(input, 0, colorCursor)
+		insertCursor(input, 0, colorCursor, termenv.TrueColor)
 	}
 }
 
+func Test_highlightRange(t *testing.T) {
+	colorContent1 := Color{Foreground: termenv.ANSI256Color(81), Background: termenv.ANSI256Color(0)}
+	colorContent2 := Color{Foreground: termenv.ANSI256Color(82), Background: termenv.ANSI256Color(0)}
+	colorHighlight := StyleColorsDefault.Selection
+
+	input := "select"
+	assert.Equal(t, input, highlightRange(input, 2, 2, colorHighlight))
+	assert.Equal(t, "se"+colorHighlight.Sprint("le")+"ct", highlightRange(input, 2, 4, colorHighlight))
+	assert.Equal(t, colorHighlight.Sprint("select"), highlightRange(input, 0, 10, colorHighlight))
+
+	input = colorContent1.Sprint("select")
+	expectedOutput := colorContent1.Sprint("s") +
+		colorHighlight.Sprint("ele") +
+		colorContent1.Sprint("ct")
+	assert.Equal(t, expectedOutput, highlightRange(input, 1, 4, colorHighlight))
+
+	input = colorContent1.Sprint("select") +
+		colorContent2.Sprint(" ") +
+		colorContent1.Sprint("foo")
+	expectedOutput = colorContent1.Sprint("sel") +
+		colorHighlight.Sprint("ect f") +
+		colorContent1.Sprint("oo")
+	assert.Equal(t, expectedOutput, highlightRange(input, 3, 8, colorHighlight))
+}
+
+func Test_underlineRange(t *testing.T) {
+	colorContent1 := Color{Foreground: termenv.ANSI256Color(81), Background: termenv.ANSI256Color(0)}
+
+	input := "select"
+	assert.Equal(t, input, underlineRange(input, 2, 2))
+	assert.Equal(t, "se\x1b[4mle\x1b[24mct", underlineRange(input, 2, 4))
+	assert.Equal(t, "\x1b[4mselect\x1b[24m", underlineRange(input, 0, 10))
+
+	// retains the colors, and re-applies the underline after every escape
+	// sequence in the range
+	input = colorContent1.Sprint("select") + " foo"
+	assert.Equal(t,
+		"\x1b[38;5;81;48;5;0ms\x1b[4melect\x1b[0m\x1b[4m f\x1b[24moo",
+		underlineRange(input, 1, 8),
+	)
+}
+
 func Test_insertCursor(t *testing.T) {
 	colorContent1 := Color{Foreground: termenv.ANSI256Color(81), Background: termenv.ANSI256Color(0)}
 	colorContent2 := Color{Foreground: termenv.ANSI256Color(82), Background: termenv.ANSI256Color(0)}
@@ -106,19 +189,19 @@ func Test_insertCursor(t *testing.T) {
 
 	input := "select"
 	expectedOutput := colorCursor.Sprint("s") + "elect"
-	output := insertCursor(input, 0, colorCursor)
+	output := insertCursor(input, 0, colorCursor, termenv.TrueColor)
 	assert.Equal(t, expectedOutput, output)
 
 	input = colorContent1.Sprint("select")
 	expectedOutput = colorCursor.Sprint("s") +
 		colorContent1.Sprint("elect")
-	output = insertCursor(input, 0, colorCursor)
+	output = insertCursor(input, 0, colorCursor, termenv.TrueColor)
 	assert.Equal(t, expectedOutput, output)
 
 	input = colorContent1.Sprint("select")
 	expectedOutput = colorContent1.Sprint("select") +
 		colorCursor.Sprint(" ")
-	output = insertCursor(input, 10, colorCursor)
+	output = insertCursor(input, 10, colorCursor, termenv.TrueColor)
 	assert.Equal(t, expectedOutput, output)
 
 	input = colorContent1.Sprint("select") +
@@ -129,8 +212,13 @@ func Test_insertCursor(t *testing.T) {
 		colorContent1.Sprint("f") +
 		colorCursor.Sprint("o") +
 		colorContent1.Sprint("o")
-	output = insertCursor(input, 8, colorCursor)
+	output = insertCursor(input, 8, colorCursor, termenv.TrueColor)
 	assert.Equal(t, expectedOutput, output)
+
+	t.Run("no colors", func(t *testing.T) {
+		assert.Equal(t, "\x1b[7ms\x1b[27melect", insertCursor("select", 0, colorCursor, termenv.Ascii))
+		assert.Equal(t, "select\x1b[7m \x1b[27m", insertCursor("select", 10, colorCursor, termenv.Ascii))
+	})
 }
 
 func Test_overwriteContent(t *testing.T) {
@@ -267,3 +355,12 @@ func Test_stringSubset(t *testing.T) {
 		assert.Equal(t, "", stringSubset(input, 5, 4))
 	})
 }
+
+func Test_trimToWidth(t *testing.T) {
+	assert.Equal(t, "abc", trimToWidth("abc", 3))
+	assert.Equal(t, "ab", trimToWidth("abc", 2))
+	assert.Equal(t, "", trimToWidth("abc", 0))
+	assert.Equal(t, "\x1b[31mab\x1b[0m", trimToWidth("\x1b[31mabc\x1b[0m", 2))
+	assert.Equal(t, "世", trimToWidth("世界", 3))
+	assert.Equal(t, "a", trimToWidth("a世b", 2))
+}
@@ -0,0 +1,64 @@
+package prompt
+
+import (
+	"fmt"
+	"strings"
+)
+
+// DiagnosticSeverity defines how severe a Diagnostic is.
+type DiagnosticSeverity int
+
+// Supported DiagnosticSeverity values.
+const (
+	DiagnosticSeverityError   DiagnosticSeverity = iota // prevents the input from being submitted
+	DiagnosticSeverityWarning                           // informs the user, but allows the input to be submitted
+)
+
+// Diagnostic is a problem found in the user input by a Validator.
+type Diagnostic struct {
+	Start    CursorLocation // beginning of the range with the problem
+	End      CursorLocation // end of the range with the problem (exclusive)
+	Message  string
+	Severity DiagnosticSeverity
+}
+
+// Validator validates the user input and returns the problems found in it (if
+// any). This is called every time the input changes, and before the input is
+// submitted on the "Terminate" action; the input is not submitted as long as
+// there are any errors.
+type Validator func(input string) []Diagnostic
+
+// DiagnosticsError is returned by Prompt in the non-interactive mode when the
+// Validator finds errors in an input that is otherwise complete. It unwraps to
+// ErrInvalidInput.
+type DiagnosticsError struct {
+	Input       string
+	Diagnostics []Diagnostic
+}
+
+// Error returns the location and the message of every error found.
+func (e *DiagnosticsError) Error() string {
+	var problems []string
+	for _, diagnostic := range e.Diagnostics {
+		if diagnostic.Severity == DiagnosticSeverityError {
+			problems = append(problems, fmt.Sprintf("%d:%d: %s",
+				diagnostic.Start.Line+1, diagnostic.Start.Column+1, diagnostic.Message))
+		}
+	}
+	return fmt.Sprintf("%v: %s", ErrInvalidInput, strings.Join(problems, "; "))
+}
+
+// Unwrap returns ErrInvalidInput.
+func (e *DiagnosticsError) Unwrap() error {
+	return ErrInvalidInput
+}
+
+// hasErrors returns true if any of the diagnostics is an error.
+func hasErrors(diagnostics []Diagnostic) bool {
+	for _, diagnostic := range diagnostics {
+		if diagnostic.Severity == DiagnosticSeverityError {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,16 @@
+package prompt
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_hasErrors(t *testing.T) {
+	assert.False(t, hasErrors(nil))
+	assert.False(t, hasErrors([]Diagnostic{{Severity: DiagnosticSeverityWarning}}))
+	assert.True(t, hasErrors([]Diagnostic{
+		{Severity: DiagnosticSeverityWarning},
+		{Severity: DiagnosticSeverityError},
+	}))
+}
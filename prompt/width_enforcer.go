@@ -2,10 +2,14 @@ package prompt
 
 import (
 	"strings"
+	"unicode"
+
+	"github.com/jedib0t/go-pretty/v6/text"
 )
 
 // WidthEnforcer is a function that will enforce a "max-length" condition on the
-// given text.
+// given text. The rows may begin with a hanging indent, but have to add up to
+// the given text otherwise.
 type WidthEnforcer func(input string, maxLen int) string
 
 // WidthEnforcerDefault -
@@ -51,3 +55,139 @@ func WidthEnforcerDefault(str string, maxLen int) string {
 	}
 	return out.String()
 }
+
+// wordWrapDelimiters contains the characters (other than whitespace) after
+// which WidthEnforcerWordWrap may break a line.
+const wordWrapDelimiters = ",;)]}"
+
+// WidthEnforcerWordWrap returns a WidthEnforcer that breaks lines after
+// whitespace or delimiters (like a comma) to keep words intact, and falls back
+// to breaking words that do not fit on a row of their own. Continuation rows
+// begin with the given hanging indent (if it leaves enough room for content).
+// Like WidthEnforcerDefault, the colors in effect at the end of a row are
+// re-applied at the beginning of the next one.
+func WidthEnforcerWordWrap(hangingIndent string) WidthEnforcer {
+	indentWidth := text.RuneWidthWithoutEscSequences(hangingIndent)
+	return func(str string, maxLen int) string {
+		if maxLen == 0 {
+			return str
+		}
+		indent := hangingIndent
+		if indentWidth >= maxLen {
+			indent = ""
+		}
+
+		// split into visible runes, each with the escape sequences before it
+		type cell struct {
+			escSeq string
+			r      rune
+			width  int
+		}
+		var cells []cell
+		escSeq, inEscSeq := strings.Builder{}, false
+		for _, r := range str {
+			if r == escSeqStart || inEscSeq {
+				inEscSeq = r != escSeqStop
+				escSeq.WriteRune(r)
+				continue
+			}
+			cells = append(cells, cell{escSeq: escSeq.String(), r: r, width: text.RuneWidth(r)})
+			escSeq.Reset()
+		}
+		trailingEscSeq := escSeq.String()
+		isBreakable := func(r rune) bool {
+			return unicode.IsSpace(r) || strings.ContainsRune(wordWrapDelimiters, r)
+		}
+
+		out := strings.Builder{}
+		out.Grow(len(str) + (len(str) / maxLen * (len(indent) + 1)))
+		activeEscSeq := strings.Builder{}
+		for start, rowIdx := 0, 0; start < len(cells); rowIdx++ {
+			rowWidth := maxLen
+			if rowIdx > 0 {
+				// reset before end of line, and restart on next line
+				if activeEscSeq.Len() > 0 {
+					out.WriteString(escSeqReset)
+				}
+				out.WriteRune('\n')
+				if indent != "" {
+					out.WriteString(indent)
+					rowWidth -= indentWidth
+				}
+				out.WriteString(activeEscSeq.String())
+			}
+
+			// find the cells that fit, and break after the last breakable one
+			// to keep the whitespace and delimiters at the end of the row
+			stop, width := start, 0
+			for stop < len(cells) && (stop == start || width+cells[stop].width <= rowWidth) {
+				width += cells[stop].width
+				stop++
+			}
+			if stop < len(cells) {
+				for idx := stop - 1; idx > start; idx-- {
+					if isBreakable(cells[idx].r) {
+						stop = idx + 1
+						break
+					}
+				}
+			}
+
+			for _, c := range cells[start:stop] {
+				if c.escSeq != "" {
+					if strings.HasSuffix(c.escSeq, escSeqReset) {
+						activeEscSeq.Reset()
+					} else {
+						activeEscSeq.WriteString(c.escSeq)
+					}
+				}
+				out.WriteString(c.escSeq)
+				out.WriteRune(c.r)
+			}
+			start = stop
+		}
+		out.WriteString(trailingEscSeq)
+		return out.String()
+	}
+}
+
+// wrapIndentWidths returns the width of the hanging indent (if any) that the
+// WidthEnforcer inserted at the beginning of each of the given rows generated
+// for the line, so that the indent can be told apart from the content when
+// mapping the rows back to the input. The indent is found by matching the rows
+// against the text of the line, as the rows have to add up to the line other
+// than for the indent.
+func wrapIndentWidths(line string, rows []string) []int {
+	lineRunes := []rune(text.StripEscape(line))
+	rsp, offset := make([]int, len(rows)), 0
+	for rowIdx, row := range rows {
+		rowRunes := []rune(text.StripEscape(row))
+		indent := 0
+		if rowIdx > 0 {
+			for indent < len(rowRunes) && !hasRunesPrefix(lineRunes[offset:], rowRunes[indent:]) {
+				indent++
+			}
+			if indent == len(rowRunes) {
+				indent = 0 // not a part of the line; leave it as is
+			}
+			rsp[rowIdx] = text.RuneWidthWithoutEscSequences(string(rowRunes[:indent]))
+		}
+		offset += len(rowRunes) - indent
+		if offset > len(lineRunes) {
+			offset = len(lineRunes)
+		}
+	}
+	return rsp
+}
+
+func hasRunesPrefix(runes []rune, prefix []rune) bool {
+	if len(prefix) > len(runes) {
+		return false
+	}
+	for idx := range prefix {
+		if runes[idx] != prefix[idx] {
+			return false
+		}
+	}
+	return true
+}
@@ -78,3 +78,60 @@ func TestWidthEnforcerDefault(t *testing.T) {
 		renderTestExpectedCode()
 	}
 }
+
+func TestWidthEnforcerWordWrap(t *testing.T) {
+	enforcer := WidthEnforcerWordWrap("")
+	in := "Ghosts of the Deep"
+	assert.Equal(t, in, enforcer(in, 0))
+	assert.Equal(t, "Ghosts \nof the \nDeep", enforcer(in, 8))
+	assert.Equal(t, "Ghost\ns of \nthe \nDeep", enforcer(in, 5))
+	assert.Equal(t, "select \na,b,c \nfrom \nfoo;", enforcer("select a,b,c from foo;", 7))
+
+	color := Color{
+		Background: termenv.ANSI256Color(100),
+		Foreground: termenv.ANSI256Color(200),
+	}
+	in = color.Sprint("Ghosts") + " of the " + color.Sprint("Deep")
+	out := enforcer(in, 10)
+	expected := "" +
+		"\x1b[38;5;200;48;5;100mGhosts\x1b[0m of \n" +
+		"the \x1b[38;5;200;48;5;100mDeep\x1b[0m"
+	assert.Equal(t, expected, out)
+	in = color.Sprint("Ghosts of the Deep")
+	out = enforcer(in, 8)
+	expected = "" +
+		"\x1b[38;5;200;48;5;100mGhosts \x1b[0m\n" +
+		"\x1b[38;5;200;48;5;100mof the \x1b[0m\n" +
+		"\x1b[38;5;200;48;5;100mDeep\x1b[0m"
+	assert.Equal(t, expected, out)
+
+	t.Run("hanging indent", func(t *testing.T) {
+		enforcer := WidthEnforcerWordWrap("  ")
+		out := enforcer("Ghosts of the Deep", 8)
+		expected := "" +
+			"Ghosts \n" +
+			"  of \n" +
+			"  the \n" +
+			"  Deep"
+		assert.Equal(t, expected, out)
+
+		// no indent if it leaves no room for the content
+		assert.Equal(t, "Gh\nos\nts", WidthEnforcerWordWrap("  ")("Ghosts", 2))
+	})
+}
+
+func Test_wrapIndentWidths(t *testing.T) {
+	line := "Ghosts of the Deep"
+	assert.Equal(t, []int{0, 2, 2, 2}, wrapIndentWidths(line, strings.Split(WidthEnforcerWordWrap("  ")(line, 8), "\n")))
+	assert.Equal(t, []int{0, 0, 0}, wrapIndentWidths(line, strings.Split(WidthEnforcerWordWrap("")(line, 8), "\n")))
+	assert.Equal(t, []int{0, 0, 0}, wrapIndentWidths(line, strings.Split(WidthEnforcerDefault(line, 8), "\n")))
+
+	// escape sequences and wide characters in the indent and in the line
+	line = "\x1b[38;5;200m  Ghosts 👻 of\x1b[0m the Deep"
+	rows := strings.Split(WidthEnforcerWordWrap("→ ")(line, 10), "\n")
+	assert.Equal(t, []string{"\x1b[38;5;200m  Ghosts \x1b[0m", "→ \x1b[38;5;200m👻 of\x1b[0m ", "→ the Deep"}, rows)
+	assert.Equal(t, []int{0, 2, 2}, wrapIndentWidths(line, rows))
+
+	// rows that do not add up to the line have no indent
+	assert.Equal(t, []int{0, 0}, wrapIndentWidths("foo", []string{"foo", "bar"}))
+}
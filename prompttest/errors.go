@@ -0,0 +1,11 @@
+package prompttest
+
+import "errors"
+
+// ErrNotPrompting is returned when waiting for the result of a Prompt that
+// was never started.
+var ErrNotPrompting = errors.New("not prompting")
+
+// ErrTimeout is returned when the Screen did not get to the expected state, or
+// the Prompt did not return, in time.
+var ErrTimeout = errors.New("timed out")
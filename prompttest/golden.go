@@ -0,0 +1,171 @@
+package prompttest
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/muesli/termenv"
+)
+
+// EnvVarUpdate is the environment variable which, when set to "1" or "true",
+// makes MatchGolden (re)write the golden files instead of comparing with them.
+const EnvVarUpdate = "PROMPTTEST_UPDATE"
+
+var updateGolden = flag.Bool("prompttest.update", false, "update the golden files of prompttest.MatchGolden")
+
+// TestingT is the part of testing.TB used to report mismatches.
+type TestingT interface {
+	Errorf(format string, args ...any)
+	Helper()
+}
+
+// MatchGolden compares the Snapshot of the Screen with the contents of the
+// golden file at the given path, and reports the differences if any. Run the
+// tests with the -prompttest.update flag (or with PROMPTTEST_UPDATE=1) to
+// (re)write the golden files with the current snapshots.
+//
+// Things that change from run to run (like the time of the day in the prefix,
+// or a blinking cursor) need to be turned off to get stable snapshots.
+func MatchGolden(t TestingT, screen *Screen, path string) {
+	t.Helper()
+
+	actual := screen.Snapshot()
+	if isUpdateGolden() {
+		if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+			t.Errorf("failed to create the directory for %s: %v", path, err)
+			return
+		}
+		if err := os.WriteFile(path, []byte(actual), 0o644); err != nil {
+			t.Errorf("failed to update %s: %v", path, err)
+		}
+		return
+	}
+
+	expected, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		t.Errorf("golden file %s does not exist; run with -prompttest.update or %s=1 to create it", path, EnvVarUpdate)
+		return
+	} else if err != nil {
+		t.Errorf("failed to read %s: %v", path, err)
+		return
+	}
+	if diff := diffLines(string(expected), actual); diff != "" {
+		t.Errorf("screen does not match %s (-expected +actual):\n%s", path, diff)
+	}
+}
+
+// Snapshot returns a textual representation of everything visible on the
+// Screen: the text, the cursor, and the colors and attributes of the text
+// (as runs of cells on each row with the same non-default style).
+func (s *Screen) Snapshot() string {
+	out := strings.Builder{}
+	out.WriteString(s.String())
+	out.WriteString("\n-- cursor --\n")
+
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	out.WriteString(fmt.Sprintf("row=%d column=%d visible=%v shape=%d\n",
+		s.cursorRow, s.cursorCol, s.cursorVisible, s.cursorShape))
+	out.WriteString("-- styles --\n")
+	for row, cells := range s.cells {
+		for col := 0; col < len(cells); {
+			end := col + 1
+			for end < len(cells) && cells[end].Style == cells[col].Style {
+				end++
+			}
+			if style := cells[col].Style.String(); style != "" {
+				out.WriteString(fmt.Sprintf("%d:%d-%d %s\n", row, col, end-1, style))
+			}
+			col = end
+		}
+	}
+	return out.String()
+}
+
+// String returns the non-default colors and attributes, like
+// "fg=231 bg=#ff0000 bold"; ANSI colors are prefixed with "ansi:" to tell them
+// apart from the first 16 colors of the 256-color palette.
+func (cs CellStyle) String() string {
+	var parts []string
+	if cs.Foreground != nil {
+		parts = append(parts, "fg="+colorString(cs.Foreground))
+	}
+	if cs.Background != nil {
+		parts = append(parts, "bg="+colorString(cs.Background))
+	}
+	for _, attr := range []struct {
+		name    string
+		enabled bool
+	}{
+		{"blink", cs.Blink},
+		{"bold", cs.Bold},
+		{"crossed-out", cs.CrossedOut},
+		{"faint", cs.Faint},
+		{"italic", cs.Italic},
+		{"reverse", cs.Reverse},
+		{"underline", cs.Underline},
+	} {
+		if attr.enabled {
+			parts = append(parts, attr.name)
+		}
+	}
+	return strings.Join(parts, " ")
+}
+
+func colorString(color termenv.Color) string {
+	switch c := color.(type) {
+	case termenv.ANSIColor:
+		return fmt.Sprintf("ansi:%d", c)
+	case termenv.ANSI256Color:
+		return fmt.Sprintf("%d", c)
+	case termenv.RGBColor:
+		return string(c)
+	}
+	return fmt.Sprint(color)
+}
+
+// diffLines returns the lines that differ between the expected and the actual
+// text, or an empty string if they match.
+func diffLines(expected string, actual string) string {
+	if expected == actual {
+		return ""
+	}
+
+	expectedLines, actualLines := strings.Split(expected, "\n"), strings.Split(actual, "\n")
+	out := strings.Builder{}
+	for idx := 0; idx < len(expectedLines) || idx < len(actualLines); idx++ {
+		var e, a *string
+		if idx < len(expectedLines) {
+			e = &expectedLines[idx]
+		}
+		if idx < len(actualLines) {
+			a = &actualLines[idx]
+		}
+		if e != nil && a != nil && *e == *a {
+			out.WriteString(fmt.Sprintf("  %s\n", *e))
+			continue
+		}
+		if e != nil {
+			out.WriteString(fmt.Sprintf("- %s\n", *e))
+		}
+		if a != nil {
+			out.WriteString(fmt.Sprintf("+ %s\n", *a))
+		}
+	}
+	return out.String()
+}
+
+func isUpdateGolden() bool {
+	if *updateGolden {
+		return true
+	}
+	switch strings.ToLower(os.Getenv(EnvVarUpdate)) {
+	case "1", "true":
+		return true
+	}
+	return false
+}
@@ -0,0 +1,76 @@
+package prompttest
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/muesli/termenv"
+	"github.com/stretchr/testify/assert"
+)
+
+type fakeT struct {
+	errors []string
+}
+
+func (f *fakeT) Errorf(format string, args ...any) {
+	f.errors = append(f.errors, fmt.Sprintf(format, args...))
+}
+
+func (f *fakeT) Helper() {}
+
+func TestCellStyle_String(t *testing.T) {
+	assert.Equal(t, "", CellStyle{}.String())
+	assert.Equal(t, "fg=ansi:1 bg=#ff0000 bold underline", CellStyle{
+		Background: termenv.RGBColor("#ff0000"),
+		Bold:       true,
+		Foreground: termenv.ANSIColor(1),
+		Underline:  true,
+	}.String())
+	assert.Equal(t, "fg=232 blink crossed-out faint italic reverse", CellStyle{
+		Blink:      true,
+		CrossedOut: true,
+		Faint:      true,
+		Foreground: termenv.ANSI256Color(232),
+		Italic:     true,
+		Reverse:    true,
+	}.String())
+}
+
+func TestMatchGolden(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "sub", "screen.golden")
+	s := NewScreen(10, 3)
+	writeString(s, "foo\r\n\x1b[1;31mbar\x1b[0m\x1b[?25l")
+
+	// missing file
+	ft := &fakeT{}
+	MatchGolden(ft, s, path)
+	assert.Len(t, ft.errors, 1)
+	assert.Contains(t, ft.errors[0], "does not exist")
+
+	// update
+	t.Setenv(EnvVarUpdate, "true")
+	ft = &fakeT{}
+	MatchGolden(ft, s, path)
+	assert.Empty(t, ft.errors)
+	content, err := os.ReadFile(path)
+	assert.Nil(t, err)
+	assert.Equal(t, "foo\nbar\n-- cursor --\nrow=1 column=3 visible=false shape=0\n-- styles --\n1:0-2 fg=ansi:1 bold\n", string(content))
+
+	// match and mismatch
+	t.Setenv(EnvVarUpdate, "")
+	ft = &fakeT{}
+	MatchGolden(ft, s, path)
+	assert.Empty(t, ft.errors)
+	writeString(s, "\rbaz")
+	MatchGolden(ft, s, path)
+	assert.Len(t, ft.errors, 1)
+	assert.Contains(t, ft.errors[0], "  foo\n- bar\n+ baz\n")
+}
+
+func Test_diffLines(t *testing.T) {
+	assert.Equal(t, "", diffLines("a\nb", "a\nb"))
+	assert.Equal(t, "  a\n- b\n+ c\n+ d\n", diffLines("a\nb", "a\nc\nd"))
+	assert.Equal(t, "  a\n- b\n", diffLines("a\nb", "a"))
+}
@@ -0,0 +1,591 @@
+package prompttest
+
+import (
+	"strconv"
+	"strings"
+	"sync"
+	"unicode/utf8"
+
+	"github.com/jedib0t/go-pretty/v6/text"
+	"github.com/muesli/termenv"
+)
+
+// Cell is a single character cell on the Screen.
+type Cell struct {
+	Rune  rune // 0 for the second cell of a wide character
+	Style CellStyle
+}
+
+// CellStyle contains the colors and attributes of a Cell. The colors are nil
+// if the terminal's default colors are to be used.
+type CellStyle struct {
+	Background termenv.Color
+	Blink      bool
+	Bold       bool
+	CrossedOut bool
+	Faint      bool
+	Foreground termenv.Color
+	Italic     bool
+	Reverse    bool
+	Underline  bool
+}
+
+// Screen is an in-memory terminal emulator that interprets the text and the
+// escape sequences written to it the way a terminal in raw mode would: a line
+// feed moves the cursor down without returning it to the first column, and
+// writing past the last column wraps to the next row. It understands enough of
+// the CSI and SGR sequences to render everything the prompt writes, and
+// ignores the rest. It is safe for concurrent use.
+type Screen struct {
+	altCells      [][]Cell
+	altCursor     [2]int
+	altScreen     bool
+	cells         [][]Cell
+	cursorCol     int
+	cursorRow     int
+	cursorShape   int
+	cursorVisible bool
+	height        int
+	mutex         sync.Mutex
+	modes         map[int]bool
+	partial       []byte // incomplete UTF-8 sequence or escape sequence
+	pendingWrap   bool
+	savedCursor   [2]int
+	scrolledOff   []string
+	style         CellStyle
+	width         int
+}
+
+// NewScreen returns a blank Screen of the given size with the cursor at the
+// top-left corner.
+func NewScreen(width int, height int) *Screen {
+	if width < 1 {
+		width = 1
+	}
+	if height < 1 {
+		height = 1
+	}
+	s := &Screen{
+		cursorVisible: true,
+		height:        height,
+		modes:         make(map[int]bool),
+		width:         width,
+	}
+	s.cells = s.blankRows(height)
+	return s
+}
+
+// Cell returns the Cell at the given (0-indexed) row and column.
+func (s *Screen) Cell(row int, col int) Cell {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	if row < 0 || row >= s.height || col < 0 || col >= s.width {
+		return Cell{}
+	}
+	return s.cells[row][col]
+}
+
+// Cursor returns the (0-indexed) row and column of the cursor.
+func (s *Screen) Cursor() (int, int) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	return s.cursorRow, s.cursorCol
+}
+
+// CursorShape returns the shape of the cursor set with the DECSCUSR sequence
+// (0 being the terminal's default).
+func (s *Screen) CursorShape() int {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	return s.cursorShape
+}
+
+// CursorVisible returns true unless the cursor was hidden.
+func (s *Screen) CursorVisible() bool {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	return s.cursorVisible
+}
+
+// IsAltScreen returns true if the alternate screen buffer is in use.
+func (s *Screen) IsAltScreen() bool {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	return s.altScreen
+}
+
+// Lines returns the text on each row of the Screen without the trailing
+// spaces.
+func (s *Screen) Lines() []string {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	rsp := make([]string, s.height)
+	for row := range s.cells {
+		rsp[row] = rowText(s.cells[row])
+	}
+	return rsp
+}
+
+// Mode returns true if the private mode (like 2004 for bracketed paste) was
+// enabled using "CSI ? <mode> h".
+func (s *Screen) Mode(mode int) bool {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	return s.modes[mode]
+}
+
+// ScrolledOff returns the text of the rows that scrolled off the top of the
+// Screen, oldest first.
+func (s *Screen) ScrolledOff() []string {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	return append([]string{}, s.scrolledOff...)
+}
+
+// Size returns the width and the height of the Screen.
+func (s *Screen) Size() (int, int) {
+	return s.width, s.height
+}
+
+// String returns the text on the Screen without the trailing spaces on each
+// row and without the trailing empty rows.
+func (s *Screen) String() string {
+	lines := s.Lines()
+	for len(lines) > 0 && lines[len(lines)-1] == "" {
+		lines = lines[:len(lines)-1]
+	}
+	return strings.Join(lines, "\n")
+}
+
+// TerminalSize returns the width and the height of the Screen; this lets the
+// prompt size itself to fit the Screen when rendering to it.
+func (s *Screen) TerminalSize() (int, int) {
+	return s.Size()
+}
+
+// Write interprets the given text and escape sequences. Sequences split across
+// calls are handled as if they were written at once.
+func (s *Screen) Write(b []byte) (int, error) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	data := append(s.partial, b...)
+	s.partial = nil
+	for idx := 0; idx < len(data); {
+		if data[idx] == '\x1b' {
+			n := s.processEscSeq(data[idx:])
+			if n == 0 { // incomplete
+				s.partial = append([]byte{}, data[idx:]...)
+				break
+			}
+			idx += n
+			continue
+		}
+
+		r, size := utf8.DecodeRune(data[idx:])
+		if r == utf8.RuneError && !utf8.FullRune(data[idx:]) {
+			s.partial = append([]byte{}, data[idx:]...)
+			break
+		}
+		s.processRune(r)
+		idx += size
+	}
+	return len(b), nil
+}
+
+func (s *Screen) blankRows(n int) [][]Cell {
+	rows := make([][]Cell, n)
+	for idx := range rows {
+		rows[idx] = make([]Cell, s.width)
+		for col := range rows[idx] {
+			rows[idx][col] = Cell{Rune: ' '}
+		}
+	}
+	return rows
+}
+
+func (s *Screen) clampCursor() {
+	s.cursorRow = clamp(s.cursorRow, 0, s.height-1)
+	s.cursorCol = clamp(s.cursorCol, 0, s.width-1)
+	s.pendingWrap = false
+}
+
+func (s *Screen) eraseCells(row int, from int, to int) {
+	for col := clamp(from, 0, s.width); col < clamp(to, 0, s.width); col++ {
+		s.cells[row][col] = Cell{Rune: ' ', Style: CellStyle{Background: s.style.Background}}
+	}
+}
+
+func (s *Screen) lineFeed() {
+	s.pendingWrap = false
+	if s.cursorRow < s.height-1 {
+		s.cursorRow++
+		return
+	}
+	s.scrollUp(1)
+}
+
+// processCSI handles the "CSI [?] params [intermediate] final" sequence.
+//
+//gocyclo:ignore
+func (s *Screen) processCSI(private bool, params []int, intermediate string, final byte) {
+	param := func(idx int, def int) int {
+		if idx < len(params) && params[idx] > 0 {
+			return params[idx]
+		}
+		return def
+	}
+
+	if private {
+		if final == 'h' || final == 'l' {
+			for _, mode := range params {
+				s.setMode(mode, final == 'h')
+			}
+		}
+		return
+	}
+	if intermediate == " " && final == 'q' {
+		s.cursorShape = param(0, 0)
+		return
+	}
+	if intermediate != "" {
+		return
+	}
+
+	switch final {
+	case '@': // insert blank characters
+		n, row := param(0, 1), s.cells[s.cursorRow]
+		copy(row[clamp(s.cursorCol+n, 0, s.width):], row[s.cursorCol:])
+		s.eraseCells(s.cursorRow, s.cursorCol, s.cursorCol+n)
+	case 'A':
+		s.cursorRow -= param(0, 1)
+		s.clampCursor()
+	case 'B':
+		s.cursorRow += param(0, 1)
+		s.clampCursor()
+	case 'C':
+		s.cursorCol += param(0, 1)
+		s.clampCursor()
+	case 'D':
+		s.cursorCol -= param(0, 1)
+		s.clampCursor()
+	case 'E':
+		s.cursorRow, s.cursorCol = s.cursorRow+param(0, 1), 0
+		s.clampCursor()
+	case 'F':
+		s.cursorRow, s.cursorCol = s.cursorRow-param(0, 1), 0
+		s.clampCursor()
+	case 'G':
+		s.cursorCol = param(0, 1) - 1
+		s.clampCursor()
+	case 'H', 'f':
+		s.cursorRow, s.cursorCol = param(0, 1)-1, param(1, 1)-1
+		s.clampCursor()
+	case 'J':
+		switch param(0, 0) {
+		case 0:
+			s.eraseCells(s.cursorRow, s.cursorCol, s.width)
+			for row := s.cursorRow + 1; row < s.height; row++ {
+				s.eraseCells(row, 0, s.width)
+			}
+		case 1:
+			for row := 0; row < s.cursorRow; row++ {
+				s.eraseCells(row, 0, s.width)
+			}
+			s.eraseCells(s.cursorRow, 0, s.cursorCol+1)
+		case 2, 3:
+			for row := 0; row < s.height; row++ {
+				s.eraseCells(row, 0, s.width)
+			}
+		}
+	case 'K':
+		switch param(0, 0) {
+		case 0:
+			s.eraseCells(s.cursorRow, s.cursorCol, s.width)
+		case 1:
+			s.eraseCells(s.cursorRow, 0, s.cursorCol+1)
+		case 2:
+			s.eraseCells(s.cursorRow, 0, s.width)
+		}
+	case 'L', 'M': // insert/delete lines
+		n := clamp(param(0, 1), 0, s.height-s.cursorRow)
+		rows := append([][]Cell{}, s.cells[s.cursorRow:]...)
+		if final == 'L' {
+			rows = append(s.blankRows(n), rows[:len(rows)-n]...)
+		} else {
+			rows = append(rows[n:], s.blankRows(n)...)
+		}
+		copy(s.cells[s.cursorRow:], rows)
+	case 'P': // delete characters
+		n, row := param(0, 1), s.cells[s.cursorRow]
+		copy(row[s.cursorCol:], row[clamp(s.cursorCol+n, 0, s.width):])
+		s.eraseCells(s.cursorRow, s.width-n, s.width)
+	case 'S':
+		s.scrollUp(param(0, 1))
+	case 'T':
+		n := clamp(param(0, 1), 0, s.height)
+		s.cells = append(s.blankRows(n), s.cells[:s.height-n]...)
+	case 'X': // erase characters
+		s.eraseCells(s.cursorRow, s.cursorCol, s.cursorCol+param(0, 1))
+	case 'd':
+		s.cursorRow = param(0, 1) - 1
+		s.clampCursor()
+	case 'm':
+		s.processSGR(params)
+	case 's':
+		s.savedCursor = [2]int{s.cursorRow, s.cursorCol}
+	case 'u':
+		s.cursorRow, s.cursorCol = s.savedCursor[0], s.savedCursor[1]
+		s.clampCursor()
+	}
+}
+
+// processEscSeq handles the escape sequence at the beginning of the given
+// data, and returns its length, or 0 if it is incomplete.
+func (s *Screen) processEscSeq(data []byte) int {
+	if len(data) < 2 {
+		return 0
+	}
+
+	switch data[1] {
+	case '[': // CSI
+		for idx := 2; idx < len(data); idx++ {
+			if data[idx] >= 0x40 && data[idx] <= 0x7e {
+				body := string(data[2:idx])
+				private := strings.HasPrefix(body, "?")
+				body = strings.TrimLeft(body, "?<=>")
+				intermediate := strings.TrimLeft(body, "0123456789;:")
+				body = strings.TrimSuffix(body, intermediate)
+				s.processCSI(private, parseParams(body), intermediate, data[idx])
+				return idx + 1
+			}
+		}
+		return 0
+	case ']', 'P', '_', '^': // OSC, DCS, APC, PM: ignored till BEL or ST
+		for idx := 2; idx < len(data); idx++ {
+			if data[idx] == '\a' {
+				return idx + 1
+			}
+			if data[idx] == '\x1b' && idx+1 < len(data) && data[idx+1] == '\\' {
+				return idx + 2
+			}
+		}
+		return 0
+	case '7':
+		s.savedCursor = [2]int{s.cursorRow, s.cursorCol}
+	case '8':
+		s.cursorRow, s.cursorCol = s.savedCursor[0], s.savedCursor[1]
+		s.clampCursor()
+	case 'M': // reverse index
+		if s.cursorRow > 0 {
+			s.cursorRow--
+		} else {
+			s.cells = append(s.blankRows(1), s.cells[:s.height-1]...)
+		}
+	case '(', ')': // character set designation
+		if len(data) < 3 {
+			return 0
+		}
+		return 3
+	}
+	return 2
+}
+
+func (s *Screen) processRune(r rune) {
+	switch r {
+	case '\a':
+		return
+	case '\b':
+		if s.cursorCol > 0 {
+			s.cursorCol--
+		}
+		s.pendingWrap = false
+		return
+	case '\n', '\v', '\f':
+		s.lineFeed()
+		return
+	case '\r':
+		s.cursorCol, s.pendingWrap = 0, false
+		return
+	case '\t':
+		s.cursorCol = clamp((s.cursorCol/8+1)*8, 0, s.width-1)
+		s.pendingWrap = false
+		return
+	}
+	if r < ' ' || r == 0x7f {
+		return
+	}
+
+	width := text.RuneWidth(r)
+	if width == 0 {
+		return // combining characters are not supported
+	}
+	if s.pendingWrap || s.cursorCol+width > s.width {
+		s.cursorCol = 0
+		s.lineFeed()
+	}
+	s.cells[s.cursorRow][s.cursorCol] = Cell{Rune: r, Style: s.style}
+	if width == 2 && s.cursorCol+1 < s.width {
+		s.cells[s.cursorRow][s.cursorCol+1] = Cell{Style: s.style}
+	}
+	if s.cursorCol+width >= s.width {
+		s.cursorCol, s.pendingWrap = s.width-1, true
+	} else {
+		s.cursorCol += width
+	}
+}
+
+// processSGR applies the "Select Graphic Rendition" parameters on the current
+// style.
+//
+//gocyclo:ignore
+func (s *Screen) processSGR(params []int) {
+	if len(params) == 0 {
+		params = []int{0}
+	}
+	for idx := 0; idx < len(params); idx++ {
+		switch param := params[idx]; {
+		case param == 0:
+			s.style = CellStyle{}
+		case param == 1:
+			s.style.Bold = true
+		case param == 2:
+			s.style.Faint = true
+		case param == 3:
+			s.style.Italic = true
+		case param == 4:
+			s.style.Underline = true
+		case param == 5 || param == 6:
+			s.style.Blink = true
+		case param == 7:
+			s.style.Reverse = true
+		case param == 9:
+			s.style.CrossedOut = true
+		case param == 22:
+			s.style.Bold, s.style.Faint = false, false
+		case param == 23:
+			s.style.Italic = false
+		case param == 24:
+			s.style.Underline = false
+		case param == 25:
+			s.style.Blink = false
+		case param == 27:
+			s.style.Reverse = false
+		case param == 29:
+			s.style.CrossedOut = false
+		case param >= 30 && param <= 37:
+			s.style.Foreground = termenv.ANSIColor(param - 30)
+		case param >= 40 && param <= 47:
+			s.style.Background = termenv.ANSIColor(param - 40)
+		case param >= 90 && param <= 97:
+			s.style.Foreground = termenv.ANSIColor(param - 90 + 8)
+		case param >= 100 && param <= 107:
+			s.style.Background = termenv.ANSIColor(param - 100 + 8)
+		case param == 38 || param == 48:
+			color, numParams := parseSGRColor(params[idx+1:])
+			if param == 38 {
+				s.style.Foreground = color
+			} else {
+				s.style.Background = color
+			}
+			idx += numParams
+		case param == 39:
+			s.style.Foreground = nil
+		case param == 49:
+			s.style.Background = nil
+		}
+	}
+}
+
+func (s *Screen) scrollUp(n int) {
+	n = clamp(n, 0, s.height)
+	if !s.altScreen {
+		for _, row := range s.cells[:n] {
+			s.scrolledOff = append(s.scrolledOff, rowText(row))
+		}
+	}
+	s.cells = append(s.cells[n:], s.blankRows(n)...)
+}
+
+func (s *Screen) setMode(mode int, enabled bool) {
+	s.modes[mode] = enabled
+	switch mode {
+	case 25:
+		s.cursorVisible = enabled
+	case 1049:
+		if enabled && !s.altScreen {
+			s.altCells, s.cells = s.cells, s.blankRows(s.height)
+			s.altCursor = [2]int{s.cursorRow, s.cursorCol}
+			s.altScreen = true
+		} else if !enabled && s.altScreen {
+			s.cells, s.altCells = s.altCells, nil
+			s.cursorRow, s.cursorCol = s.altCursor[0], s.altCursor[1]
+			s.altScreen = false
+		}
+	}
+}
+
+func clamp(val int, min int, max int) int {
+	if val < min {
+		return min
+	}
+	if val > max {
+		return max
+	}
+	return val
+}
+
+func parseParams(body string) []int {
+	if body == "" {
+		return nil
+	}
+	parts := strings.FieldsFunc(body, func(r rune) bool {
+		return r == ';' || r == ':'
+	})
+	rsp := make([]int, 0, len(parts))
+	for _, part := range parts {
+		num, _ := strconv.Atoi(part)
+		rsp = append(rsp, num)
+	}
+	return rsp
+}
+
+// parseSGRColor parses the "5;n" or "2;r;g;b" parameters following 38 or 48,
+// and returns the color and the number of parameters consumed.
+func parseSGRColor(params []int) (termenv.Color, int) {
+	if len(params) >= 2 && params[0] == 5 {
+		return termenv.ANSI256Color(params[1]), 2
+	}
+	if len(params) >= 4 && params[0] == 2 {
+		return termenv.RGBColor(rgbHex(params[1], params[2], params[3])), 4
+	}
+	return nil, len(params)
+}
+
+func rgbHex(r int, g int, b int) string {
+	const hexDigits = "0123456789abcdef"
+	out := []byte{'#'}
+	for _, v := range []int{r, g, b} {
+		v = clamp(v, 0, 255)
+		out = append(out, hexDigits[v>>4], hexDigits[v&0xf])
+	}
+	return string(out)
+}
+
+func rowText(row []Cell) string {
+	out := strings.Builder{}
+	for _, cell := range row {
+		if cell.Rune != 0 {
+			out.WriteRune(cell.Rune)
+		}
+	}
+	return strings.TrimRight(out.String(), " ")
+}
@@ -0,0 +1,151 @@
+package prompttest
+
+import (
+	"testing"
+
+	"github.com/muesli/termenv"
+	"github.com/stretchr/testify/assert"
+)
+
+func writeString(s *Screen, str string) {
+	_, _ = s.Write([]byte(str))
+}
+
+func TestNewScreen(t *testing.T) {
+	s := NewScreen(0, -1)
+	width, height := s.Size()
+	assert.Equal(t, 1, width)
+	assert.Equal(t, 1, height)
+	assert.True(t, s.CursorVisible())
+	assert.Equal(t, Cell{Rune: ' '}, s.Cell(0, 0))
+	assert.Equal(t, Cell{}, s.Cell(1, 1))
+}
+
+func TestScreen_Write(t *testing.T) {
+	t.Run("raw mode line feeds", func(t *testing.T) {
+		s := NewScreen(10, 3)
+		writeString(s, "foo\nbar\r\nbaz")
+		assert.Equal(t, []string{"foo", "   bar", "baz"}, s.Lines())
+		row, col := s.Cursor()
+		assert.Equal(t, 2, row)
+		assert.Equal(t, 3, col)
+	})
+
+	t.Run("wrapping and scrolling", func(t *testing.T) {
+		s := NewScreen(4, 2)
+		writeString(s, "abcd")
+		row, col := s.Cursor()
+		assert.Equal(t, 0, row)
+		assert.Equal(t, 3, col) // pending wrap
+		writeString(s, "efghij")
+		assert.Equal(t, []string{"efgh", "ij"}, s.Lines())
+		assert.Equal(t, []string{"abcd"}, s.ScrolledOff())
+	})
+
+	t.Run("control characters", func(t *testing.T) {
+		s := NewScreen(20, 1)
+		writeString(s, "ab\bc\tx\a\x01y")
+		assert.Equal(t, "ac      xy", s.String())
+	})
+
+	t.Run("wide characters", func(t *testing.T) {
+		s := NewScreen(5, 2)
+		writeString(s, "a世界")
+		assert.Equal(t, []string{"a世界", ""}, s.Lines())
+		assert.Equal(t, Cell{}, s.Cell(0, 2))
+		writeString(s, "b")
+		assert.Equal(t, []string{"a世界", "b"}, s.Lines())
+	})
+
+	t.Run("split sequences", func(t *testing.T) {
+		s := NewScreen(10, 1)
+		writeString(s, "a\x1b[")
+		writeString(s, "31mb\xe4")
+		writeString(s, "\xb8\x96\x1b]0;title")
+		writeString(s, "\x07c")
+		assert.Equal(t, "ab世c", s.String())
+		assert.Equal(t, termenv.ANSIColor(1), s.Cell(0, 1).Style.Foreground)
+	})
+
+	t.Run("cursor movement", func(t *testing.T) {
+		s := NewScreen(10, 5)
+		writeString(s, "\x1b[3;4Hx\x1b[2Ay\x1b[3Bz\x1b[5Dw\x1b[2C1\x1b[1G2\x1b[F3\x1b[2E4\x1b[99;99H5")
+		assert.Equal(t, []string{"    y", "", "3  x", "2w  1z", "4        5"}, s.Lines())
+	})
+
+	t.Run("save and restore", func(t *testing.T) {
+		s := NewScreen(10, 2)
+		writeString(s, "ab\x1b7\ncd\x1b8ef\x1b[s\x1b[2;9H\x1b[ug")
+		assert.Equal(t, []string{"abefg", "  cd"}, s.Lines())
+	})
+
+	t.Run("erasing", func(t *testing.T) {
+		s := NewScreen(5, 3)
+		writeString(s, "aaaaa\r\nbbbbb\r\nccccc\x1b[2;3H\x1b[K")
+		assert.Equal(t, []string{"aaaaa", "bb", "ccccc"}, s.Lines())
+		writeString(s, "\x1b[1K")
+		assert.Equal(t, []string{"aaaaa", "", "ccccc"}, s.Lines())
+		writeString(s, "\x1b[J")
+		assert.Equal(t, []string{"aaaaa", "", ""}, s.Lines())
+		writeString(s, "\x1b[1J")
+		assert.Equal(t, []string{"", "", ""}, s.Lines())
+		writeString(s, "xy\x1b[2K")
+		assert.Equal(t, "", s.String())
+		writeString(s, "\x1b[1;1Hxyz\x1b[2Jw\x1b[1;4H\x1b[X")
+		assert.Equal(t, []string{"", "", ""}, s.Lines())
+	})
+
+	t.Run("inserting and deleting", func(t *testing.T) {
+		s := NewScreen(6, 3)
+		writeString(s, "abcdef\x1b[1;2H\x1b[2@")
+		assert.Equal(t, "a  bcd", s.String())
+		writeString(s, "\x1b[3P")
+		assert.Equal(t, "acd", s.String())
+		writeString(s, "\x1b[2;1Hx\x1b[3;1Hy\x1b[2;1H\x1b[L")
+		assert.Equal(t, []string{"acd", "", "x"}, s.Lines())
+		writeString(s, "\x1b[2M")
+		assert.Equal(t, []string{"acd", "", ""}, s.Lines())
+		writeString(s, "\x1b[1;1H\x1bM")
+		assert.Equal(t, []string{"", "acd", ""}, s.Lines())
+		writeString(s, "\x1b[S")
+		assert.Equal(t, []string{"acd", "", ""}, s.Lines())
+		writeString(s, "\x1b[T")
+		assert.Equal(t, []string{"", "acd", ""}, s.Lines())
+	})
+
+	t.Run("modes", func(t *testing.T) {
+		s := NewScreen(5, 2)
+		writeString(s, "main\x1b[?25l\x1b[?2004h\x1b[5 q")
+		assert.False(t, s.CursorVisible())
+		assert.True(t, s.Mode(2004))
+		assert.Equal(t, 5, s.CursorShape())
+
+		writeString(s, "\x1b[?1049h")
+		assert.True(t, s.IsAltScreen())
+		assert.Equal(t, "", s.String())
+		writeString(s, "alt\x1b[?1049l\x1b[?25h\x1b[?2004l")
+		assert.False(t, s.IsAltScreen())
+		assert.Equal(t, "main", s.String())
+		assert.True(t, s.CursorVisible())
+		assert.False(t, s.Mode(2004))
+	})
+}
+
+func TestScreen_processSGR(t *testing.T) {
+	s := NewScreen(20, 1)
+	writeString(s, "\x1b[1;2;3;4;5;7;9;31;42ma\x1b[22;23;24;25;27;29mb\x1b[91;103mc\x1b[39;49md")
+	writeString(s, "\x1b[38;5;232;48;2;255;0;16me\x1b[0mf\x1b[38;5mg\x1b[9999mh")
+
+	assert.Equal(t, CellStyle{
+		Background: termenv.ANSIColor(2), Blink: true, Bold: true, CrossedOut: true, Faint: true,
+		Foreground: termenv.ANSIColor(1), Italic: true, Reverse: true, Underline: true,
+	}, s.Cell(0, 0).Style)
+	assert.Equal(t, CellStyle{Background: termenv.ANSIColor(2), Foreground: termenv.ANSIColor(1)}, s.Cell(0, 1).Style)
+	assert.Equal(t, CellStyle{Background: termenv.ANSIColor(11), Foreground: termenv.ANSIColor(9)}, s.Cell(0, 2).Style)
+	assert.Equal(t, CellStyle{}, s.Cell(0, 3).Style)
+	assert.Equal(t, CellStyle{Background: termenv.RGBColor("#ff0010"), Foreground: termenv.ANSI256Color(232)}, s.Cell(0, 4).Style)
+	assert.Equal(t, CellStyle{}, s.Cell(0, 5).Style)
+	assert.Equal(t, CellStyle{}, s.Cell(0, 6).Style)
+	assert.Equal(t, CellStyle{}, s.Cell(0, 7).Style)
+	assert.Equal(t, "abcdefgh", s.String())
+}
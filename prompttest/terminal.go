@@ -0,0 +1,150 @@
+package prompttest
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/jedib0t/go-prompter/prompt"
+	"github.com/muesli/termenv"
+)
+
+// pollInterval is how often the Screen is checked when waiting for something.
+const pollInterval = time.Millisecond * 10
+
+// Terminal runs a Prompter against a virtual terminal (a Screen) so that tests
+// can type into the prompt and check what a user would see.
+type Terminal struct {
+	inputReader *os.File
+	inputWriter *os.File
+	mutex       sync.Mutex
+	numWrites   int
+	prompter    prompt.Prompter
+	results     chan result
+	screen      *Screen
+}
+
+type result struct {
+	input string
+	err   error
+}
+
+// NewTerminal returns a Terminal of the given size and sets up the Prompter to
+// read from it and render to it with all the colors.
+func NewTerminal(prompter prompt.Prompter, width int, height int) (*Terminal, error) {
+	inputReader, inputWriter, err := os.Pipe()
+	if err != nil {
+		return nil, fmt.Errorf("failed to set up the input: %w", err)
+	}
+
+	t := &Terminal{
+		inputReader: inputReader,
+		inputWriter: inputWriter,
+		prompter:    prompter,
+		screen:      NewScreen(width, height),
+	}
+	prompter.SetColorProfile(termenv.TrueColor)
+	prompter.SetInput(inputReader)
+	prompter.SetInteractive(true)
+	prompter.SetOutput(t)
+	return t, nil
+}
+
+// Close releases the resources held by the Terminal. The Prompt (if any)
+// should have returned before this is called.
+func (t *Terminal) Close() error {
+	_ = t.inputWriter.Close()
+	return t.inputReader.Close()
+}
+
+// Prompt calls Prompt on the Prompter in the background, and returns once the
+// prompt is ready for input. Use Result to get what the Prompt returns.
+func (t *Terminal) Prompt(ctx context.Context, timeout time.Duration) error {
+	t.mutex.Lock()
+	numWrites := t.numWrites
+	results := make(chan result, 1)
+	t.results = results
+	t.mutex.Unlock()
+
+	go func() {
+		input, err := t.prompter.Prompt(ctx)
+		results <- result{input: input, err: err}
+	}()
+
+	// the prompt is ready once it renders for the first time
+	return t.WaitFor(func(_ *Screen) bool {
+		t.mutex.Lock()
+		defer t.mutex.Unlock()
+		return t.prompter.IsActive() && t.numWrites > numWrites
+	}, timeout)
+}
+
+// Result waits for the Prompt started with Prompt to return, and returns what
+// it returned.
+func (t *Terminal) Result(timeout time.Duration) (string, error) {
+	t.mutex.Lock()
+	results := t.results
+	t.mutex.Unlock()
+	if results == nil {
+		return "", ErrNotPrompting
+	}
+
+	select {
+	case rsp := <-results:
+		return rsp.input, rsp.err
+	case <-time.After(timeout):
+		return "", fmt.Errorf("%w waiting for the prompt to return; screen:\n%s", ErrTimeout, t.screen.String())
+	}
+}
+
+// Screen returns the Screen the prompt renders to.
+func (t *Terminal) Screen() *Screen {
+	return t.screen
+}
+
+// Send sends the given strings, runes and KeySequences to the prompt, as
+// Prompter.SendInput would.
+func (t *Terminal) Send(a ...any) error {
+	return t.prompter.SendInput(a)
+}
+
+// TerminalSize returns the width and the height of the Screen; this lets the
+// prompt size itself to fit the Screen.
+func (t *Terminal) TerminalSize() (int, int) {
+	return t.screen.Size()
+}
+
+// WaitFor waits until the given condition is met by the Screen.
+func (t *Terminal) WaitFor(condition func(s *Screen) bool, timeout time.Duration) error {
+	deadline := time.Now().Add(timeout)
+	for !condition(t.screen) {
+		if time.Now().After(deadline) {
+			return fmt.Errorf("%w; screen:\n%s", ErrTimeout, t.screen.String())
+		}
+		time.Sleep(pollInterval)
+	}
+	return nil
+}
+
+// WaitForText waits until the given text appears on the Screen.
+func (t *Terminal) WaitForText(text string, timeout time.Duration) error {
+	err := t.WaitFor(func(s *Screen) bool {
+		return strings.Contains(s.String(), text)
+	}, timeout)
+	if err != nil {
+		return fmt.Errorf("waiting for %q: %w", text, err)
+	}
+	return nil
+}
+
+// Write writes the given bytes to the Screen.
+func (t *Terminal) Write(b []byte) (int, error) {
+	t.mutex.Lock()
+	t.numWrites++
+	t.mutex.Unlock()
+
+	return t.screen.Write(b)
+}
@@ -0,0 +1,106 @@
+package prompttest
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/jedib0t/go-prompter/prompt"
+	"github.com/stretchr/testify/assert"
+)
+
+func generateTestTerminal(t *testing.T) (*Terminal, prompt.Prompter) {
+	p, err := prompt.New()
+	assert.Nil(t, err)
+	p.SetPrefix("> ")
+	p.Style().Cursor.Blink = false
+
+	term, err := NewTerminal(p, 40, 6)
+	assert.Nil(t, err)
+	if err != nil {
+		t.FailNow()
+	}
+	return term, p
+}
+
+func TestTerminal(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second*10)
+	defer cancel()
+
+	term, p := generateTestTerminal(t)
+	defer term.Close()
+	p.SetTerminationChecker(prompt.TerminationCheckerSQL())
+
+	assert.Nil(t, term.Prompt(ctx, time.Second*5))
+	assert.Nil(t, term.Send("select 1", prompt.Enter, "from dual"))
+	assert.Nil(t, term.WaitForText("from dual", time.Second*5))
+	assert.Equal(t, []string{"> select 1", "> from dual", "", "", "", ""}, term.Screen().Lines())
+	assert.Equal(t, "6", colorString(term.Screen().Cell(1, 11).Style.Background))
+	MatchGolden(t, term.Screen(), "testdata/terminal.golden")
+	assert.False(t, term.Screen().CursorVisible()) // painted on to the text
+
+	assert.Nil(t, term.Send(";", prompt.Enter))
+	userInput, err := term.Result(time.Second * 5)
+	assert.Nil(t, err)
+	assert.Equal(t, "select 1\nfrom dual;", userInput)
+	row, col := term.Screen().Cursor()
+	assert.Equal(t, 2, row)
+	assert.Equal(t, 0, col)
+	assert.True(t, term.Screen().CursorVisible())
+}
+
+func TestTerminal_TerminalCursor(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second*10)
+	defer cancel()
+	t.Setenv("TERM", "xterm-256color")
+
+	term, p := generateTestTerminal(t)
+	defer term.Close()
+	p.Style().Cursor = prompt.StyleCursorTerminal
+
+	assert.Nil(t, term.Prompt(ctx, time.Second*5))
+	assert.Nil(t, term.Send("abc"))
+	assert.Nil(t, term.WaitForText("abc", time.Second*5))
+	<-time.After(time.Second / 10) // nothing else should be drawing over it
+	assert.Equal(t, "> abc", term.Screen().Lines()[0])
+	assert.True(t, term.Screen().CursorVisible())
+	row, col := term.Screen().Cursor()
+	assert.Equal(t, 0, row)
+	assert.Equal(t, 5, col)
+
+	assert.Nil(t, term.Send(prompt.Enter))
+	userInput, err := term.Result(time.Second * 5)
+	assert.Nil(t, err)
+	assert.Equal(t, "abc", userInput)
+	assert.Equal(t, "> abc", term.Screen().Lines()[0])
+	assert.True(t, term.Screen().CursorVisible())
+	row, col = term.Screen().Cursor()
+	assert.Equal(t, 1, row)
+	assert.Equal(t, 0, col)
+}
+
+func TestTerminal_Result(t *testing.T) {
+	term, _ := generateTestTerminal(t)
+	defer term.Close()
+
+	_, err := term.Result(time.Millisecond)
+	assert.True(t, errors.Is(err, ErrNotPrompting))
+}
+
+func TestTerminal_WaitForText(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second*10)
+	defer cancel()
+
+	term, _ := generateTestTerminal(t)
+	defer term.Close()
+
+	assert.Nil(t, term.Prompt(ctx, time.Second*5))
+	err := term.WaitForText("foo", time.Millisecond*50)
+	assert.True(t, errors.Is(err, ErrTimeout))
+	assert.Contains(t, err.Error(), "waiting for \"foo\"")
+
+	cancel()
+	_, err = term.Result(time.Second * 5)
+	assert.NotNil(t, err)
+}